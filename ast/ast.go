@@ -0,0 +1,38 @@
+// Package ast declares the types used to represent syntax trees of Fract code.
+package ast
+
+import "github.com/fract-lang/fract/pkg/obj"
+
+// Node of syntax tree.
+type Node interface {
+	Token() obj.Token // Token for position of node.
+}
+
+// Expr is value expression node.
+type Expr interface {
+	Node
+	expr()
+}
+
+// Stmt is statement node.
+type Stmt interface {
+	Node
+	stmt()
+}
+
+// Block of statements.
+type Block struct {
+	Tk    obj.Token // Open brace.
+	Stmts []Stmt
+}
+
+func (b *Block) Token() obj.Token { return b.Tk }
+func (*Block) stmt()              {}
+
+// Param of function.
+type Param struct {
+	Name    obj.Token
+	Type    string // "", "var", "mut", "const", "const var" or "const mut".
+	Params  bool
+	Default Expr // Nil if not given.
+}
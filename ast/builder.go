@@ -0,0 +1,991 @@
+package ast
+
+import (
+	"strings"
+
+	"github.com/fract-lang/fract/pkg/diag"
+	"github.com/fract-lang/fract/pkg/fract"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// Builder of syntax tree.
+type builder struct {
+	tokens    [][]obj.Token
+	index     int
+	loopCount int
+	funcCount int
+	yields    *bool      // Set if yield is used in function in process, nil if not in function.
+	diags     *diag.List // Panics of statements are added to diagnostics if not nil.
+}
+
+// Build returns syntax tree of statement tokens.
+func Build(tokens [][]obj.Token) *Block {
+	b := &builder{tokens: tokens}
+	return &Block{Stmts: b.build()}
+}
+
+// BuildDiags returns syntax tree of statement tokens.
+// Statements with errors are skipped and errors are added to diagnostics.
+func BuildDiags(tokens [][]obj.Token, diags *diag.List) *Block {
+	b := &builder{tokens: tokens, diags: diags}
+	return &Block{Stmts: b.build()}
+}
+
+// build returns statements of all tokens.
+func (b *builder) build() []Stmt {
+	var stmts []Stmt
+	for b.index = 0; b.index < len(b.tokens); b.index++ {
+		if b.diags == nil {
+			stmts = append(stmts, b.buildStmt(b.tokens[b.index]))
+			continue
+		}
+		tokens := b.tokens[b.index]
+		b.diags.Run(func() { stmts = append(stmts, b.buildStmt(tokens)) })
+	}
+	return stmts
+}
+
+// isValidName returns true if name is valid, returns false if not.
+func isValidName(name string) bool { return name != "_" && name != "this" }
+
+// findBlock returns start index of block if found, panics if not.
+func findBlock(tokens []obj.Token) int {
+	braceCount := 0
+	for i, tk := range tokens {
+		switch tk.Val {
+		case "[", "(":
+			braceCount++
+		case "]", ")":
+			braceCount--
+		case "{":
+			if braceCount == 0 {
+				return i
+			}
+		}
+	}
+	fract.IPanic(tokens[0], obj.SyntaxPanic, "Block is not given!")
+	return -1
+}
+
+// closeIndex returns index of close brace of open brace at specified index.
+func closeIndex(tokens []obj.Token, index int) int {
+	braceCount := 0
+	for i := index; i < len(tokens); i++ {
+		tk := tokens[i]
+		if tk.Type != fract.Brace {
+			continue
+		}
+		switch tk.Val {
+		case "{", "[", "(":
+			braceCount++
+		default:
+			braceCount--
+		}
+		if braceCount == 0 {
+			return i
+		}
+	}
+	fract.IPanic(tokens[index], obj.SyntaxPanic, "Invalid syntax!")
+	return -1
+}
+
+// splitBlock returns statement tokens of block tokens with braces.
+func splitBlock(tokens []obj.Token) [][]obj.Token {
+	var lines [][]obj.Token
+	tokens = tokens[1 : len(tokens)-1]
+	if len(tokens) == 0 {
+		return lines
+	}
+	line := tokens[0].Line
+	braceCount := 0
+	last := 0
+	for j, tk := range tokens {
+		if tk.Type == fract.Brace {
+			switch tk.Val {
+			case "{", "[", "(":
+				braceCount++
+			default:
+				braceCount--
+				line = tk.Line
+			}
+		}
+		if braceCount > 0 {
+			continue
+		}
+		if tk.Type == fract.StatementTerminator {
+			if j > last {
+				lines = append(lines, tokens[last:j])
+			}
+			last = j + 1
+			line = tk.Line
+			continue
+		}
+		if line < tk.Line {
+			if j > last {
+				lines = append(lines, tokens[last:j])
+			}
+			last = j
+			line = tk.Line
+		}
+	}
+	if last < len(tokens) {
+		lines = append(lines, tokens[last:])
+	}
+	return lines
+}
+
+// decomposeComma returns parts of tokens separated by commas.
+// Last comma is ignored if allowLast is true.
+func decomposeComma(tokens []obj.Token, allowLast bool) [][]obj.Token {
+	var (
+		parts      [][]obj.Token
+		braceCount int
+		last       int
+	)
+	for i, tk := range tokens {
+		switch tk.Type {
+		case fract.Brace:
+			switch tk.Val {
+			case "{", "[", "(":
+				braceCount++
+			default:
+				braceCount--
+			}
+		case fract.Comma:
+			if braceCount != 0 {
+				break
+			}
+			if i-last == 0 {
+				fract.IPanic(tk, obj.SyntaxPanic, "Value is not given!")
+			}
+			parts = append(parts, tokens[last:i])
+			last = i + 1
+		}
+	}
+	if last < len(tokens) {
+		parts = append(parts, tokens[last:])
+	} else if !allowLast && len(tokens) > 0 {
+		fract.IPanic(tokens[len(tokens)-1], obj.SyntaxPanic, "Value is not given!")
+	}
+	return parts
+}
+
+// getBlock returns block of tokens. Tokens after block are
+// inserted as next statement.
+func (b *builder) getBlock(tokens []obj.Token) *Block {
+	if len(tokens) == 0 {
+		b.index++
+		if b.index >= len(b.tokens) {
+			last := b.tokens[b.index-1]
+			fract.IPanic(last[len(last)-1], obj.SyntaxPanic, "Block is not given!")
+		}
+		tokens = b.tokens[b.index]
+	}
+	if tokens[0].Type != fract.Brace || tokens[0].Val != "{" {
+		fract.IPanic(tokens[0], obj.SyntaxPanic, "Invalid syntax!")
+	}
+	i := closeIndex(tokens, 0)
+	if i < len(tokens)-1 {
+		b.tokens = append(b.tokens[:b.index+1], append([][]obj.Token{tokens[i+1:]}, b.tokens[b.index+1:]...)...)
+	}
+	return b.buildBlock(tokens[:i+1])
+}
+
+// buildBlock returns block of tokens with braces.
+func (b *builder) buildBlock(tokens []obj.Token) *Block {
+	sub := &builder{
+		tokens:    splitBlock(tokens),
+		loopCount: b.loopCount,
+		funcCount: b.funcCount,
+		yields:    b.yields,
+		diags:     b.diags,
+	}
+	return &Block{Tk: tokens[0], Stmts: sub.build()}
+}
+
+// next returns next statement tokens if first token type of
+// statement is same with specified type, returns nil if not.
+func (b *builder) next(typ uint8) []obj.Token {
+	if b.index+1 >= len(b.tokens) {
+		return nil
+	}
+	if tokens := b.tokens[b.index+1]; tokens[0].Type == typ {
+		b.index++
+		return tokens
+	}
+	return nil
+}
+
+//! A change added here(especially added a code block) must also be compatible with "Import" of parser.
+
+// buildStmt returns statement of tokens.
+func (b *builder) buildStmt(tokens []obj.Token) Stmt {
+	switch first := tokens[0]; first.Type {
+	case fract.Value, fract.Interpolation, fract.Brace, fract.Name:
+		if first.Type == fract.Name {
+			braceCount := 0
+			for i, tk := range tokens {
+				if tk.Type == fract.Brace {
+					switch tk.Val {
+					case "{", "[", "(":
+						braceCount++
+					default:
+						braceCount--
+					}
+				}
+				if braceCount > 0 || tk.Type != fract.Operator {
+					continue
+				}
+				switch tk.Val {
+				case "=", "+=", "-=", "*=", "/=", "%=", "^=", "<<=", ">>=", "|=", "&=", "**=":
+					return b.buildAssign(tokens, i)
+				case ":=":
+					return b.buildShortVarDecl(tokens, i)
+				case "<-":
+					return b.buildSend(tokens, i)
+				}
+			}
+		}
+		return &ExprStmt{X: b.buildExpr(tokens)}
+	case fract.Operator:
+		if first.Val == "<-" { // Receive.
+			return &ExprStmt{X: b.buildExpr(tokens)}
+		}
+	case fract.Var:
+		return b.buildVarDecl(tokens)
+	case fract.If:
+		return b.buildIf(tokens)
+	case fract.Match:
+		return b.buildMatch(tokens)
+	case fract.Select:
+		return b.buildSelect(tokens)
+	case fract.Loop:
+		return b.buildLoop(tokens)
+	case fract.Break:
+		if b.loopCount < 1 {
+			fract.IPanic(first, obj.SyntaxPanic, "Break keyword only used in loops!")
+		} else if len(tokens) > 1 {
+			fract.IPanic(tokens[1], obj.SyntaxPanic, "Invalid syntax!")
+		}
+		return &Break{Tk: first}
+	case fract.Continue:
+		if b.loopCount < 1 {
+			fract.IPanic(first, obj.SyntaxPanic, "Continue keyword only used in loops!")
+		} else if len(tokens) > 1 {
+			fract.IPanic(tokens[1], obj.SyntaxPanic, "Invalid syntax!")
+		}
+		return &Continue{Tk: first}
+	case fract.Return:
+		if b.funcCount < 1 {
+			fract.IPanic(first, obj.SyntaxPanic, "Return keyword only used in functions!")
+		}
+		ret := &Return{Tk: first}
+		for _, part := range decomposeComma(tokens[1:], false) {
+			ret.Vals = append(ret.Vals, b.buildExpr(part))
+		}
+		return ret
+	case fract.Yield:
+		if b.yields == nil {
+			fract.IPanic(first, obj.SyntaxPanic, "Yield keyword only used in functions!")
+		} else if len(tokens) < 2 {
+			fract.IPanicC(first.File, first.Line, first.Column+len(first.Val), obj.SyntaxPanic, "Value is not given!")
+		}
+		*b.yields = true
+		return &Yield{Tk: first, Val: b.buildExpr(tokens[1:])}
+	case fract.Func:
+		return b.buildFuncDecl(tokens)
+	case fract.Try:
+		return b.buildTryCatch(tokens)
+	case fract.Import:
+		return b.buildImport(tokens)
+	case fract.Macro:
+		if len(tokens) < 2 || tokens[1].Type != fract.Name {
+			fract.IPanic(first, obj.SyntaxPanic, "Invalid pragma!")
+		}
+		pragma := &Pragma{Tk: first, Name: tokens[1]}
+		switch tokens[1].Val {
+		case "enofi":
+			if len(tokens) > 2 {
+				fract.IPanic(tokens[2], obj.SyntaxPanic, "Invalid syntax!")
+			}
+		case "nolint": // IDs of analyzers are optional.
+			for _, part := range decomposeComma(tokens[2:], false) {
+				if len(part) != 1 || part[0].Type != fract.Name {
+					fract.IPanic(part[0], obj.SyntaxPanic, "Invalid syntax!")
+				}
+				pragma.Args = append(pragma.Args, part[0])
+			}
+		default:
+			fract.IPanic(tokens[1], obj.SyntaxPanic, "Invalid pragma!")
+		}
+		return pragma
+	case fract.Struct:
+		return b.buildStructDecl(tokens)
+	case fract.Class:
+		return b.buildClassDecl(tokens)
+	case fract.Defer, fract.Go:
+		if l := len(tokens); l < 2 {
+			fract.IPanic(first, obj.SyntaxPanic, "Function is not given!")
+		} else if t := tokens[l-1]; t.Type != fract.Brace || t.Val != ")" {
+			fract.IPanicC(first.File, first.Line, first.Column+len(first.Val), obj.SyntaxPanic, "Invalid syntax!")
+		}
+		call, ok := b.buildExpr(tokens[1:]).(*Call)
+		if !ok {
+			fract.IPanic(tokens[1], obj.SyntaxPanic, "Invalid syntax!")
+		}
+		if first.Type == fract.Defer {
+			return &Defer{Tk: first, Call: call}
+		}
+		return &Go{Tk: first, Call: call}
+	}
+	fract.IPanic(tokens[0], obj.SyntaxPanic, "Invalid syntax!")
+	return nil
+}
+
+func (b *builder) buildAssign(tokens []obj.Token, setterIndex int) Stmt {
+	setter := tokens[setterIndex]
+	if setterIndex+1 >= len(tokens) {
+		fract.IPanicC(setter.File, setter.Line, setter.Column+len(setter.Val), obj.SyntaxPanic, "Value is not given!")
+	}
+	return &Assign{
+		Target: b.buildExpr(tokens[:setterIndex]),
+		Setter: setter,
+		Val:    b.buildExpr(tokens[setterIndex+1:]),
+	}
+}
+
+func (b *builder) buildShortVarDecl(tokens []obj.Token, setterIndex int) Stmt {
+	setter := tokens[setterIndex]
+	decl := &ShortVarDecl{Setter: setter}
+	var name ShortName
+	for _, tk := range tokens[:setterIndex] {
+		switch tk.Type {
+		case fract.Var:
+			if name.Name.Val == "" || tk.Val == "var" {
+				fract.IPanic(tk, obj.SyntaxPanic, "Invalid syntax!")
+			} else if name.Type != "" {
+				fract.IPanic(tk, obj.SyntaxPanic, "Type repetition!")
+			}
+			name.Type = tk.Val
+		case fract.Name:
+			if name.Name.Val != "" {
+				fract.IPanic(tk, obj.SyntaxPanic, "Invalid syntax!")
+			}
+			if tk.Val != "_" {
+				if !isValidName(tk.Val) {
+					fract.IPanic(tk, obj.SyntaxPanic, "Invalid name!")
+				}
+				for _, n := range decl.Names {
+					if n.Name.Val == tk.Val {
+						fract.IPanic(tk, obj.NamePanic, "Name duplicate!")
+					}
+				}
+			}
+			name.Name = tk
+		case fract.Comma:
+			if name.Name.Val == "" {
+				fract.IPanic(tk, obj.SyntaxPanic, "Invalid syntax!")
+			}
+			decl.Names = append(decl.Names, name)
+			name = ShortName{}
+		default:
+			fract.IPanic(tk, obj.SyntaxPanic, "Invalid syntax!")
+		}
+	}
+	if name.Name.Val == "" {
+		fract.IPanic(setter, obj.SyntaxPanic, "Name is not given!")
+	}
+	decl.Names = append(decl.Names, name)
+	if len(decl.Names) == 1 && decl.Names[0].Name.Val == "_" {
+		fract.IPanic(tokens[0], obj.SyntaxPanic, "Invalid name!")
+	}
+	if setterIndex+1 >= len(tokens) {
+		fract.IPanic(setter, obj.SyntaxPanic, "Value is not given!")
+	}
+	for _, part := range decomposeComma(tokens[setterIndex+1:], false) {
+		decl.Vals = append(decl.Vals, b.buildExpr(part))
+	}
+	return decl
+}
+
+func (b *builder) buildSend(tokens []obj.Token, opIndex int) *Send {
+	op := tokens[opIndex]
+	if opIndex+1 >= len(tokens) {
+		fract.IPanicC(op.File, op.Line, op.Column+len(op.Val), obj.SyntaxPanic, "Value is not given!")
+	}
+	return &Send{
+		Ch:  b.buildExpr(tokens[:opIndex]),
+		Tk:  op,
+		Val: b.buildExpr(tokens[opIndex+1:]),
+	}
+}
+
+// buildVarSpec returns variable of declaration.
+func (b *builder) buildVarSpec(tokens []obj.Token) VarSpec {
+	nameTk := tokens[0]
+	if nameTk.Type != fract.Name || !isValidName(nameTk.Val) {
+		fract.IPanic(nameTk, obj.SyntaxPanic, "Invalid name!")
+	}
+	// Setter is not defined?
+	if len(tokens) < 2 {
+		fract.IPanicC(nameTk.File, nameTk.Line, nameTk.Column+len(nameTk.Val), obj.SyntaxPanic, "Setter is not found!")
+	}
+	setter := tokens[1]
+	// Setter is not a setter operator?
+	if setter.Type != fract.Operator || setter.Val != "=" {
+		fract.IPanic(setter, obj.SyntaxPanic, "Invalid setter operator: "+setter.Val)
+	}
+	// Value is not defined?
+	if len(tokens) < 3 {
+		fract.IPanicC(setter.File, setter.Line, setter.Column+len(setter.Val), obj.SyntaxPanic, "Value is not given!")
+	}
+	return VarSpec{Name: nameTk, Setter: setter, Val: b.buildExpr(tokens[2:])}
+}
+
+func (b *builder) buildVarDecl(tokens []obj.Token) *VarDecl {
+	first := tokens[0]
+	// Name is not defined?
+	if len(tokens) < 2 {
+		fract.IPanicC(first.File, first.Line, first.Column+len(first.Val), obj.SyntaxPanic, "Name is not given!")
+	}
+	decl := &VarDecl{Tk: first}
+	switch pre := tokens[1]; {
+	case pre.Type == fract.Name:
+		decl.Specs = append(decl.Specs, b.buildVarSpec(tokens[1:]))
+	case pre.Type == fract.Brace && pre.Val == "(":
+		if closeIndex(tokens, 1) != len(tokens)-1 {
+			fract.IPanic(tokens[len(tokens)-1], obj.SyntaxPanic, "Invalid syntax!")
+		}
+		for _, line := range splitBlock(tokens[1:]) {
+			decl.Specs = append(decl.Specs, b.buildVarSpec(line))
+		}
+	default:
+		fract.IPanic(pre, obj.SyntaxPanic, "Invalid syntax!")
+	}
+	return decl
+}
+
+func (b *builder) buildIf(tokens []obj.Token) *If {
+	blockIndex := findBlock(tokens)
+	condTokens := tokens[1:blockIndex]
+	// Condition is empty?
+	if len(condTokens) == 0 {
+		first := tokens[0]
+		fract.IPanicC(first.File, first.Line, first.Column+len(first.Val), obj.SyntaxPanic, "Condition is empty!")
+	}
+	stmt := &If{
+		Tk:   tokens[0],
+		Cond: b.buildExpr(condTokens),
+		Body: b.getBlock(tokens[blockIndex:]),
+	}
+	if tokens = b.next(fract.Else); tokens == nil {
+		return stmt
+	}
+	if len(tokens) > 1 && tokens[1].Type == fract.If { // Else if.
+		stmt.Else = b.buildIf(tokens[1:])
+	} else {
+		stmt.Else = b.getBlock(tokens[1:])
+	}
+	return stmt
+}
+
+func (b *builder) buildLoop(tokens []obj.Token) *Loop {
+	blockIndex := findBlock(tokens)
+	loop := &Loop{Tk: tokens[0]}
+	b.loopCount++
+	loop.Body = b.getBlock(tokens[blockIndex:])
+	b.loopCount--
+	tokens = tokens[1:blockIndex]
+	// Infinity loop.
+	if len(tokens) == 0 {
+		return loop
+	}
+	// While loop.
+	if len(tokens) == 1 || tokens[1].Type != fract.In && tokens[1].Type != fract.Comma {
+		loop.Cond = b.buildExpr(tokens)
+		return loop
+	}
+	// Foreach loop.
+	loop.Key = tokens[0]
+	if loop.Key.Type != fract.Name {
+		fract.IPanic(loop.Key, obj.SyntaxPanic, "This is not a valid name!")
+	} else if loop.Key.Val != "_" && !isValidName(loop.Key.Val) {
+		fract.IPanic(loop.Key, obj.NamePanic, "Invalid name!")
+	}
+	// Element name?
+	if tokens[1].Type == fract.Comma {
+		if len(tokens) < 3 || tokens[2].Type != fract.Name {
+			fract.IPanic(tokens[1], obj.SyntaxPanic, "Element name is not defined!")
+		}
+		loop.Elem = tokens[2]
+		if loop.Elem.Val != "_" && !isValidName(loop.Elem.Val) {
+			fract.IPanic(loop.Elem, obj.NamePanic, "Invalid name!")
+		}
+		if len(tokens)-3 == 0 {
+			tokens[2].Column += len(tokens[2].Val)
+			fract.IPanic(tokens[2], obj.SyntaxPanic, "Value is not given!")
+		}
+		tokens = tokens[2:]
+	}
+	if len(tokens) < 3 {
+		fract.IPanic(tokens[1], obj.SyntaxPanic, "Value is not given!")
+	} else if t := tokens[1]; t.Type != fract.In {
+		fract.IPanic(tokens[1], obj.SyntaxPanic, "Invalid syntax!")
+	}
+	loop.Iter = b.buildExpr(tokens[2:])
+	return loop
+}
+
+// buildParams returns parameters of tokens.
+func (b *builder) buildParams(fnName string, tokens []obj.Token) []Param {
+	var (
+		params     []Param
+		param      Param
+		paramName  = true
+		defaultDef = false
+	)
+	for i := 0; i < len(tokens); i++ {
+		tk := tokens[i]
+		if paramName {
+			switch tk.Type {
+			case fract.Params:
+				if param.Params {
+					fract.IPanic(tk, obj.SyntaxPanic, "Invalid syntax!")
+				}
+				param.Params = true
+				continue
+			case fract.Name:
+				if !isValidName(tk.Val) {
+					fract.IPanic(tk, obj.NamePanic, "Invalid name!")
+				} else if tk.Val == fnName {
+					fract.IPanic(tk, obj.NamePanic, "Parameter name is not same with function name!")
+				}
+			case fract.Var:
+				if param.Type != "" || param.Params {
+					if !param.Params && param.Type == "const" && tk.Val != "const" {
+						param.Type += " " + tk.Val
+						continue
+					}
+					fract.IPanic(tk, obj.SyntaxPanic, "Invalid syntax!")
+				}
+				param.Type = tk.Val
+				continue
+			default:
+				fract.IPanic(tk, obj.SyntaxPanic, "Parameter name is not found!")
+			}
+			param.Name = tk
+			params = append(params, param)
+			paramName = false
+			continue
+		}
+		paramName = true
+		// Default value definition?
+		if tk.Val == "=" {
+			braceCount := 0
+			i++
+			start := i
+			for ; i < len(tokens); i++ {
+				tk = tokens[i]
+				if tk.Type == fract.Brace {
+					switch tk.Val {
+					case "{", "[", "(":
+						braceCount++
+					default:
+						braceCount--
+					}
+				} else if tk.Type == fract.Comma && braceCount == 0 {
+					break
+				}
+			}
+			if i-start < 1 {
+				fract.IPanic(tokens[start-1], obj.SyntaxPanic, "Value is not given!")
+			}
+			params[len(params)-1].Default = b.buildExpr(tokens[start:i])
+			defaultDef = true
+		} else if defaultDef {
+			fract.IPanic(tk, obj.SyntaxPanic, "All parameters after a given parameter with a default value must take a default value!")
+		} else if tk.Type != fract.Comma {
+			fract.IPanic(tk, obj.SyntaxPanic, "Comma is not found!")
+		}
+		param = Param{}
+	}
+	if defaultDef && params[len(params)-1].Default == nil {
+		fract.IPanic(tokens[len(tokens)-1], obj.SyntaxPanic, "All parameters after a given parameter with a default value must take a default value!")
+	}
+	return params
+}
+
+// buildFunc returns parameters and body of function.
+// Returns true as generator if body has yield statement.
+// Tokens are starts with parameters or block.
+func (b *builder) buildFunc(fnName string, tokens []obj.Token, getBlock func([]obj.Token) *Block) ([]Param, *Block, bool) {
+	var params []Param
+	if len(tokens) > 0 && tokens[0].Type == fract.Brace && tokens[0].Val == "(" {
+		i := closeIndex(tokens, 0)
+		params = b.buildParams(fnName, tokens[1:i])
+		tokens = tokens[i+1:]
+	}
+	loopCount, yields := b.loopCount, b.yields
+	generator := false
+	b.loopCount, b.yields = 0, &generator
+	b.funcCount++
+	body := getBlock(tokens)
+	b.funcCount--
+	b.loopCount, b.yields = loopCount, yields
+	return params, body, generator
+}
+
+func (b *builder) buildFuncDecl(tokens []obj.Token) *FuncDecl {
+	if len(tokens) < 2 {
+		fract.IPanic(tokens[0], obj.SyntaxPanic, "Invalid syntax!")
+	}
+	nameTk := tokens[1]
+	// Name is not name?
+	if nameTk.Type != fract.Name || !isValidName(nameTk.Val) {
+		fract.IPanic(nameTk, obj.SyntaxPanic, "Invalid name!")
+	}
+	if len(tokens) < 3 {
+		fract.IPanicC(nameTk.File, nameTk.Line, nameTk.Column+len(nameTk.Val), obj.SyntaxPanic, "Invalid syntax!")
+	}
+	decl := &FuncDecl{Tk: tokens[0], Name: nameTk}
+	decl.Params, decl.Body, decl.Generator = b.buildFunc(nameTk.Val, tokens[2:], b.getBlock)
+	return decl
+}
+
+func (b *builder) buildTryCatch(tokens []obj.Token) *TryCatch {
+	stmt := &TryCatch{Tk: tokens[0], Try: b.getBlock(tokens[1:])}
+	for tokens = b.next(fract.Catch); tokens != nil; tokens = b.next(fract.Catch) {
+		stmt.Catches = append(stmt.Catches, b.buildCatch(tokens))
+	}
+	if tokens = b.next(fract.Finally); tokens != nil {
+		stmt.Finally = b.getBlock(tokens[1:])
+	}
+	return stmt
+}
+
+// buildCatch builds catch clause.
+// Clause is catches panics of type if two names are given.
+func (b *builder) buildCatch(tokens []obj.Token) *Catch {
+	c := &Catch{Tk: tokens[0]}
+	if len(tokens) < 2 {
+		fract.IPanic(tokens[0], obj.SyntaxPanic, "Invalid syntax!")
+	}
+	i := 1
+	for ; i < len(tokens) && i < 3 && tokens[i].Type == fract.Name; i++ {
+	}
+	switch i {
+	case 2:
+		c.Name = tokens[1]
+	case 3:
+		c.Type = tokens[1]
+		c.Name = tokens[2]
+		if !isValidName(c.Type.Val) {
+			fract.IPanic(c.Type, obj.NamePanic, "Invalid panic type!")
+		}
+	}
+	// Panic is not bind to variable if name is "_".
+	if c.Name.Val == "_" {
+		c.Name = obj.Token{}
+	} else if c.Name.Val != "" && !isValidName(c.Name.Val) {
+		fract.IPanic(c.Name, obj.NamePanic, "Invalid name!")
+	}
+	if i >= len(tokens) {
+		fract.IPanic(tokens[i-1], obj.SyntaxPanic, "Invalid syntax!")
+	}
+	c.Body = b.getBlock(tokens[i:])
+	return c
+}
+
+func (b *builder) buildImport(tokens []obj.Token) *Import {
+	if len(tokens) == 1 {
+		fract.IPanic(tokens[0], obj.SyntaxPanic, "Import path is not given!")
+	}
+	if tokens[1].Type != fract.Name && (tokens[1].Type != fract.Value || tokens[1].Val[0] != '"' && tokens[1].Val[0] != '.') {
+		fract.IPanic(tokens[1], obj.ValuePanic, "Import path should be string or standard path!")
+	}
+	tokens = joinPath(tokens)
+	stmt := &Import{Tk: tokens[0]}
+	j := 1
+	if len(tokens) > 2 {
+		if tokens[1].Type == fract.Name && !strings.Contains(tokens[1].Val, ".") {
+			j = 2
+			stmt.Alias = tokens[1]
+		} else {
+			fract.IPanic(tokens[1], obj.NamePanic, "Alias is should be a invalid name!")
+		}
+	}
+	if j == 2 && len(tokens) != 3 {
+		fract.IPanic(tokens[3], obj.SyntaxPanic, "Invalid syntax!")
+	} else if tk := tokens[j]; tk.Type != fract.Name && (tk.Type != fract.Value || tk.Val[0] != '"' && tk.Val[0] != '.') {
+		fract.IPanic(tk, obj.ValuePanic, "Import path should be string or standard path!")
+	}
+	stmt.Path = tokens[j]
+	return stmt
+}
+
+// joinPath returns tokens that dotted standard path is joined as one name.
+func joinPath(tokens []obj.Token) []obj.Token {
+	var joined []obj.Token
+	for i := 0; i < len(tokens); i++ {
+		tk := tokens[i]
+		if tk.Type == fract.Name {
+			for ; i+2 < len(tokens) && tokens[i+1].Type == fract.Dot && tokens[i+2].Type == fract.Name; i += 2 {
+				tk.Val += "." + tokens[i+2].Val
+			}
+		}
+		joined = append(joined, tk)
+	}
+	return joined
+}
+
+// buildFields returns field names of struct block.
+func buildFields(tokens []obj.Token) []obj.Token {
+	var fields []obj.Token
+	for _, tokens := range splitBlock(tokens) {
+		var comma bool
+		for _, tk := range tokens {
+			switch tk.Type {
+			case fract.Comma:
+				if !comma {
+					fract.IPanic(tk, obj.SyntaxPanic, "Invalid syntax!")
+				}
+				comma = false
+			case fract.Name:
+				if !isValidName(tk.Val) {
+					fract.IPanic(tk, obj.NamePanic, "Invalid name!")
+				}
+				if comma {
+					fract.IPanic(tk, obj.SyntaxPanic, "Invalid syntax!")
+				}
+				for _, field := range fields {
+					if field.Val == tk.Val {
+						fract.IPanic(tk, obj.NamePanic, "Field is already defined: "+tk.Val)
+					}
+				}
+				fields = append(fields, tk)
+				comma = true
+			default:
+				fract.IPanic(tk, obj.SyntaxPanic, "Invalid syntax!")
+			}
+		}
+	}
+	return fields
+}
+
+// getBlockTokens returns tokens of block with braces.
+// Tokens after block are inserted as next statement.
+func (b *builder) getBlockTokens(tokens []obj.Token) []obj.Token {
+	if len(tokens) == 0 || tokens[0].Type != fract.Brace || tokens[0].Val != "{" {
+		first := b.tokens[b.index][0]
+		fract.IPanic(first, obj.SyntaxPanic, "Invalid syntax!")
+	}
+	i := closeIndex(tokens, 0)
+	if i < len(tokens)-1 {
+		b.tokens = append(b.tokens[:b.index+1], append([][]obj.Token{tokens[i+1:]}, b.tokens[b.index+1:]...)...)
+	}
+	return tokens[:i+1]
+}
+
+func (b *builder) buildStructDecl(tokens []obj.Token) *StructDecl {
+	if len(tokens) < 2 {
+		fract.IPanic(tokens[0], obj.SyntaxPanic, "Invalid syntax!")
+	}
+	nameTk := tokens[1]
+	if nameTk.Type != fract.Name || !isValidName(nameTk.Val) {
+		fract.IPanic(nameTk, obj.SyntaxPanic, "Name is not valid!")
+	}
+	return &StructDecl{Tk: tokens[0], Name: nameTk, Fields: buildFields(b.getBlockTokens(tokens[2:]))}
+}
+
+func (b *builder) buildClassDecl(tokens []obj.Token) *ClassDecl {
+	if len(tokens) < 2 {
+		fract.IPanic(tokens[0], obj.SyntaxPanic, "Invalid syntax!")
+	}
+	nameTk := tokens[1]
+	if nameTk.Type != fract.Name || !isValidName(nameTk.Val) {
+		fract.IPanic(nameTk, obj.SyntaxPanic, "Name is not valid!")
+	}
+	decl := &ClassDecl{Tk: tokens[0], Name: nameTk}
+	sub := &builder{tokens: splitBlock(b.getBlockTokens(tokens[2:])), diags: b.diags}
+	for sub.index = 0; sub.index < len(sub.tokens); sub.index++ {
+		switch tokens := sub.tokens[sub.index]; tokens[0].Type {
+		case fract.Var:
+			decl.Vars = append(decl.Vars, sub.buildVarDecl(tokens))
+		case fract.Func:
+			decl.Funcs = append(decl.Funcs, sub.buildFuncDecl(tokens))
+		default:
+			fract.IPanic(tokens[0], obj.SyntaxPanic, "Invalid syntax!")
+		}
+	}
+	return decl
+}
+
+// buildPattern returns pattern of match case.
+func (b *builder) buildPattern(tokens []obj.Token) Expr {
+	first, last := tokens[0], tokens[len(tokens)-1]
+	switch {
+	case first.Type == fract.Operator && first.Val == "^":
+		if len(tokens) == 1 {
+			fract.IPanicC(first.File, first.Line, first.Column+1, obj.SyntaxPanic, "Value is not given!")
+		}
+		return &Pin{Tk: first, Expr: b.buildExpr(tokens[1:])}
+	case first.Type == fract.Brace && first.Val == "[" && closeIndex(tokens, 0) == len(tokens)-1:
+		list := &List{Tk: first}
+		parts := decomposeComma(tokens[1:len(tokens)-1], false)
+		for i, part := range parts {
+			// Rest of list.
+			if plast := part[len(part)-1]; plast.Type == fract.Params {
+				if i != len(parts)-1 {
+					fract.IPanic(plast, obj.SyntaxPanic, "Rest pattern is must be last!")
+				} else if len(part) != 2 || part[0].Type != fract.Name {
+					fract.IPanic(part[0], obj.SyntaxPanic, "Invalid syntax!")
+				}
+				list.Elems = append(list.Elems, &Rest{Name: part[0]})
+				continue
+			}
+			list.Elems = append(list.Elems, b.buildPattern(part))
+		}
+		return list
+	case last.Type == fract.Brace && last.Val == ")":
+		call, ok := b.buildExpr(tokens).(*Call)
+		if !ok {
+			break
+		}
+		// Build arguments as patterns.
+		i := len(tokens) - 1
+		for braceCount := 0; i >= 0; i-- {
+			if tk := tokens[i]; tk.Type == fract.Brace {
+				switch tk.Val {
+				case ")":
+					braceCount++
+				case "(":
+					braceCount--
+				}
+				if braceCount == 0 {
+					break
+				}
+			}
+		}
+		call.Args = nil
+		for _, part := range decomposeComma(tokens[i+1:len(tokens)-1], false) {
+			call.Args = append(call.Args, Arg{Val: b.buildPattern(part)})
+		}
+		return call
+	}
+	return b.buildExpr(tokens)
+}
+
+func (b *builder) buildMatch(tokens []obj.Token) *Match {
+	blockIndex := findBlock(tokens)
+	valTokens := tokens[1:blockIndex]
+	// Value is empty?
+	if len(valTokens) == 0 {
+		first := tokens[0]
+		fract.IPanicC(first.File, first.Line, first.Column+len(first.Val), obj.SyntaxPanic, "Value is not given!")
+	}
+	stmt := &Match{Tk: tokens[0], Val: b.buildExpr(valTokens)}
+	sub := &builder{
+		tokens:    splitBlock(b.getBlockTokens(tokens[blockIndex:])),
+		loopCount: b.loopCount,
+		funcCount: b.funcCount,
+		yields:    b.yields,
+		diags:     b.diags,
+	}
+	isDefault := false
+	for sub.index = 0; sub.index < len(sub.tokens); sub.index++ {
+		tokens := sub.tokens[sub.index]
+		if tokens[0].Type != fract.Case {
+			fract.IPanic(tokens[0], obj.SyntaxPanic, "Match block is can only contain cases!")
+		} else if isDefault {
+			fract.IPanic(tokens[0], obj.SyntaxPanic, "Case is unreachable after default case!")
+		}
+		blockIndex := findBlock(tokens)
+		if blockIndex == 1 {
+			first := tokens[0]
+			fract.IPanicC(first.File, first.Line, first.Column+len(first.Val), obj.SyntaxPanic, "Pattern is not given!")
+		}
+		c := &Case{Tk: tokens[0]}
+		for _, part := range decomposeComma(tokens[1:blockIndex], false) {
+			// Wildcard and names are match any value.
+			if len(part) == 1 && part[0].Type == fract.Name {
+				isDefault = true
+			}
+			c.Patterns = append(c.Patterns, sub.buildPattern(part))
+		}
+		blockTokens := tokens[blockIndex:]
+		if closeIndex(blockTokens, 0) != len(blockTokens)-1 {
+			fract.IPanic(blockTokens[closeIndex(blockTokens, 0)+1], obj.SyntaxPanic, "Invalid syntax!")
+		}
+		c.Body = sub.buildBlock(blockTokens)
+		stmt.Cases = append(stmt.Cases, c)
+	}
+	return stmt
+}
+
+// buildComm returns channel operation of select case.
+func (b *builder) buildComm(c *CommClause, tokens []obj.Token) {
+	// Receive with name.
+	if len(tokens) > 2 && tokens[0].Type == fract.Name && tokens[1].Val == ":=" {
+		if !isValidName(tokens[0].Val) {
+			fract.IPanic(tokens[0], obj.NamePanic, "Invalid name!")
+		}
+		c.Name = tokens[0]
+		tokens = tokens[2:]
+	}
+	switch s := b.buildStmt(tokens).(type) {
+	case *Send:
+		if c.Name.Val == "" {
+			c.Comm = s
+			return
+		}
+	case *ExprStmt:
+		if _, ok := s.X.(*Receive); ok {
+			c.Comm = s
+			return
+		}
+	}
+	fract.IPanic(tokens[0], obj.SyntaxPanic, "Case is must be send or receive operation!")
+}
+
+func (b *builder) buildSelect(tokens []obj.Token) *Select {
+	if len(tokens) < 2 {
+		first := tokens[0]
+		fract.IPanicC(first.File, first.Line, first.Column+len(first.Val), obj.SyntaxPanic, "Block is not given!")
+	}
+	stmt := &Select{Tk: tokens[0]}
+	sub := &builder{
+		tokens:    splitBlock(b.getBlockTokens(tokens[1:])),
+		loopCount: b.loopCount,
+		funcCount: b.funcCount,
+		yields:    b.yields,
+		diags:     b.diags,
+	}
+	isDefault := false
+	for sub.index = 0; sub.index < len(sub.tokens); sub.index++ {
+		tokens := sub.tokens[sub.index]
+		if tokens[0].Type != fract.Case {
+			fract.IPanic(tokens[0], obj.SyntaxPanic, "Select block is can only contain cases!")
+		}
+		blockIndex := findBlock(tokens)
+		if blockIndex == 1 {
+			first := tokens[0]
+			fract.IPanicC(first.File, first.Line, first.Column+len(first.Val), obj.SyntaxPanic, "Channel operation is not given!")
+		}
+		c := &CommClause{Tk: tokens[0]}
+		if comm := tokens[1:blockIndex]; len(comm) == 1 && comm[0].Val == "_" {
+			if isDefault {
+				fract.IPanic(comm[0], obj.SyntaxPanic, "Default case is already defined!")
+			}
+			isDefault = true
+		} else {
+			sub.buildComm(c, comm)
+		}
+		blockTokens := tokens[blockIndex:]
+		if closeIndex(blockTokens, 0) != len(blockTokens)-1 {
+			fract.IPanic(blockTokens[closeIndex(blockTokens, 0)+1], obj.SyntaxPanic, "Invalid syntax!")
+		}
+		c.Body = sub.buildBlock(blockTokens)
+		stmt.Cases = append(stmt.Cases, c)
+	}
+	return stmt
+}
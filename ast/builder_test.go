@@ -0,0 +1,166 @@
+package ast
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fract-lang/fract/lex"
+	"github.com/fract-lang/fract/pkg/diag"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// tokenize returns statement tokens of code.
+func tokenize(code string) [][]obj.Token {
+	l := &lex.Lex{File: &obj.File{Path: "test.fract", Lines: strings.Split(code, "\n")}, Line: 1}
+	var tokens [][]obj.Token
+	for !l.Finished {
+		if tks := l.Next(); tks != nil {
+			tokens = append(tokens, tks)
+		}
+	}
+	return tokens
+}
+
+// dump returns expression as text, processes are in prefix notation.
+func dump(e Expr) string {
+	switch t := e.(type) {
+	case *Value:
+		return t.Tk.Val
+	case *Name:
+		return t.Tk.Val
+	case *Binary:
+		return "(" + t.Op.Val + " " + dump(t.Left) + " " + dump(t.Right) + ")"
+	case *Compare:
+		return "(" + t.Op.Val + " " + dump(t.Left) + " " + dump(t.Right) + ")"
+	case *Logical:
+		return "(" + t.Op.Val + " " + dump(t.Left) + " " + dump(t.Right) + ")"
+	case *Selector:
+		return dump(t.X) + "." + t.Name.Val
+	case *Index:
+		return dump(t.X) + "[" + dump(t.Index) + "]"
+	case *Call:
+		args := []string{dump(t.Fn)}
+		for _, arg := range t.Args {
+			args = append(args, dump(arg.Val))
+		}
+		return "call(" + strings.Join(args, " ") + ")"
+	case *List:
+		var elems []string
+		for _, elem := range t.Elems {
+			elems = append(elems, dump(elem))
+		}
+		return "[" + strings.Join(elems, " ") + "]"
+	case *Rest:
+		return t.Name.Val + "..."
+	case *Pin:
+		return "^" + dump(t.Expr)
+	case *Receive:
+		return "<-" + dump(t.Ch)
+	}
+	return "?"
+}
+
+func TestBuildExpr(t *testing.T) {
+	tests := []struct{ code, want string }{
+		{"1 + 2 * 3", "(+ 1 (* 2 3))"},
+		{"(1 + 2) * 3", "(* (+ 1 2) 3)"},
+		{"1 - 2 - 3", "(- (- 1 2) 3)"},
+		{"a.b(1, c[0])", "call(a.b 1 c[0])"},
+		{"x > 1 && y || z", "(|| (&& (> x 1) y) z)"},
+		{"[1, [2, 3]]", "[1 [2 3]]"},
+		{"<-ch", "<-ch"},
+	}
+	for _, test := range tests {
+		tree := Build(tokenize("x := " + test.code))
+		decl, ok := tree.Stmts[0].(*ShortVarDecl)
+		if !ok {
+			t.Errorf("%s: got %T, want *ShortVarDecl", test.code, tree.Stmts[0])
+			continue
+		}
+		if got := dump(decl.Vals[0]); got != test.want {
+			t.Errorf("%s: got %s, want %s", test.code, got, test.want)
+		}
+	}
+}
+
+func TestBuildStmts(t *testing.T) {
+	const code = `if a {
+} else if b {
+} else {
+}
+for i, x in [1, 2] {
+    break
+}
+func f(a, b = 1) {
+    return a
+}
+try {
+} catch DivideByZeroPanic e {
+} finally {
+}
+match v {
+    case ^x, [h, t...] {}
+    case _ {}
+}`
+	stmts := Build(tokenize(code)).Stmts
+	if len(stmts) != 5 {
+		t.Fatalf("got %d statements, want 5", len(stmts))
+	}
+
+	s := stmts[0].(*If)
+	elif, ok := s.Else.(*If)
+	if !ok || dump(s.Cond) != "a" || dump(elif.Cond) != "b" {
+		t.Errorf("if: got %s else %T", dump(s.Cond), s.Else)
+	} else if _, ok := elif.Else.(*Block); !ok {
+		t.Errorf("else if: got else %T, want *Block", elif.Else)
+	}
+
+	loop := stmts[1].(*Loop)
+	if loop.Key.Val != "i" || loop.Elem.Val != "x" || dump(loop.Iter) != "[1 2]" || len(loop.Body.Stmts) != 1 {
+		t.Errorf("loop: got %s, %s in %s", loop.Key.Val, loop.Elem.Val, dump(loop.Iter))
+	}
+
+	fn := stmts[2].(*FuncDecl)
+	if fn.Name.Val != "f" || len(fn.Params) != 2 || fn.Generator {
+		t.Errorf("func: got %s with %d params", fn.Name.Val, len(fn.Params))
+	} else if ret, ok := fn.Body.Stmts[0].(*Return); !ok || dump(ret.Vals[0]) != "a" {
+		t.Errorf("func: got body %T", fn.Body.Stmts[0])
+	}
+
+	try := stmts[3].(*TryCatch)
+	if len(try.Catches) != 1 || try.Catches[0].Type.Val != "DivideByZeroPanic" ||
+		try.Catches[0].Name.Val != "e" || try.Finally == nil {
+		t.Errorf("try: got %d catches, finally %v", len(try.Catches), try.Finally != nil)
+	}
+
+	match := stmts[4].(*Match)
+	var patterns []string
+	for _, c := range match.Cases {
+		for _, p := range c.Patterns {
+			patterns = append(patterns, dump(p))
+		}
+	}
+	if got := strings.Join(patterns, ", "); got != "^x, [h t...], _" {
+		t.Errorf("match: got %s", got)
+	}
+}
+
+func TestBuildDiags(t *testing.T) {
+	const code = `x := 1
+return 2
+y := 3
+break`
+	var diags diag.List
+	stmts := BuildDiags(tokenize(code), &diags).Stmts
+	if len(stmts) != 2 {
+		t.Errorf("got %d statements, want 2", len(stmts))
+	}
+	if len(diags) != 2 {
+		t.Fatalf("got %d diagnostics, want 2", len(diags))
+	}
+	for i, line := range []int{2, 4} {
+		if diags[i].Span.Line != line {
+			t.Errorf("got diagnostic at line %d, want %d: %s", diags[i].Span.Line, line, diags[i].Message)
+		}
+	}
+}
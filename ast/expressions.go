@@ -0,0 +1,174 @@
+package ast
+
+import "github.com/fract-lang/fract/pkg/obj"
+
+// Types of literal values.
+// These are must be same with value types of oop.
+const (
+	NoneValue    uint8 = 0
+	IntValue     uint8 = 1
+	FloatValue   uint8 = 2
+	StringValue  uint8 = 3
+	BoolValue    uint8 = 4
+	BigIntValue  uint8 = 13
+	DecimalValue uint8 = 14
+)
+
+// Value is literal value.
+type Value struct {
+	Tk   obj.Token
+	Type uint8
+	Data interface{}
+}
+
+// Interpolation is interpolated string literal.
+// Texts are around of expressions, count of texts is one more than count of expressions.
+type Interpolation struct {
+	Tk      obj.Token
+	Texts   []string
+	Exprs   []Expr
+	Formats []string // Format specifiers of expressions, empty if not given.
+}
+
+// Name of define.
+type Name struct {
+	Tk obj.Token
+}
+
+// Binary is arithmetic process.
+type Binary struct {
+	Op    obj.Token
+	Left  Expr
+	Right Expr
+}
+
+// Compare is comparison with ==, !=, >, <, >=, <= or in.
+type Compare struct {
+	Op    obj.Token
+	Left  Expr
+	Right Expr
+}
+
+// Logical is conditional expression with && or ||.
+type Logical struct {
+	Op    obj.Token
+	Left  Expr
+	Right Expr
+}
+
+// Selector is access to sub field of object.
+type Selector struct {
+	X    Expr
+	Name obj.Token
+}
+
+// Index is element access of enumerable.
+type Index struct {
+	X     Expr
+	Tk    obj.Token // Open bracket.
+	Index Expr
+}
+
+// Arg of function call.
+type Arg struct {
+	Name   obj.Token // Name of parameter if argument is keyword argument.
+	Val    Expr
+	Spread bool // Notation "..." is used.
+}
+
+// Call is function call.
+type Call struct {
+	Fn   Expr
+	Tk   obj.Token // Open parentheses.
+	Args []Arg
+}
+
+// List is list value.
+type List struct {
+	Tk    obj.Token
+	Elems []Expr
+}
+
+// Map is map value.
+type Map struct {
+	Tk   obj.Token
+	Keys []Expr
+	Vals []Expr
+}
+
+// Comprehension is list comprehension.
+type Comprehension struct {
+	Tk     obj.Token
+	Select Expr
+	Name   obj.Token
+	Iter   Expr
+	Filter Expr // Nil if not given.
+}
+
+// Func is anonymous function.
+type Func struct {
+	Tk        obj.Token
+	Params    []Param
+	Body      *Block
+	Generator bool // Body has yield statement.
+}
+
+// Struct is anonymous struct.
+type Struct struct {
+	Tk     obj.Token
+	Fields []obj.Token
+}
+
+// Receive is value receive of channel.
+type Receive struct {
+	Tk obj.Token // Receive operator.
+	Ch Expr
+}
+
+// Rest is rest of list in match patterns.
+type Rest struct {
+	Name obj.Token
+}
+
+// Pin is value pattern of expression like ^x in match patterns.
+// Value is compared with value of expression instead of binding to name.
+type Pin struct {
+	Tk   obj.Token // Pin operator.
+	Expr Expr
+}
+
+func (e *Value) Token() obj.Token         { return e.Tk }
+func (e *Interpolation) Token() obj.Token { return e.Tk }
+func (e *Name) Token() obj.Token          { return e.Tk }
+func (e *Binary) Token() obj.Token        { return e.Op }
+func (e *Compare) Token() obj.Token       { return e.Op }
+func (e *Logical) Token() obj.Token       { return e.Op }
+func (e *Selector) Token() obj.Token      { return e.Name }
+func (e *Index) Token() obj.Token         { return e.Tk }
+func (e *Call) Token() obj.Token          { return e.Tk }
+func (e *List) Token() obj.Token          { return e.Tk }
+func (e *Map) Token() obj.Token           { return e.Tk }
+func (e *Comprehension) Token() obj.Token { return e.Tk }
+func (e *Func) Token() obj.Token          { return e.Tk }
+func (e *Struct) Token() obj.Token        { return e.Tk }
+func (e *Rest) Token() obj.Token          { return e.Name }
+func (e *Pin) Token() obj.Token           { return e.Tk }
+func (e *Receive) Token() obj.Token       { return e.Tk }
+
+func (*Value) expr()         {}
+func (*Interpolation) expr() {}
+func (*Name) expr()          {}
+func (*Binary) expr()        {}
+func (*Compare) expr()       {}
+func (*Logical) expr()       {}
+func (*Selector) expr()      {}
+func (*Index) expr()         {}
+func (*Call) expr()          {}
+func (*List) expr()          {}
+func (*Map) expr()           {}
+func (*Comprehension) expr() {}
+func (*Func) expr()          {}
+func (*Struct) expr()        {}
+func (*Rest) expr()          {}
+func (*Pin) expr()           {}
+func (*Receive) expr()       {}
@@ -0,0 +1,229 @@
+package ast
+
+import "github.com/fract-lang/fract/pkg/obj"
+
+// ExprStmt is expression statement.
+type ExprStmt struct {
+	X Expr
+}
+
+// VarSpec is single variable of declaration.
+type VarSpec struct {
+	Name   obj.Token
+	Setter obj.Token
+	Val    Expr
+}
+
+// VarDecl is variable declaration with var, mut or const.
+type VarDecl struct {
+	Tk    obj.Token
+	Specs []VarSpec
+}
+
+// ShortName is name of short variable declaration.
+type ShortName struct {
+	Name obj.Token
+	Type string // "", "mut" or "const".
+}
+
+// ShortVarDecl is short variable declaration with ":=".
+type ShortVarDecl struct {
+	Names  []ShortName
+	Setter obj.Token
+	Vals   []Expr
+}
+
+// Assign is value set statement.
+type Assign struct {
+	Target Expr
+	Setter obj.Token
+	Val    Expr
+}
+
+// If is if-else statement.
+type If struct {
+	Tk   obj.Token
+	Cond Expr
+	Body *Block
+	Else Stmt // *If, *Block or nil.
+}
+
+// Loop is infinity, while or foreach loop.
+type Loop struct {
+	Tk   obj.Token
+	Cond Expr      // Condition of while loop, nil if not while.
+	Key  obj.Token // Index or key name of foreach loop.
+	Elem obj.Token // Element name of foreach loop.
+	Iter Expr      // Enumerable of foreach loop, nil if not foreach.
+	Body *Block
+}
+
+// Break statement.
+type Break struct {
+	Tk obj.Token
+}
+
+// Continue statement.
+type Continue struct {
+	Tk obj.Token
+}
+
+// Return statement.
+type Return struct {
+	Tk   obj.Token
+	Vals []Expr
+}
+
+// Yield statement of generator function.
+type Yield struct {
+	Tk  obj.Token
+	Val Expr
+}
+
+// FuncDecl is function declaration.
+type FuncDecl struct {
+	Tk        obj.Token
+	Name      obj.Token
+	Params    []Param
+	Body      *Block
+	Generator bool // Body has yield statement.
+}
+
+// StructDecl is struct declaration.
+type StructDecl struct {
+	Tk     obj.Token
+	Name   obj.Token
+	Fields []obj.Token
+}
+
+// ClassDecl is class declaration.
+type ClassDecl struct {
+	Tk    obj.Token
+	Name  obj.Token
+	Vars  []*VarDecl
+	Funcs []*FuncDecl
+}
+
+// TryCatch is try-catch statement.
+type TryCatch struct {
+	Tk      obj.Token
+	Try     *Block
+	Catches []*Catch
+	Finally *Block // Block that runs after try and catches, nil if not given.
+}
+
+// Catch is catch clause of try-catch statement.
+type Catch struct {
+	Tk   obj.Token
+	Type obj.Token // Type of panics to catch, empty if catches all panics.
+	Name obj.Token // Name of catch variable, empty if not given.
+	Body *Block
+}
+
+// Import is package import statement.
+type Import struct {
+	Tk    obj.Token
+	Alias obj.Token // Empty if not given.
+	Path  obj.Token // Name of standard library package or path string.
+}
+
+// Pragma statement.
+type Pragma struct {
+	Tk   obj.Token
+	Name obj.Token
+	Args []obj.Token // Names that given after name.
+}
+
+// Defer is deferred function call.
+type Defer struct {
+	Tk   obj.Token
+	Call *Call
+}
+
+// Go is concurrent function call.
+type Go struct {
+	Tk   obj.Token
+	Call *Call
+}
+
+// Send is value send statement of channel.
+type Send struct {
+	Ch  Expr
+	Tk  obj.Token // Send operator.
+	Val Expr
+}
+
+// CommClause is case of select statement.
+type CommClause struct {
+	Tk   obj.Token
+	Name obj.Token // Name of received value, empty if not given.
+	Comm Stmt      // *Send, *ExprStmt of *Receive or nil if default case.
+	Body *Block
+}
+
+// Select is select statement of channel operations.
+type Select struct {
+	Tk    obj.Token
+	Cases []*CommClause
+}
+
+// Case of match statement.
+type Case struct {
+	Tk       obj.Token
+	Patterns []Expr
+	Body     *Block
+}
+
+// Match is match-case statement.
+type Match struct {
+	Tk    obj.Token
+	Val   Expr
+	Cases []*Case
+}
+
+func (s *ExprStmt) Token() obj.Token     { return s.X.Token() }
+func (s *VarDecl) Token() obj.Token      { return s.Tk }
+func (s *ShortVarDecl) Token() obj.Token { return s.Names[0].Name }
+func (s *Assign) Token() obj.Token       { return s.Setter }
+func (s *If) Token() obj.Token           { return s.Tk }
+func (s *Loop) Token() obj.Token         { return s.Tk }
+func (s *Break) Token() obj.Token        { return s.Tk }
+func (s *Continue) Token() obj.Token     { return s.Tk }
+func (s *Return) Token() obj.Token       { return s.Tk }
+func (s *Yield) Token() obj.Token        { return s.Tk }
+func (s *FuncDecl) Token() obj.Token     { return s.Tk }
+func (s *StructDecl) Token() obj.Token   { return s.Tk }
+func (s *ClassDecl) Token() obj.Token    { return s.Tk }
+func (s *TryCatch) Token() obj.Token     { return s.Tk }
+func (s *Catch) Token() obj.Token        { return s.Tk }
+func (s *Import) Token() obj.Token       { return s.Tk }
+func (s *Pragma) Token() obj.Token       { return s.Tk }
+func (s *Defer) Token() obj.Token        { return s.Tk }
+func (s *Go) Token() obj.Token           { return s.Tk }
+func (s *Case) Token() obj.Token         { return s.Tk }
+func (s *Match) Token() obj.Token        { return s.Tk }
+func (s *Send) Token() obj.Token         { return s.Tk }
+func (s *CommClause) Token() obj.Token   { return s.Tk }
+func (s *Select) Token() obj.Token       { return s.Tk }
+
+func (*ExprStmt) stmt()     {}
+func (*VarDecl) stmt()      {}
+func (*ShortVarDecl) stmt() {}
+func (*Assign) stmt()       {}
+func (*If) stmt()           {}
+func (*Loop) stmt()         {}
+func (*Break) stmt()        {}
+func (*Continue) stmt()     {}
+func (*Return) stmt()       {}
+func (*Yield) stmt()        {}
+func (*FuncDecl) stmt()     {}
+func (*StructDecl) stmt()   {}
+func (*ClassDecl) stmt()    {}
+func (*TryCatch) stmt()     {}
+func (*Import) stmt()       {}
+func (*Pragma) stmt()       {}
+func (*Defer) stmt()        {}
+func (*Go) stmt()           {}
+func (*Match) stmt()        {}
+func (*Send) stmt()         {}
+func (*Select) stmt()       {}
@@ -0,0 +1,369 @@
+package ast
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/fract-lang/fract/lex"
+	"github.com/fract-lang/fract/pkg/decimal"
+	"github.com/fract-lang/fract/pkg/fract"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// precedence returns precedence of operator, returns -1 if token is not operator.
+func precedence(tk obj.Token) int {
+	if tk.Type == fract.In {
+		return 3
+	} else if tk.Type != fract.Operator {
+		return -1
+	}
+	switch tk.Val {
+	case "||":
+		return 1
+	case "&&":
+		return 2
+	case "==", "!=", ">", "<", ">=", "<=":
+		return 3
+	case "+", "-":
+		return 4
+	case "&", "|", "^":
+		return 5
+	case "*", "/":
+		return 6
+	case "%", "**", "<<", ">>":
+		return 7
+	case "<-": // Unary receive operator.
+		return -1
+	}
+	return 0
+}
+
+// nextOperator returns index of operator to split expression,
+// returns -1 if not exist any operator.
+func nextOperator(tokens []obj.Token) int {
+	index, low := -1, -1
+	braceCount := 0
+	for i, tk := range tokens {
+		if tk.Type == fract.Brace {
+			switch tk.Val {
+			case "{", "[", "(":
+				braceCount++
+			default:
+				braceCount--
+			}
+		}
+		if braceCount > 0 {
+			continue
+		}
+		prec := precedence(tk)
+		switch {
+		case prec == -1:
+			continue
+		case prec == 0:
+			fract.IPanic(tk, obj.SyntaxPanic, "Invalid syntax!")
+		case low == -1 || prec < low:
+			index, low = i, prec
+		case prec == low && prec != 3: // Comparisons are processed from left.
+			index = i
+		}
+	}
+	return index
+}
+
+// buildExpr returns expression of tokens.
+func (b *builder) buildExpr(tokens []obj.Token) Expr {
+	i := nextOperator(tokens)
+	if i == -1 {
+		return b.buildOperand(tokens)
+	}
+	op := tokens[i]
+	prec := precedence(op)
+	if i == 0 || i == len(tokens)-1 {
+		if prec <= 3 {
+			fract.IPanic(op, obj.SyntaxPanic, "Comparison values are missing!")
+		}
+		fract.IPanic(op, obj.SyntaxPanic, "Operator overflow!")
+	}
+	left, right := b.buildExpr(tokens[:i]), b.buildExpr(tokens[i+1:])
+	switch prec {
+	case 1, 2:
+		return &Logical{Op: op, Left: left, Right: right}
+	case 3:
+		return &Compare{Op: op, Left: left, Right: right}
+	}
+	return &Binary{Op: op, Left: left, Right: right}
+}
+
+// buildLiteral returns literal value of token.
+func buildLiteral(tk obj.Token) *Value {
+	switch {
+	case tk.Val[0] == '\'' || tk.Val[0] == '"' || tk.Val[0] == '`':
+		return &Value{Tk: tk, Type: StringValue, Data: tk.Val[1 : len(tk.Val)-1]}
+	case tk.Val == "true" || tk.Val == "false":
+		return &Value{Tk: tk, Type: BoolValue, Data: tk.Val == "true"}
+	case tk.Val == "none":
+		return &Value{Tk: tk, Type: NoneValue, Data: tk.Val}
+	}
+	val := &Value{Tk: tk, Type: FloatValue}
+	switch {
+	case tk.Val == "NaN":
+		val.Data = math.NaN()
+	case strings.HasSuffix(tk.Val, "n"):
+		i, ok := new(big.Int).SetString(tk.Val[:len(tk.Val)-1], 10)
+		if !ok {
+			fract.IPanic(tk, obj.ValuePanic, "Bigint literals is must be integer!")
+		}
+		val.Type = BigIntValue
+		val.Data = i
+	case strings.HasSuffix(tk.Val, "d"):
+		d, ok := decimal.Parse(tk.Val[:len(tk.Val)-1])
+		if !ok {
+			fract.IPanic(tk, obj.ValuePanic, "Invalid decimal literal!")
+		}
+		val.Type = DecimalValue
+		val.Data = d
+	case strings.Contains(tk.Val, ".") || strings.ContainsAny(tk.Val, "eE"):
+		prs, _ := new(big.Float).SetString(tk.Val)
+		val.Data, _ = prs.Float64()
+	default:
+		i, err := strconv.ParseInt(tk.Val, 10, 64)
+		if err != nil {
+			fract.IPanic(tk, obj.ArithmeticPanic, "Integer overflow!")
+		}
+		val.Type = IntValue
+		val.Data = i
+	}
+	return val
+}
+
+// buildInterpolation returns interpolated string literal of token.
+func (b *builder) buildInterpolation(tk obj.Token) *Interpolation {
+	parts, tail := lex.Interpolations(tk)
+	interp := &Interpolation{Tk: tk}
+	for _, part := range parts {
+		interp.Texts = append(interp.Texts, part.Text)
+		interp.Exprs = append(interp.Exprs, b.buildExpr(part.Tokens))
+		interp.Formats = append(interp.Formats, part.Format)
+	}
+	interp.Texts = append(interp.Texts, tail)
+	return interp
+}
+
+// buildOperand returns operand expression of tokens.
+func (b *builder) buildOperand(tokens []obj.Token) Expr {
+	expr, i := b.buildPrimary(tokens)
+	for i < len(tokens) {
+		tk := tokens[i]
+		switch {
+		case tk.Type == fract.Dot:
+			if i+1 >= len(tokens) || tokens[i+1].Type != fract.Name {
+				fract.IPanic(tk, obj.SyntaxPanic, "Invalid syntax!")
+			}
+			expr = &Selector{X: expr, Name: tokens[i+1]}
+			i += 2
+		case tk.Type == fract.Brace && tk.Val == "(":
+			j := closeIndex(tokens, i)
+			expr = &Call{Fn: expr, Tk: tk, Args: b.buildArgs(tokens[i+1 : j])}
+			i = j + 1
+		case tk.Type == fract.Brace && tk.Val == "[":
+			j := closeIndex(tokens, i)
+			if j-i == 1 {
+				fract.IPanic(tk, obj.SyntaxPanic, "Index is not given!")
+			}
+			expr = &Index{X: expr, Tk: tk, Index: b.buildExpr(tokens[i+1 : j])}
+			i = j + 1
+		default:
+			fract.IPanic(tk, obj.SyntaxPanic, "Invalid syntax!")
+		}
+	}
+	return expr
+}
+
+// buildPrimary returns primary expression and index of next token.
+func (b *builder) buildPrimary(tokens []obj.Token) (Expr, int) {
+	switch tk := tokens[0]; tk.Type {
+	case fract.Value, fract.None:
+		return buildLiteral(tk), 1
+	case fract.Interpolation:
+		return b.buildInterpolation(tk), 1
+	case fract.Name:
+		return &Name{Tk: tk}, 1
+	case fract.Brace:
+		j := closeIndex(tokens, 0)
+		switch tk.Val {
+		case "(":
+			if j == 1 {
+				fract.IPanic(tk, obj.SyntaxPanic, "Invalid syntax!")
+			}
+			return b.buildExpr(tokens[1:j]), j + 1
+		case "[":
+			return b.buildEnumerable(tokens[:j+1]), j + 1
+		case "{":
+			return b.buildMap(tokens[:j+1]), j + 1
+		}
+	case fract.Func:
+		i := 1
+		if i < len(tokens) && tokens[i].Type == fract.Brace && tokens[i].Val == "(" {
+			i = closeIndex(tokens, i) + 1
+		}
+		if i >= len(tokens) || tokens[i].Type != fract.Brace || tokens[i].Val != "{" {
+			fract.IPanic(tk, obj.SyntaxPanic, "Invalid syntax!")
+		}
+		j := closeIndex(tokens, i)
+		fn := &Func{Tk: tk}
+		fn.Params, fn.Body, fn.Generator = b.buildFunc("anonymous", tokens[1:j+1], b.buildBlock)
+		return fn, j + 1
+	case fract.Operator:
+		if tk.Val != "<-" {
+			break
+		} else if len(tokens) < 2 {
+			fract.IPanicC(tk.File, tk.Line, tk.Column+len(tk.Val), obj.SyntaxPanic, "Channel is not given!")
+		}
+		return &Receive{Tk: tk, Ch: b.buildOperand(tokens[1:])}, len(tokens)
+	case fract.Struct:
+		if len(tokens) < 2 || tokens[1].Type != fract.Brace || tokens[1].Val != "{" {
+			fract.IPanic(tk, obj.SyntaxPanic, "Invalid syntax!")
+		}
+		j := closeIndex(tokens, 1)
+		return &Struct{Tk: tk, Fields: buildFields(tokens[1 : j+1])}, j + 1
+	}
+	fract.IPanic(tokens[0], obj.ValuePanic, "Invalid value!")
+	return nil, 0
+}
+
+// buildArgs returns arguments of function call.
+func (b *builder) buildArgs(tokens []obj.Token) []Arg {
+	var args []Arg
+	for _, part := range decomposeComma(tokens, true) {
+		var arg Arg
+		// Keyword argument?
+		if len(part) >= 2 && part[0].Type == fract.Name && part[1].Type == fract.Operator && part[1].Val == "=" {
+			if len(part) == 2 {
+				fract.IPanic(part[1], obj.SyntaxPanic, "Value is not given!")
+			}
+			arg.Name = part[0]
+			part = part[2:]
+		}
+		if last := part[len(part)-1]; last.Type == fract.Params {
+			if len(part) == 1 {
+				fract.IPanic(last, obj.SyntaxPanic, "Value is not given!")
+			}
+			arg.Spread = true
+			part = part[:len(part)-1]
+		}
+		arg.Val = b.buildExpr(part)
+		args = append(args, arg)
+	}
+	return args
+}
+
+// buildEnumerable returns list or list comprehension of tokens with brackets.
+func (b *builder) buildEnumerable(tokens []obj.Token) Expr {
+	braceCount := 0
+	for i, tk := range tokens {
+		if tk.Type == fract.Brace {
+			switch tk.Val {
+			case "{", "[", "(":
+				braceCount++
+			default:
+				braceCount--
+			}
+		}
+		if braceCount > 1 {
+			continue
+		}
+		if tk.Type == fract.Comma {
+			break
+		} else if tk.Type == fract.Loop {
+			return b.buildComprehension(tokens, i)
+		}
+	}
+	list := &List{Tk: tokens[0]}
+	for _, part := range decomposeComma(tokens[1:len(tokens)-1], true) {
+		list.Elems = append(list.Elems, b.buildExpr(part))
+	}
+	return list
+}
+
+// buildComprehension returns list comprehension of tokens with brackets.
+// loopIndex is index of loop keyword.
+func (b *builder) buildComprehension(tokens []obj.Token, loopIndex int) *Comprehension {
+	if loopIndex == 1 {
+		fract.IPanic(tokens[1], obj.SyntaxPanic, "Value is not given!")
+	}
+	c := &Comprehension{Tk: tokens[0], Select: b.buildExpr(tokens[1:loopIndex])}
+	loopTokens := tokens[loopIndex : len(tokens)-1]
+	braceCount := 0
+	for i, tk := range loopTokens {
+		if tk.Type == fract.Brace {
+			switch tk.Val {
+			case "{", "[", "(":
+				braceCount++
+			default:
+				braceCount--
+			}
+		}
+		if braceCount == 0 && tk.Type == fract.Comma {
+			if i == len(loopTokens)-1 {
+				fract.IPanic(tk, obj.SyntaxPanic, "Condition is empty!")
+			}
+			c.Filter = b.buildExpr(loopTokens[i+1:])
+			loopTokens = loopTokens[:i]
+			break
+		}
+	}
+	if len(loopTokens) < 2 {
+		fract.IPanic(loopTokens[0], obj.SyntaxPanic, "Variable name is not given!")
+	}
+	c.Name = loopTokens[1]
+	// Name is not name?
+	if c.Name.Type != fract.Name {
+		fract.IPanic(c.Name, obj.SyntaxPanic, "This is not a valid name!")
+	} else if c.Name.Val != "_" && !isValidName(c.Name.Val) {
+		fract.IPanic(c.Name, obj.NamePanic, "Invalid name!")
+	}
+	if len(loopTokens) < 3 {
+		tk := tokens[0]
+		fract.IPanicC(tk.File, tk.Line, c.Name.Column+len(c.Name.Val), obj.SyntaxPanic, "Value is not given!")
+	} else if loopTokens[2].Type != fract.In {
+		fract.IPanic(loopTokens[2], obj.SyntaxPanic, "Invalid syntax!")
+	} else if len(loopTokens) < 4 {
+		fract.IPanic(loopTokens[2], obj.SyntaxPanic, "Value is not given!")
+	}
+	c.Iter = b.buildExpr(loopTokens[3:])
+	return c
+}
+
+// buildMap returns map of tokens with braces.
+func (b *builder) buildMap(tokens []obj.Token) *Map {
+	m := &Map{Tk: tokens[0]}
+	for _, part := range decomposeComma(tokens[1:len(tokens)-1], true) {
+		i := -1
+		braceCount := 0
+		for j, tk := range part {
+			if tk.Type == fract.Brace {
+				switch tk.Val {
+				case "{", "[", "(":
+					braceCount++
+				default:
+					braceCount--
+				}
+			} else if tk.Type == fract.Colon && braceCount == 0 {
+				i = j
+				break
+			}
+		}
+		if i == -1 {
+			fract.IPanic(part[len(part)-1], obj.SyntaxPanic, "Value identifier is not found!")
+		} else if i == 0 {
+			fract.IPanic(part[0], obj.SyntaxPanic, "Key is not given!")
+		} else if i+1 >= len(part) {
+			fract.IPanic(part[i], obj.SyntaxPanic, "Value is not given!")
+		}
+		m.Keys = append(m.Keys, b.buildExpr(part[:i]))
+		m.Vals = append(m.Vals, b.buildExpr(part[i+1:]))
+	}
+	return m
+}
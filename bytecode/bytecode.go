@@ -0,0 +1,97 @@
+// Package bytecode implements compiled form of Fract code files.
+package bytecode
+
+// Version of bytecode format.
+// Files of another version are cannot be executed.
+const Version = 9
+
+// Modes of values.
+const (
+	ModeNone = 0 // Type of value.
+	ModeVar  = 1 // Force to immutability.
+	ModeMut  = 2 // Force to mutability.
+	ModeArg  = 3 // By type of parameter of current argument.
+)
+
+// Flags of variable definitions.
+const (
+	FlagConst = 1
+	FlagMut   = 2
+)
+
+// Kinds of select cases.
+const (
+	CaseRecv    = 0
+	CaseSend    = 1
+	CaseDefault = 2
+)
+
+// Instr is instruction of code.
+type Instr struct {
+	Op Opcode
+	A  int
+	B  int
+}
+
+// Pos is source position of instruction.
+type Pos struct {
+	Line   int
+	Column int
+}
+
+// Code is instructions with source line table.
+type Code struct {
+	Instrs []Instr
+	Pos    []Pos // Source positions of instructions.
+}
+
+// Const is literal value.
+// Types are same with value types of oop.
+type Const struct {
+	Type uint8
+	Data interface{}
+}
+
+// Struct define.
+type Struct struct {
+	Name   string
+	Fields []string
+}
+
+// Param of function.
+type Param struct {
+	Name    string
+	Type    string
+	Params  bool
+	Default bool // Default value is given.
+}
+
+// Func is compiled function.
+type Func struct {
+	Name      string
+	Line      int
+	Params    []Param
+	Generator bool // Calls are returns iterator.
+	Code
+}
+
+// Program is compiled code file.
+type Program struct {
+	Path    string   // Path of source file.
+	Lines   []string // Lines of source file.
+	Package string
+	Names   []string
+	Consts  []Const
+	Structs []Struct
+	Funcs   []*Func
+	Main    Code
+}
+
+// Name returns name by index.
+// Returns empty string if index is negative.
+func (p *Program) Name(i int) string {
+	if i < 0 {
+		return ""
+	}
+	return p.Names[i]
+}
@@ -0,0 +1,705 @@
+package bytecode
+
+import (
+	"github.com/fract-lang/fract/ast"
+	"github.com/fract-lang/fract/pkg/fract"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// Labels of loop.
+type loopLabels struct {
+	cont   int   // Target of continue.
+	breaks []int // Jumps of break.
+	depth  int   // Count of blocks at loop.
+}
+
+// Finally block of open try statement.
+type finallyLabel struct {
+	body  *ast.Block
+	depth int // Index of ending block of try or catch.
+	loops int // Count of loops at try.
+}
+
+type compiler struct {
+	prog   *Program
+	code   *Code
+	names  map[string]int
+	loops  []*loopLabels
+	blocks []Opcode        // Ending opcodes of open blocks.
+	finals []*finallyLabel // Finally blocks of open try statements.
+}
+
+// Compile syntax tree of code file.
+func Compile(f *obj.File, pkg string, tree *ast.Block) *Program {
+	c := &compiler{
+		prog:  &Program{Path: f.Path, Lines: f.Lines, Package: pkg},
+		names: map[string]int{},
+	}
+	c.code = &c.prog.Main
+	c.stmts(tree.Stmts)
+	return c.prog
+}
+
+// emit instruction and returns index of it.
+func (c *compiler) emit(op Opcode, a, b int, tk obj.Token) int {
+	c.code.Instrs = append(c.code.Instrs, Instr{Op: op, A: a, B: b})
+	c.code.Pos = append(c.code.Pos, Pos{Line: tk.Line, Column: tk.Column})
+	return len(c.code.Instrs) - 1
+}
+
+// patch jump of instruction to current position.
+func (c *compiler) patch(i int) {
+	instr := &c.code.Instrs[i]
+	// Names of name pattern and catch type are at A.
+	if instr.Op == OpPatName || instr.Op == OpCatchType {
+		instr.B = len(c.code.Instrs)
+		return
+	}
+	instr.A = len(c.code.Instrs)
+}
+
+// name returns index of name.
+func (c *compiler) name(n string) int {
+	if i, ok := c.names[n]; ok {
+		return i
+	}
+	c.prog.Names = append(c.prog.Names, n)
+	c.names[n] = len(c.prog.Names) - 1
+	return c.names[n]
+}
+
+func (c *compiler) stmts(stmts []ast.Stmt) {
+	for _, stmt := range stmts {
+		c.stmt(stmt)
+	}
+}
+
+// block compiles statements in new scope.
+func (c *compiler) block(b *ast.Block) {
+	c.emit(OpScope, 0, 0, b.Tk)
+	c.blocks = append(c.blocks, OpEndScope)
+	c.stmts(b.Stmts)
+	c.blocks = c.blocks[:len(c.blocks)-1]
+	c.emit(OpEndScope, 0, 0, b.Tk)
+}
+
+// end blocks until depth for break, continue and return.
+// Finally blocks of ended try statements are compiled after ends.
+func (c *compiler) end(depth int, tk obj.Token) {
+	j := len(c.finals) - 1
+	for i := len(c.blocks) - 1; i >= depth; i-- {
+		c.emit(c.blocks[i], 0, 0, tk)
+		if j >= 0 && c.finals[j].depth == i {
+			c.finally(j)
+			j--
+		}
+	}
+}
+
+// finally compiles finally block of index with blocks and loops of outside of try.
+func (c *compiler) finally(i int) {
+	f := c.finals[i]
+	blocks, loops, finals := c.blocks, c.loops, c.finals
+	c.blocks, c.loops, c.finals = blocks[:f.depth:f.depth], loops[:f.loops:f.loops], finals[:i:i]
+	c.block(f.body)
+	c.blocks, c.loops, c.finals = blocks, loops, finals
+}
+
+func (c *compiler) stmt(stmt ast.Stmt) {
+	switch s := stmt.(type) {
+	case *ast.ExprStmt:
+		c.expr(s.X, ModeNone)
+		c.emit(OpPop, 1, 0, s.X.Token())
+	case *ast.Assign:
+		c.assign(s)
+	case *ast.ShortVarDecl:
+		c.shortVarDecl(s)
+	case *ast.VarDecl:
+		c.varDecl(s)
+	case *ast.If:
+		c.ifStmt(s)
+	case *ast.Match:
+		c.match(s)
+	case *ast.Select:
+		c.selectStmt(s)
+	case *ast.Send:
+		c.expr(s.Ch, ModeNone)
+		c.expr(s.Val, ModeNone)
+		c.emit(OpValid, 0, 0, s.Val.Token())
+		c.emit(OpSend, 0, 0, s.Tk)
+	case *ast.Loop:
+		c.loop(s)
+	case *ast.Break:
+		l := c.loops[len(c.loops)-1]
+		c.end(l.depth, s.Tk)
+		l.breaks = append(l.breaks, c.emit(OpJump, 0, 0, s.Tk))
+	case *ast.Continue:
+		l := c.loops[len(c.loops)-1]
+		c.end(l.depth, s.Tk)
+		c.emit(OpJump, l.cont, 0, s.Tk)
+	case *ast.Return:
+		for _, v := range s.Vals {
+			c.expr(v, ModeNone)
+		}
+		c.ret(len(s.Vals), s.Tk)
+	case *ast.Yield:
+		c.expr(s.Val, ModeNone)
+		resume := c.emit(OpYield, 0, 0, s.Tk)
+		// Function returns from yield if generator is closed.
+		c.ret(0, s.Tk)
+		c.patch(resume)
+	case *ast.FuncDecl:
+		c.funcDecl(s)
+	case *ast.TryCatch:
+		c.tryCatch(s)
+	case *ast.Import:
+		tk := s.Path
+		if s.Alias.Val != "" {
+			tk = s.Alias
+		}
+		if s.Path.Type == fract.Name {
+			c.emit(OpImport, c.name(s.Path.Val), 1, tk)
+		} else {
+			c.emit(OpImport, c.name(s.Path.Val[1:len(s.Path.Val)-1]), 0, tk)
+		}
+		alias := -1
+		if s.Alias.Val != "" {
+			alias = c.name(s.Alias.Val)
+		}
+		c.emit(OpPackage, alias, 0, s.Tk)
+	case *ast.StructDecl:
+		c.emit(OpDefined, c.name(s.Name.Val), 0, s.Name)
+		c.structVal(s.Name.Val, s.Fields, ModeNone, s.Tk)
+		c.emit(OpConstDef, c.name(s.Name.Val), 0, s.Tk)
+	case *ast.ClassDecl:
+		c.classDecl(s)
+	case *ast.Defer:
+		c.call(s.Call, ModeNone, OpDefer)
+	case *ast.Go:
+		c.call(s.Call, ModeNone, OpGo)
+	case *ast.Block:
+		c.block(s)
+	}
+}
+
+// ret compiles return with count of values.
+func (c *compiler) ret(n int, tk obj.Token) {
+	if len(c.finals) == 0 {
+		c.emit(OpReturn, n, 0, tk)
+		return
+	}
+	// Finally blocks are runs after set of returned values.
+	c.emit(OpSetReturn, n, 0, tk)
+	c.end(0, tk)
+	c.emit(OpReturn, -1, 0, tk)
+}
+
+// ref compiles reference of assignment target.
+func (c *compiler) ref(e ast.Expr) {
+	switch t := e.(type) {
+	case *ast.Name:
+		c.emit(OpRefName, c.name(t.Tk.Val), 0, t.Tk)
+	case *ast.Selector:
+		c.expr(t.X, ModeMut)
+		c.emit(OpRefField, c.name(t.Name.Val), 0, t.Name)
+	default:
+		c.expr(e, ModeMut)
+	}
+}
+
+func (c *compiler) assign(s *ast.Assign) {
+	if t, ok := s.Target.(*ast.Index); ok {
+		c.ref(t.X)
+		c.expr(t.Index, ModeNone)
+		c.emit(OpTarget, 0, 1, s.Setter)
+	} else {
+		c.ref(s.Target)
+		c.emit(OpTarget, 0, 0, s.Setter)
+	}
+	c.expr(s.Val, ModeNone)
+	c.emit(OpAssign, c.name(s.Setter.Val), 0, s.Setter)
+}
+
+// flags returns flags of variable type.
+func flags(typ string) int {
+	switch typ {
+	case "const":
+		return FlagConst
+	case "mut":
+		return FlagMut
+	}
+	return 0
+}
+
+func (c *compiler) shortVarDecl(s *ast.ShortVarDecl) {
+	for _, name := range s.Names {
+		if name.Name.Val != "_" {
+			c.emit(OpDefined, c.name(name.Name.Val), 0, name.Name)
+		}
+	}
+	for _, v := range s.Vals {
+		c.expr(v, ModeNone)
+	}
+	c.emit(OpUnpack, len(s.Names), len(s.Vals), s.Setter)
+	for i := len(s.Names) - 1; i >= 0; i-- {
+		name := s.Names[i]
+		if name.Name.Val == "_" {
+			c.emit(OpShortVar, -1, 0, s.Setter)
+			continue
+		}
+		c.emit(OpShortVar, c.name(name.Name.Val), flags(name.Type), s.Setter)
+	}
+}
+
+func (c *compiler) varDecl(s *ast.VarDecl) {
+	for _, spec := range s.Specs {
+		c.emit(OpDefined, c.name(spec.Name.Val), 0, spec.Name)
+		c.expr(spec.Val, ModeNone)
+		c.emit(OpValid, 0, 0, spec.Val.Token())
+		c.emit(OpVar, c.name(spec.Name.Val), flags(s.Tk.Val), spec.Name)
+	}
+}
+
+func (c *compiler) ifStmt(s *ast.If) {
+	c.expr(s.Cond, ModeNone)
+	c.emit(OpCond, 0, 0, s.Tk)
+	jumpElse := c.emit(OpJumpFalse, 0, 0, s.Tk)
+	c.block(s.Body)
+	if s.Else == nil {
+		c.patch(jumpElse)
+		return
+	}
+	jumpEnd := c.emit(OpJump, 0, 0, s.Tk)
+	c.patch(jumpElse)
+	switch t := s.Else.(type) {
+	case *ast.If:
+		c.ifStmt(t)
+	case *ast.Block:
+		c.block(t)
+	}
+	c.patch(jumpEnd)
+}
+
+func (c *compiler) loop(s *ast.Loop) {
+	l := &loopLabels{depth: len(c.blocks)}
+	//*************
+	//    WHILE
+	//*************
+	if s.Iter == nil {
+		l.cont = len(c.code.Instrs)
+		jumpEnd := -1
+		if s.Cond != nil {
+			c.expr(s.Cond, ModeNone)
+			c.emit(OpCond, 0, 0, s.Tk)
+			jumpEnd = c.emit(OpJumpFalse, 0, 0, s.Tk)
+		}
+		c.loops = append(c.loops, l)
+		c.block(s.Body)
+		c.loops = c.loops[:len(c.loops)-1]
+		c.emit(OpJump, l.cont, 0, s.Tk)
+		if jumpEnd != -1 {
+			c.patch(jumpEnd)
+		}
+		for _, i := range l.breaks {
+			c.patch(i)
+		}
+		return
+	}
+	//*************
+	//   FOREACH
+	//*************
+	key := s.Key.Val
+	if key != "_" {
+		c.emit(OpDefined, c.name(key), 0, s.Key)
+	} else {
+		key = ""
+	}
+	elem := ""
+	if s.Elem.Val != "" && s.Elem.Val != "_" {
+		elem = s.Elem.Val
+		c.emit(OpDefined, c.name(elem), 0, s.Elem)
+	}
+	c.expr(s.Iter, ModeNone)
+	c.emit(OpEnum, 1, 0, s.Iter.Token())
+	if s.Elem.Val != "" {
+		c.emit(OpEnum, 2, 0, s.Elem)
+	}
+	c.emit(OpIter, c.name(key), c.name(elem), s.Iter.Token())
+	l.cont = c.emit(OpNext, 0, 0, s.Tk)
+	c.loops = append(c.loops, l)
+	c.block(s.Body)
+	c.loops = c.loops[:len(c.loops)-1]
+	c.emit(OpJump, l.cont, 0, s.Tk)
+	c.patch(l.cont)
+	for _, i := range l.breaks {
+		c.patch(i)
+	}
+	c.emit(OpEndIter, 0, 0, s.Tk)
+}
+
+// function compiles function and returns index of it.
+// Default values of parameters are compiled to current code.
+func (c *compiler) function(name string, line int, params []ast.Param, body *ast.Block, generator bool) int {
+	fn := &Func{Name: name, Line: line, Generator: generator}
+	for _, param := range params {
+		fn.Params = append(fn.Params, Param{
+			Name:    param.Name.Val,
+			Type:    param.Type,
+			Params:  param.Params,
+			Default: param.Default != nil,
+		})
+		if param.Default != nil {
+			c.expr(param.Default, ModeNone)
+			if param.Params {
+				c.emit(OpParamsDefault, 0, 0, param.Default.Token())
+			}
+		}
+	}
+	// Functions of body are appended after function.
+	i := len(c.prog.Funcs)
+	c.prog.Funcs = append(c.prog.Funcs, fn)
+	code, loops, blocks, finals := c.code, c.loops, c.blocks, c.finals
+	c.code, c.loops, c.blocks, c.finals = &fn.Code, nil, nil, nil
+	c.stmts(body.Stmts)
+	c.code, c.loops, c.blocks, c.finals = code, loops, blocks, finals
+	return i
+}
+
+func (c *compiler) funcDecl(s *ast.FuncDecl) {
+	c.emit(OpDefined, c.name(s.Name.Val), 0, s.Name)
+	i := c.function(s.Name.Val, s.Name.Line, s.Params, s.Body, s.Generator)
+	c.emit(OpFunc, i, 0, s.Name)
+}
+
+func (c *compiler) classDecl(s *ast.ClassDecl) {
+	c.emit(OpDefined, c.name(s.Name.Val), 0, s.Name)
+	c.emit(OpClass, c.name(s.Name.Val), 0, s.Tk)
+	for _, decl := range s.Vars {
+		c.varDecl(decl)
+	}
+	for _, decl := range s.Funcs {
+		c.funcDecl(decl)
+		if decl.Name.Val == s.Name.Val {
+			c.emit(OpCtor, 0, 0, decl.Tk)
+		}
+	}
+	c.emit(OpEndClass, 0, 0, s.Tk)
+	c.emit(OpConstDef, c.name(s.Name.Val), 0, s.Tk)
+}
+
+func (c *compiler) tryCatch(s *ast.TryCatch) {
+	try := c.emit(OpTry, 0, -1, s.Tk)
+	if s.Finally != nil {
+		c.finals = append(c.finals, &finallyLabel{body: s.Finally, depth: len(c.blocks), loops: len(c.loops)})
+	}
+	c.blocks = append(c.blocks, OpEndTry)
+	c.stmts(s.Try.Stmts)
+	c.blocks = c.blocks[:len(c.blocks)-1]
+	c.emit(OpEndTry, 0, 0, s.Tk)
+	ends := []int{c.emit(OpJump, 0, 0, s.Tk)}
+	c.patch(try)
+	all := len(s.Catches) == 0
+	if all {
+		c.emit(OpEndCatch, 0, 0, s.Tk)
+	}
+	for _, cs := range s.Catches {
+		next := -1
+		if cs.Type.Val != "" {
+			next = c.emit(OpCatchType, c.name(cs.Type.Val), 0, cs.Type)
+		}
+		if cs.Name.Val != "" {
+			c.emit(OpCatch, c.name(cs.Name.Val), 0, cs.Name)
+		}
+		c.blocks = append(c.blocks, OpEndCatch)
+		c.stmts(cs.Body.Stmts)
+		c.blocks = c.blocks[:len(c.blocks)-1]
+		c.emit(OpEndCatch, 0, 0, cs.Tk)
+		ends = append(ends, c.emit(OpJump, 0, 0, cs.Tk))
+		if next == -1 { // Catches all panics, later catches are unreachable.
+			all = true
+			break
+		}
+		c.patch(next)
+	}
+	if s.Finally != nil {
+		c.finals = c.finals[:len(c.finals)-1]
+		// Not catched panics and panics of catch blocks are raised again after finally block.
+		if len(s.Catches) > 0 {
+			c.code.Instrs[try].B = c.emit(OpFinally, 0, 0, s.Finally.Tk)
+			c.blocks = append(c.blocks, OpEndCatch)
+			c.block(s.Finally)
+			c.blocks = c.blocks[:len(c.blocks)-1]
+			c.emit(OpRethrow, 0, 0, s.Tk)
+		}
+	} else if !all { // Not catched panics are raised again.
+		c.emit(OpRethrow, 0, 0, s.Tk)
+	}
+	for _, i := range ends {
+		c.patch(i)
+	}
+	if s.Finally != nil {
+		c.block(s.Finally)
+	}
+}
+
+func (c *compiler) match(s *ast.Match) {
+	c.expr(s.Val, ModeNone)
+	c.emit(OpMatch, 0, 0, s.Tk)
+	c.blocks = append(c.blocks, OpEndMatch)
+	var ends []int
+	for _, cs := range s.Cases {
+		var binds, fails []int
+		for _, pattern := range cs.Patterns {
+			for _, i := range fails {
+				c.patch(i)
+			}
+			fails = nil
+			c.emit(OpPatBegin, 0, 0, pattern.Token())
+			c.pattern(pattern, &fails)
+			binds = append(binds, c.emit(OpJump, 0, 0, pattern.Token()))
+		}
+		for _, i := range binds {
+			c.patch(i)
+		}
+		c.emit(OpPatBind, 0, 0, cs.Tk)
+		c.blocks = append(c.blocks, OpEndScope)
+		c.stmts(cs.Body.Stmts)
+		c.blocks = c.blocks[:len(c.blocks)-1]
+		c.emit(OpEndScope, 0, 0, cs.Body.Tk)
+		ends = append(ends, c.emit(OpJump, 0, 0, cs.Tk))
+		for _, i := range fails {
+			c.patch(i)
+		}
+	}
+	c.emit(OpNoMatch, 0, 0, s.Tk)
+	for _, i := range ends {
+		c.patch(i)
+	}
+	c.blocks = c.blocks[:len(c.blocks)-1]
+	c.emit(OpEndMatch, 0, 0, s.Tk)
+}
+
+func (c *compiler) selectStmt(s *ast.Select) {
+	kinds := make([]int, len(s.Cases))
+	for i, cs := range s.Cases {
+		switch t := cs.Comm.(type) {
+		case *ast.Send:
+			kinds[i] = CaseSend
+			c.expr(t.Ch, ModeNone)
+			c.expr(t.Val, ModeNone)
+			c.emit(OpValid, 0, 0, t.Val.Token())
+		case *ast.ExprStmt:
+			kinds[i] = CaseRecv
+			c.expr(t.X.(*ast.Receive).Ch, ModeNone)
+		default:
+			kinds[i] = CaseDefault
+		}
+	}
+	c.emit(OpChanSelect, len(s.Cases), 0, s.Tk)
+	cases := make([]int, len(s.Cases))
+	for i, kind := range kinds {
+		cases[i] = c.emit(OpSelCase, 0, kind, s.Cases[i].Tk)
+	}
+	var ends []int
+	for i, cs := range s.Cases {
+		c.patch(cases[i])
+		c.emit(OpScope, 0, 0, cs.Tk)
+		// Received value is at top of stack.
+		if cs.Name.Val != "" {
+			c.emit(OpDefined, c.name(cs.Name.Val), 0, cs.Name)
+			c.emit(OpShortVar, c.name(cs.Name.Val), 0, cs.Name)
+		} else {
+			c.emit(OpPop, 0, 0, cs.Tk)
+		}
+		c.blocks = append(c.blocks, OpEndScope)
+		c.stmts(cs.Body.Stmts)
+		c.blocks = c.blocks[:len(c.blocks)-1]
+		c.emit(OpEndScope, 0, 0, cs.Body.Tk)
+		ends = append(ends, c.emit(OpJump, 0, 0, cs.Tk))
+	}
+	for _, i := range ends {
+		c.patch(i)
+	}
+}
+
+// pattern compiles match pattern, jumps of not matched appended to fails.
+func (c *compiler) pattern(e ast.Expr, fails *[]int) {
+	switch t := e.(type) {
+	case *ast.Name:
+		c.emit(OpPatName, c.name(t.Tk.Val), 0, t.Tk)
+		return
+	case *ast.Pin:
+		c.expr(t.Expr, ModeNone)
+		*fails = append(*fails, c.emit(OpPatEq, 0, 0, t.Tk))
+		return
+	case *ast.List:
+		n := len(t.Elems)
+		if n > 0 {
+			if rest, ok := t.Elems[n-1].(*ast.Rest); ok {
+				*fails = append(*fails, c.emit(OpPatListRest, 0, n, t.Tk))
+				for _, elem := range t.Elems[:n-1] {
+					c.pattern(elem, fails)
+				}
+				c.pattern(&ast.Name{Tk: rest.Name}, fails)
+				return
+			}
+		}
+		*fails = append(*fails, c.emit(OpPatList, 0, n, t.Tk))
+		for _, elem := range t.Elems {
+			c.pattern(elem, fails)
+		}
+		return
+	case *ast.Call:
+		c.expr(t.Fn, ModeNone)
+		literal := c.emit(OpIsStruct, 0, 0, t.Tk)
+		*fails = append(*fails, c.emit(OpPatStruct, 0, len(t.Args), t.Tk))
+		for _, arg := range t.Args {
+			c.pattern(arg.Val, fails)
+		}
+		done := c.emit(OpJump, 0, 0, t.Tk)
+		c.patch(literal)
+		c.emit(OpPop, 0, 0, t.Tk)
+		c.expr(t, ModeNone)
+		*fails = append(*fails, c.emit(OpPatEq, 0, 0, t.Tk))
+		c.patch(done)
+		return
+	}
+	// Literal pattern.
+	c.expr(e, ModeNone)
+	*fails = append(*fails, c.emit(OpPatEq, 0, 0, e.Token()))
+}
+
+func (c *compiler) call(call *ast.Call, mode int, end Opcode) {
+	if end == OpCall {
+		c.expr(call.Fn, mode)
+		c.emit(OpCallBegin, 0, 0, call.Tk)
+	} else {
+		c.expr(call.Fn, ModeNone)
+		c.emit(OpCallBegin, 1, 0, call.Tk)
+	}
+	for _, arg := range call.Args {
+		spread := 0
+		if arg.Spread {
+			spread = 1
+		}
+		if arg.Name.Val != "" {
+			c.emit(OpArg, c.name(arg.Name.Val), spread, arg.Name)
+		} else {
+			c.emit(OpArg, -1, spread, arg.Val.Token())
+		}
+		c.expr(arg.Val, ModeArg)
+		c.emit(OpArgEnd, 0, spread, arg.Val.Token())
+	}
+	c.emit(end, 0, mode, call.Tk)
+}
+
+func (c *compiler) structVal(name string, fields []obj.Token, mode int, tk obj.Token) {
+	s := Struct{Name: name}
+	for _, field := range fields {
+		s.Fields = append(s.Fields, field.Val)
+	}
+	c.prog.Structs = append(c.prog.Structs, s)
+	c.emit(OpStruct, len(c.prog.Structs)-1, mode, tk)
+}
+
+func (c *compiler) expr(e ast.Expr, mode int) {
+	switch t := e.(type) {
+	case *ast.Value:
+		c.prog.Consts = append(c.prog.Consts, Const{Type: t.Type, Data: t.Data})
+		c.emit(OpConst, len(c.prog.Consts)-1, mode, t.Tk)
+	case *ast.Interpolation:
+		c.interpolation(t, mode)
+	case *ast.Name:
+		c.emit(OpName, c.name(t.Tk.Val), mode, t.Tk)
+	case *ast.Binary:
+		c.expr(t.Left, mode)
+		c.expr(t.Right, mode)
+		c.emit(OpBinary, c.name(t.Op.Val), mode, t.Op)
+	case *ast.Compare:
+		c.expr(t.Left, ModeNone)
+		c.expr(t.Right, ModeNone)
+		c.emit(OpCompare, c.name(t.Op.Val), 0, t.Op)
+	case *ast.Logical:
+		c.expr(t.Left, ModeNone)
+		c.emit(OpCond, 0, 0, t.Op)
+		op := OpJumpFalseKeep
+		if t.Op.Val == "||" {
+			op = OpJumpTrueKeep
+		}
+		jump := c.emit(op, 0, 0, t.Op)
+		c.expr(t.Right, ModeNone)
+		c.emit(OpCond, 0, 0, t.Op)
+		c.patch(jump)
+	case *ast.Selector:
+		c.expr(t.X, ModeMut)
+		c.emit(OpSelect, c.name(t.Name.Val), mode, t.Name)
+	case *ast.Index:
+		c.expr(t.X, mode)
+		c.emit(OpEnum, 0, 0, t.X.Token())
+		c.expr(t.Index, ModeNone)
+		c.emit(OpIndex, 0, mode, t.Tk)
+	case *ast.Call:
+		c.call(t, mode, OpCall)
+	case *ast.List:
+		for _, elem := range t.Elems {
+			c.expr(elem, ModeNone)
+		}
+		c.emit(OpList, len(t.Elems), mode, t.Tk)
+	case *ast.Map:
+		c.emit(OpMap, 0, mode, t.Tk)
+		for i, k := range t.Keys {
+			c.expr(k, ModeNone)
+			c.emit(OpKey, 0, 0, k.Token())
+			c.expr(t.Vals[i], ModeNone)
+			c.emit(OpSetKey, 0, 0, t.Tk)
+		}
+	case *ast.Comprehension:
+		c.comprehension(t, mode)
+	case *ast.Func:
+		c.emit(OpLambda, c.function("anonymous", 0, t.Params, t.Body, t.Generator), mode, t.Tk)
+	case *ast.Struct:
+		c.structVal("anonymous", t.Fields, mode, t.Tk)
+	case *ast.Receive:
+		c.expr(t.Ch, ModeNone)
+		c.emit(OpRecv, 0, mode, t.Ch.Token())
+	}
+}
+
+func (c *compiler) interpolation(t *ast.Interpolation, mode int) {
+	n := 0
+	for i, text := range t.Texts {
+		if text != "" {
+			c.prog.Consts = append(c.prog.Consts, Const{Type: ast.StringValue, Data: text})
+			c.emit(OpConst, len(c.prog.Consts)-1, ModeNone, t.Tk)
+			n++
+		}
+		if i < len(t.Exprs) {
+			c.expr(t.Exprs[i], ModeNone)
+			c.emit(OpFormat, c.name(t.Formats[i]), 0, t.Exprs[i].Token())
+			n++
+		}
+	}
+	c.emit(OpConcat, n, mode, t.Tk)
+}
+
+func (c *compiler) comprehension(t *ast.Comprehension, mode int) {
+	c.emit(OpDefined, c.name(t.Name.Val), 0, t.Name)
+	c.expr(t.Iter, ModeNone)
+	c.emit(OpEnum, 1, 0, t.Iter.Token())
+	name := t.Name.Val
+	if name == "_" {
+		name = ""
+	}
+	c.emit(OpCompIter, c.name(name), 0, t.Tk)
+	next := c.emit(OpNext, 0, 0, t.Tk)
+	if t.Filter != nil {
+		c.expr(t.Filter, ModeNone)
+		c.emit(OpCond, 0, 0, t.Filter.Token())
+		c.emit(OpJumpFalse, next, 0, t.Filter.Token())
+	}
+	c.expr(t.Select, ModeNone)
+	c.emit(OpAppend, 0, 0, t.Tk)
+	c.emit(OpJump, next, 0, t.Tk)
+	c.patch(next)
+	c.emit(OpEndIter, 1, mode, t.Tk)
+}
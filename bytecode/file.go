@@ -0,0 +1,272 @@
+package bytecode
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+
+	"github.com/fract-lang/fract/pkg/decimal"
+)
+
+// Magic is header of bytecode files.
+const Magic = "\x00FBC"
+
+// Types of constants.
+// These are must be same with value types of oop.
+const (
+	noneConst    = 0
+	intConst     = 1
+	floatConst   = 2
+	stringConst  = 3
+	boolConst    = 4
+	bigIntConst  = 13
+	decimalConst = 14
+)
+
+type writer struct {
+	w   *bufio.Writer
+	buf [binary.MaxVarintLen64]byte
+	err error
+}
+
+func (w *writer) bytes(b []byte) {
+	if w.err == nil {
+		_, w.err = w.w.Write(b)
+	}
+}
+
+func (w *writer) int(i int) { w.bytes(w.buf[:binary.PutVarint(w.buf[:], int64(i))]) }
+
+func (w *writer) str(s string) {
+	w.int(len(s))
+	w.bytes([]byte(s))
+}
+
+func (w *writer) strs(s []string) {
+	w.int(len(s))
+	for _, str := range s {
+		w.str(str)
+	}
+}
+
+func (w *writer) bool(b bool) {
+	if b {
+		w.int(1)
+	} else {
+		w.int(0)
+	}
+}
+
+func (w *writer) code(c Code) {
+	w.int(len(c.Instrs))
+	for _, instr := range c.Instrs {
+		w.bytes([]byte{byte(instr.Op)})
+		w.int(instr.A)
+		w.int(instr.B)
+	}
+	// Line table.
+	for _, pos := range c.Pos {
+		w.int(pos.Line)
+		w.int(pos.Column)
+	}
+}
+
+// Encode program to bytecode file.
+func Encode(out io.Writer, p *Program) error {
+	w := &writer{w: bufio.NewWriter(out)}
+	w.bytes([]byte(Magic))
+	w.int(Version)
+	w.str(p.Path)
+	w.strs(p.Lines)
+	w.str(p.Package)
+	w.strs(p.Names)
+	w.int(len(p.Consts))
+	for _, c := range p.Consts {
+		w.bytes([]byte{c.Type})
+		switch c.Type {
+		case intConst:
+			w.bytes(w.buf[:binary.PutVarint(w.buf[:], c.Data.(int64))])
+		case floatConst:
+			w.bytes(w.buf[:binary.PutUvarint(w.buf[:], math.Float64bits(c.Data.(float64)))])
+		case stringConst:
+			w.str(c.Data.(string))
+		case boolConst:
+			w.bool(c.Data.(bool))
+		case bigIntConst:
+			w.str(c.Data.(*big.Int).String())
+		case decimalConst:
+			w.str(c.Data.(decimal.Decimal).String())
+		}
+	}
+	w.int(len(p.Structs))
+	for _, s := range p.Structs {
+		w.str(s.Name)
+		w.strs(s.Fields)
+	}
+	w.int(len(p.Funcs))
+	for _, f := range p.Funcs {
+		w.str(f.Name)
+		w.int(f.Line)
+		w.int(len(f.Params))
+		for _, param := range f.Params {
+			w.str(param.Name)
+			w.str(param.Type)
+			w.bool(param.Params)
+			w.bool(param.Default)
+		}
+		w.bool(f.Generator)
+		w.code(f.Code)
+	}
+	w.code(p.Main)
+	if w.err != nil {
+		return w.err
+	}
+	return w.w.Flush()
+}
+
+type reader struct {
+	r   *bufio.Reader
+	err error
+}
+
+func (r *reader) int() int {
+	if r.err != nil {
+		return 0
+	}
+	i, err := binary.ReadVarint(r.r)
+	r.err = err
+	return int(i)
+}
+
+// len returns length of sequence.
+func (r *reader) len() int {
+	n := r.int()
+	if n < 0 {
+		r.err = errors.New("invalid length")
+		return 0
+	}
+	return n
+}
+
+func (r *reader) byte() byte {
+	if r.err != nil {
+		return 0
+	}
+	b, err := r.r.ReadByte()
+	r.err = err
+	return b
+}
+
+func (r *reader) str() string {
+	n := r.len()
+	if r.err != nil {
+		return ""
+	}
+	b := make([]byte, n)
+	_, r.err = io.ReadFull(r.r, b)
+	return string(b)
+}
+
+func (r *reader) strs() []string {
+	s := make([]string, r.len())
+	for i := range s {
+		s[i] = r.str()
+	}
+	return s
+}
+
+func (r *reader) bool() bool { return r.int() == 1 }
+
+func (r *reader) code() Code {
+	var c Code
+	c.Instrs = make([]Instr, r.len())
+	for i := range c.Instrs {
+		c.Instrs[i] = Instr{Op: Opcode(r.byte()), A: r.int(), B: r.int()}
+	}
+	// Line table.
+	c.Pos = make([]Pos, len(c.Instrs))
+	for i := range c.Pos {
+		c.Pos[i] = Pos{Line: r.int(), Column: r.int()}
+	}
+	return c
+}
+
+// Decode program from bytecode file.
+func Decode(in io.Reader) (*Program, error) {
+	r := &reader{r: bufio.NewReader(in)}
+	magic := make([]byte, len(Magic))
+	if _, err := io.ReadFull(r.r, magic); err != nil || string(magic) != Magic {
+		return nil, errors.New("file is not a bytecode file")
+	}
+	if v := r.int(); r.err == nil && v != Version {
+		return nil, fmt.Errorf("bytecode version %d is not supported, expected %d", v, Version)
+	}
+	p := &Program{}
+	p.Path = r.str()
+	p.Lines = r.strs()
+	p.Package = r.str()
+	p.Names = r.strs()
+	p.Consts = make([]Const, r.len())
+	for i := range p.Consts {
+		c := Const{Type: r.byte()}
+		switch c.Type {
+		case noneConst:
+			c.Data = "none"
+		case intConst:
+			i, err := binary.ReadVarint(r.r)
+			if r.err == nil {
+				r.err = err
+			}
+			c.Data = i
+		case floatConst:
+			bits, err := binary.ReadUvarint(r.r)
+			if r.err == nil {
+				r.err = err
+			}
+			c.Data = math.Float64frombits(bits)
+		case stringConst:
+			c.Data = r.str()
+		case boolConst:
+			c.Data = r.bool()
+		case bigIntConst:
+			i, ok := new(big.Int).SetString(r.str(), 10)
+			if !ok && r.err == nil {
+				r.err = errors.New("invalid bigint constant")
+			}
+			c.Data = i
+		case decimalConst:
+			d, ok := decimal.Parse(r.str())
+			if !ok && r.err == nil {
+				r.err = errors.New("invalid decimal constant")
+			}
+			c.Data = d
+		default:
+			r.err = fmt.Errorf("invalid constant type: %d", c.Type)
+		}
+		p.Consts[i] = c
+	}
+	p.Structs = make([]Struct, r.len())
+	for i := range p.Structs {
+		p.Structs[i] = Struct{Name: r.str(), Fields: r.strs()}
+	}
+	p.Funcs = make([]*Func, r.len())
+	for i := range p.Funcs {
+		f := &Func{Name: r.str(), Line: r.int()}
+		f.Params = make([]Param, r.len())
+		for j := range f.Params {
+			f.Params[j] = Param{Name: r.str(), Type: r.str(), Params: r.bool(), Default: r.bool()}
+		}
+		f.Generator = r.bool()
+		f.Code = r.code()
+		p.Funcs[i] = f
+	}
+	p.Main = r.code()
+	if r.err != nil {
+		return nil, fmt.Errorf("corrupted bytecode file: %v", r.err)
+	}
+	return p, nil
+}
@@ -0,0 +1,101 @@
+package bytecode
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fract-lang/fract/ast"
+	"github.com/fract-lang/fract/lex"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+const code = `x := 9223372036854775807 + 1.5 - 2n * 1.10d
+const flag = true && none == none
+struct point { x, y }
+func add(a, mut b = 1, c = []) {
+    return a + b
+}
+func count(n) {
+    for i in range(1, n) {
+        yield i
+    }
+}
+try {
+    println(add(x, 'text'), point(1, 2))
+} catch DivideByZeroPanic e {
+    println(e)
+} finally {
+    match x {
+        case ^x, [h, t...] { println(h) }
+        case _ {}
+    }
+}`
+
+// compile returns program of code.
+func compile() *Program {
+	f := &obj.File{Path: "test.fract", Lines: strings.Split(code, "\n")}
+	l := &lex.Lex{File: f, Line: 1}
+	var tokens [][]obj.Token
+	for !l.Finished {
+		if tks := l.Next(); tks != nil {
+			tokens = append(tokens, tks)
+		}
+	}
+	return Compile(f, "main", ast.Build(tokens))
+}
+
+// consts returns texts of constants, bigint and decimal constants are not comparable.
+func consts(p *Program) []string {
+	texts := make([]string, len(p.Consts))
+	for i, c := range p.Consts {
+		texts[i] = fmt.Sprintf("%d:%v", c.Type, c.Data)
+	}
+	return texts
+}
+
+func TestEncodeDecode(t *testing.T) {
+	prog := compile()
+	var buf bytes.Buffer
+	if err := Encode(&buf, prog); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := consts(prog); !reflect.DeepEqual(consts(got), want) {
+		t.Errorf("got constants %v, want %v", consts(got), want)
+	}
+	got.Consts, prog.Consts = nil, nil
+	if !reflect.DeepEqual(got, prog) {
+		t.Errorf("got %+v, want %+v", got, prog)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Encode(&buf, compile()); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	version := append([]byte(Magic), 0)
+	version = append(version, data[len(Magic)+1:]...)
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"magic", []byte("package main"), "file is not a bytecode file"},
+		{"version", version, "bytecode version 0 is not supported"},
+		{"truncated", data[:len(data)/2], "corrupted bytecode file"},
+	}
+	for _, test := range tests {
+		_, err := Decode(bytes.NewReader(test.data))
+		if err == nil || !strings.HasPrefix(err.Error(), test.want) {
+			t.Errorf("%s: got %v, want %s", test.name, err, test.want)
+		}
+	}
+}
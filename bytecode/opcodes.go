@@ -0,0 +1,85 @@
+package bytecode
+
+// Opcode of instruction.
+type Opcode uint8
+
+// Opcodes.
+// Names are indexes of names of program.
+const (
+	OpConst         Opcode = iota // Push constant A with mode B.
+	OpName                        // Push value of name A with mode B.
+	OpSelect                      // Pop object and push sub field A with mode B.
+	OpEnum                        // Check top value is enumerable, A is 1 if foreach and 2 if element name of foreach is given.
+	OpIndex                       // Pop selector and enumerable, push selected elements with mode B.
+	OpBinary                      // Pop operands and push arithmetic result of operator A with mode B.
+	OpCompare                     // Pop operands and push comparison result of operator A.
+	OpCond                        // Pop value and push condition result.
+	OpJump                        // Jump to A.
+	OpJumpFalse                   // Pop condition and jump to A if false.
+	OpJumpTrueKeep                // Jump to A if condition is true, pop condition if not.
+	OpJumpFalseKeep               // Jump to A if condition is false, pop condition if not.
+	OpList                        // Pop A elements and push list with mode B.
+	OpMap                         // Push empty map with mode B.
+	OpKey                         // Check top key is not defined in map.
+	OpSetKey                      // Pop value and key, set to map.
+	OpLambda                      // Pop default values and push anonymous function A with mode B.
+	OpStruct                      // Push struct A with mode B.
+	OpCallBegin                   // Pop callee and begin call, A is 1 if callee must be function.
+	OpArg                         // Begin argument of parameter A, B is 1 if spread.
+	OpArgEnd                      // Pop argument value, B is 1 if spread.
+	OpCall                        // End call and push result with mode B.
+	OpDefer                       // End call and defer it.
+	OpGo                          // End call and call it concurrently.
+	OpPop                         // Pop value, print it in interactive shell if A is 1.
+	OpDefined                     // Check name A is not defined.
+	OpValid                       // Check top value is valid.
+	OpVar                         // Pop value and define variable A with flags B.
+	OpUnpack                      // Unpack B values to A values.
+	OpShortVar                    // Pop value and define variable A with flags B, ignore if A is negative.
+	OpConstDef                    // Pop value and define constant A.
+	OpFunc                        // Pop default values and define function A.
+	OpParamsDefault               // Check top value is default value of params parameter.
+	OpClass                       // Begin class A.
+	OpCtor                        // Set last function of class as constructor.
+	OpEndClass                    // End class and push it.
+	OpRefName                     // Push reference of name A.
+	OpRefField                    // Pop object and push reference of field A.
+	OpTarget                      // Check assignment target, B is 1 if indexed.
+	OpAssign                      // Pop value and assign to target with setter A.
+	OpScope                       // Begin scope.
+	OpEndScope                    // End scope.
+	OpIter                        // Pop enumerable and begin foreach with index name A and element name B.
+	OpCompIter                    // Pop enumerable and begin list comprehension with element name A.
+	OpNext                        // Move to next element, jump to A if finished.
+	OpAppend                      // Pop value and append to list of comprehension.
+	OpEndIter                     // End iteration, push list of comprehension with mode B if A is 1.
+	OpTry                         // Begin try block, catch block is at A, finally block of panics is at B.
+	OpEndTry                      // End try block.
+	OpCatch                       // Define variable A of catch block.
+	OpCatchType                   // Jump to B if type of panic is not A.
+	OpRethrow                     // End catch block and panic again.
+	OpFinally                     // Begin finally block of panic.
+	OpEndCatch                    // End catch block.
+	OpReturn                      // Pop A values and return, values are set already if A is -1.
+	OpSetReturn                   // Pop A values and set them as returned values.
+	OpImport                      // Push package of path A, B is 1 if standard library path.
+	OpPackage                     // Pop package and add it with alias A.
+	OpMatch                       // Pop value and begin match.
+	OpPatBegin                    // Begin pattern and push matched value.
+	OpPatName                     // Pop value and bind to name A.
+	OpPatEq                       // Pop pattern value and value, jump to A if not equal.
+	OpPatList                     // Pop list, jump to A if not matched, push B elements.
+	OpPatListRest                 // Pop list, jump to A if not matched, push rest and B-1 elements.
+	OpIsStruct                    // Jump to A if top value is not struct define.
+	OpPatStruct                   // Pop struct define and value, jump to A if not matched, push B fields.
+	OpPatBind                     // Begin scope and define bound names.
+	OpNoMatch                     // Panic with matched value.
+	OpEndMatch                    // End match.
+	OpSend                        // Pop value and channel, send value to channel.
+	OpRecv                        // Pop channel and push received value with mode B.
+	OpChanSelect                  // Pop channel operands of A cases, select case and push received value.
+	OpSelCase                     // Case of select with body at A, B is kind of case.
+	OpYield                       // Pop value and yield it, jump to A if generator is resumed.
+	OpFormat                      // Pop value and push string of it by format specifier A.
+	OpConcat                      // Pop A strings and push concatenation with mode B.
+)
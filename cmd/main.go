@@ -21,17 +21,29 @@
 package main
 
 import (
-	"bufio"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"os"
-	"path"
 	"path/filepath"
+	"regexp"
 	"strings"
 
+	interp "github.com/fract-lang/fract"
+	"github.com/fract-lang/fract/bytecode"
+	"github.com/fract-lang/fract/dap"
+	formatter "github.com/fract-lang/fract/format"
+	linter "github.com/fract-lang/fract/lint"
+	lspserver "github.com/fract-lang/fract/lsp"
+	"github.com/fract-lang/fract/oop"
 	"github.com/fract-lang/fract/parser"
+	"github.com/fract-lang/fract/pkg/diag"
 	"github.com/fract-lang/fract/pkg/fract"
 	"github.com/fract-lang/fract/pkg/obj"
+	"github.com/fract-lang/fract/pkg/readline"
 	"github.com/fract-lang/fract/pkg/str"
+	"github.com/fract-lang/fract/tester"
 )
 
 func getNamespace(cmd string) string {
@@ -50,19 +62,64 @@ func removeNamespace(cmd string) string {
 	return cmd[i+1:]
 }
 
+var (
+	stdlib string // Path of standard library.
+	rt     *parser.Runtime
+	p      *parser.Parser
+	reader *readline.Reader // Reader of interactive shell.
+)
+
+// History file of interactive shell in home directory.
+const historyFile = ".fract_history"
+
+// Keywords for completion of interactive shell.
+var keywords = []string{
+	"break", "case", "catch", "class", "const", "continue", "defer", "else",
+	"false", "finally", "for", "func", "go", "if", "in", "match", "mut", "nan",
+	"none", "open", "return", "select", "struct", "true", "try", "var", "yield",
+}
+
+// Commands of interactive shell.
+var commands = []struct{ name, usage, doc string }{
+	{"help", ":help", "Show commands."},
+	{"vars", ":vars", "Show defined variables."},
+	{"funcs", ":funcs", "Show defined functions."},
+	{"type", ":type <expr>", "Show type of expression."},
+	{"load", ":load <file>", "Interpret source file in session."},
+	{"reset", ":reset", "Clear all definitions of session."},
+	{"quit", ":quit", "Exit from shell."},
+}
+
 func input(msg string) string {
 	fmt.Print(msg)
-	//! Don't use fmt.Scanln
-	s := bufio.NewScanner(os.Stdin)
-	s.Scan()
-	return s.Text()
+	ln, _ := rt.Stdin.ReadString('\n')
+	return strings.TrimRight(ln, "\r\n")
 }
 
-var p *parser.Parser
+// readLine reads line of interactive shell.
+// Input is discarded by Ctrl-C and shell is exited by end of input.
+func readLine(prompt string) string {
+	ln, err := reader.ReadLine(prompt)
+	switch err {
+	case nil:
+		reader.AddHistory(ln)
+		return ln
+	case readline.ErrInterrupt:
+		// Panics without message are not printed by catch.
+		panic(obj.Panic{})
+	}
+	os.Exit(0)
+	return ""
+}
 
 func interpret() {
 	for {
-		p.Lex.File.Lines = []string{input(">> ")}
+		ln := readLine(">> ")
+		if cmd := strings.TrimSpace(ln); strings.HasPrefix(cmd, ":") {
+			command(cmd[1:])
+			continue
+		}
+		p.Lex.File.Lines = []string{ln}
 	reTokenize:
 		p.Tokens = nil
 	reTokenizeUnNil:
@@ -71,14 +128,20 @@ func interpret() {
 		p.Lex.Brackets = 0
 		p.Lex.Parentheses = 0
 		p.Lex.RangeComment = false
+		p.Lex.RawString = false
 		p.Lex.Line = 1
 		p.Lex.Column = 1
 		/* Tokenize all lines. */
 		for !p.Lex.Finished {
 			tks := p.Lex.Next()
+			// Check raw string.
+			if p.Lex.RawString {
+				p.Lex.File.Lines = append(p.Lex.File.Lines, []string{readLine(" | ")}...)
+				goto reTokenize
+			}
 			// Check multiline comment.
 			if p.Lex.RangeComment {
-				p.Lex.File.Lines = append(p.Lex.File.Lines, []string{input(" | ")}...)
+				p.Lex.File.Lines = append(p.Lex.File.Lines, []string{readLine(" | ")}...)
 				goto reTokenizeUnNil
 			}
 			// cacheTokens are empty?
@@ -87,12 +150,211 @@ func interpret() {
 			}
 			// Check parentheses.
 			if p.Lex.Braces > 0 || p.Lex.Brackets > 0 || p.Lex.Parentheses > 0 {
-				p.Lex.File.Lines = append(p.Lex.File.Lines, []string{input(" | ")}...)
+				p.Lex.File.Lines = append(p.Lex.File.Lines, []string{readLine(" | ")}...)
 				goto reTokenize
 			}
 			p.Tokens = append(p.Tokens, tks)
 		}
-		p.Interpret()
+		exit(p.Run(), false)
+	}
+}
+
+// newSession returns new parser of interactive shell.
+func newSession() *parser.Parser {
+	p := parser.NewStdin(rt)
+	p.AddBuiltInFuncs()
+	return p
+}
+
+// command processes colon command of interactive shell.
+func command(cmd string) {
+	name, arg := getNamespace(cmd), strings.TrimSpace(removeNamespace(cmd))
+	switch name {
+	case "help":
+		for _, c := range commands {
+			fmt.Println(c.usage + " " + str.Full(14-len(c.usage), ' ') + c.doc)
+		}
+	case "vars":
+		for _, v := range p.SessionDefs().Vars {
+			fmt.Println(v.Name + " = " + v.Val.String())
+		}
+	case "funcs":
+		for _, f := range p.SessionDefs().Funcs {
+			fmt.Println(f.Signature())
+		}
+	case "type":
+		if arg == "" {
+			fmt.Println("Usage: :type <expr>")
+			return
+		}
+		val, err := p.Value(arg)
+		if err != nil {
+			exit(err, false)
+			return
+		}
+		switch val.Type {
+		case oop.StructIns:
+			fmt.Println(oop.TypeName(val.Type) + " of " + val.Data.(oop.StructInstance).Name)
+		case oop.ClassIns:
+			fmt.Println(oop.TypeName(val.Type) + " of " + val.Data.(oop.ClassInstance).Name)
+		default:
+			fmt.Println(oop.TypeName(val.Type))
+		}
+	case "load":
+		if arg == "" {
+			fmt.Println("Usage: :load <file>")
+			return
+		} else if !strings.HasSuffix(arg, fract.Extension) {
+			arg += fract.Extension
+		}
+		if info, err := os.Stat(arg); err != nil || info.IsDir() {
+			fmt.Println("The Fract file is not exists: " + arg)
+			return
+		}
+		exit(p.Load(arg), false)
+	case "reset":
+		p = newSession()
+	case "quit", "q":
+		os.Exit(0)
+	default:
+		fmt.Println("There is no such command! Use :help for commands.")
+	}
+}
+
+func isNameByte(b byte) bool {
+	return b == '_' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9'
+}
+
+// complete returns candidates of word before cursor for interactive shell.
+// Names after dot are completed by members of package or variable before dot.
+func complete(head string) (int, []string) {
+	trimmed := strings.TrimLeft(head, " \t")
+	if strings.HasPrefix(trimmed, ":") {
+		start := len(head) - len(trimmed)
+		if i := strings.IndexByte(trimmed, ' '); i != -1 {
+			if getNamespace(trimmed[1:]) != "load" {
+				return 0, nil
+			}
+			start += i + 1
+			for start < len(head) && head[start] == ' ' {
+				start++
+			}
+			return start, completeFile(head[start:])
+		}
+		var names []string
+		for _, c := range commands {
+			names = append(names, ":"+c.name)
+		}
+		return start, filter(names, trimmed)
+	}
+	start := len(head)
+	for start > 0 && isNameByte(head[start-1]) {
+		start--
+	}
+	var names []string
+	if start > 0 && head[start-1] == '.' {
+		i := start - 1
+		for i > 0 && isNameByte(head[i-1]) {
+			i--
+		}
+		names = members(head[i : start-1])
+	} else {
+		defs := p.Defs()
+		for _, f := range defs.Funcs {
+			names = append(names, f.Name)
+		}
+		for _, v := range defs.Vars {
+			names = append(names, v.Name)
+		}
+		names = append(names, p.Packages()...)
+		names = append(names, keywords...)
+	}
+	return start, filter(names, head[start:])
+}
+
+// members returns names of members of package or variable.
+func members(name string) []string {
+	var names []string
+	if defs, ok := p.Package(name); ok {
+		// Only public names are accessible from other packages.
+		for _, f := range defs.Funcs {
+			if f.Name[0] >= 'A' && f.Name[0] <= 'Z' {
+				names = append(names, f.Name)
+			}
+		}
+		for _, v := range defs.Vars {
+			if v.Name[0] >= 'A' && v.Name[0] <= 'Z' {
+				names = append(names, v.Name)
+			}
+		}
+		return names
+	}
+	v := p.Var(name)
+	if v == nil {
+		return nil
+	}
+	var defs oop.DefMap
+	switch v.Val.Type {
+	case oop.List:
+		defs = oop.NewListModel().Defs
+	case oop.String:
+		defs = oop.NewStringModel("").Defs
+	case oop.Map:
+		defs = oop.NewMapModel().Defs
+	case oop.StructIns:
+		defs = v.Val.Data.(oop.StructInstance).Fields
+	case oop.ClassIns:
+		defs = v.Val.Data.(oop.ClassInstance).Defs
+	}
+	for _, f := range defs.Funcs {
+		names = append(names, f.Name)
+	}
+	for _, v := range defs.Vars {
+		names = append(names, v.Name)
+	}
+	return names
+}
+
+// completeFile returns source files and directories that starts with path.
+func completeFile(path string) []string {
+	matches, _ := filepath.Glob(path + "*")
+	var names []string
+	for _, m := range matches {
+		if info, err := os.Stat(m); err == nil && info.IsDir() {
+			names = append(names, m+string(filepath.Separator))
+		} else if strings.HasSuffix(m, fract.Extension) {
+			names = append(names, m)
+		}
+	}
+	return names
+}
+
+// filter returns unique names that starts with prefix.
+func filter(names []string, prefix string) []string {
+	var res []string
+	seen := map[string]bool{}
+	for _, name := range names {
+		if strings.HasPrefix(name, prefix) && !seen[name] {
+			seen[name] = true
+			res = append(res, name)
+		}
+	}
+	return res
+}
+
+// exit prints error of interpreter and exits if required.
+// Program is exited by exit code if exited by exit function.
+func exit(err error, fail bool) {
+	var e interp.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &e):
+		os.Exit(e.Code)
+	default:
+		fmt.Println(err)
+		if fail {
+			os.Exit(1)
+		}
 	}
 }
 
@@ -111,6 +373,13 @@ func help(cmd string) {
 	helpMap := map[string]string{
 		"version": "Show version.",
 		"help":    "Show help.",
+		"build":   "Compile source file to bytecode file.",
+		"check":   "Report all errors of source files.",
+		"lsp":     "Run language server over standard input and output.",
+		"fmt":     "Format source files.",
+		"lint":    "Report suspicious codes of source files.",
+		"test":    "Run tests of test files.",
+		"debug":   "Run debug adapter over standard input and output or TCP.",
 	}
 	maxKeyLen := 0
 	for k := range helpMap {
@@ -132,31 +401,397 @@ func version(cmd string) {
 	fmt.Println("Fract Version [" + fract.Version + "]")
 }
 
+// build module is compile source file to bytecode file.
+// Exits with code 1 if file is not compiled.
+func build(cmd string) {
+	args := strings.Fields(cmd)
+	if len(args) == 0 {
+		fmt.Println("This module cannot only be used!")
+		return
+	}
+	src, out := args[0], ""
+	if !strings.HasSuffix(src, fract.Extension) {
+		src += fract.Extension
+	}
+	switch {
+	case len(args) == 3 && args[1] == "-o":
+		out = args[2]
+	case len(args) == 1:
+		out = strings.TrimSuffix(src, fract.Extension) + fract.BytecodeExtension
+	default:
+		fmt.Println("Usage: build <file> [-o <output>]")
+		return
+	}
+	if info, err := os.Stat(src); err != nil || info.IsDir() {
+		fmt.Println("The Fract file is not exists: " + src)
+		os.Exit(1)
+	}
+	prog, err := interp.New(interp.Options{StdLib: stdlib}).Compile(src)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	f, err := os.Create(out)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	err = bytecode.Encode(f, prog)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(out)
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// check module is report diagnostics of source files.
+// Exits with code 1 if any file has error.
+func check(cmd string) {
+	args := strings.Fields(cmd)
+	asJSON := len(args) > 0 && args[0] == "--json"
+	if asJSON {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		fmt.Println("Usage: check [--json] <file>...")
+		return
+	}
+	diags := []interp.Diagnostic{}
+	for _, src := range args {
+		if !strings.HasSuffix(src, fract.Extension) {
+			src += fract.Extension
+		}
+		ds, err := interp.New(interp.Options{StdLib: stdlib}).Check(src)
+		if err != nil {
+			fmt.Println("The Fract file is not exists: " + src)
+			os.Exit(1)
+		}
+		diags = append(diags, ds...)
+	}
+	failed := false
+	for _, d := range diags {
+		failed = failed || d.Severity == diag.Error
+	}
+	if asJSON {
+		bytes, _ := json.MarshalIndent(diags, "", "  ")
+		fmt.Println(string(bytes))
+	} else {
+		for _, d := range diags {
+			fmt.Println(d)
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// format module is format source files.
+// Formatted codes are printed if files are not written with -w.
+// Diffs are printed with -d and exits with code 1 if any file is not formatted.
+func format(cmd string) {
+	var (
+		files        []string
+		write, diffs bool
+	)
+	for _, arg := range strings.Fields(cmd) {
+		switch arg {
+		case "-w":
+			write = true
+		case "-d":
+			diffs = true
+		default:
+			files = append(files, arg)
+		}
+	}
+	if len(files) == 0 {
+		fmt.Println("Usage: fmt [-w] [-d] <file>...")
+		return
+	}
+	failed := false
+	for _, src := range files {
+		if !strings.HasSuffix(src, fract.Extension) {
+			src += fract.Extension
+		}
+		info, err := os.Stat(src)
+		if err != nil || info.IsDir() {
+			fmt.Println("The Fract file is not exists: " + src)
+			failed = true
+			continue
+		}
+		code, err := os.ReadFile(src)
+		if err != nil {
+			fmt.Println(err)
+			failed = true
+			continue
+		}
+		out, err := formatter.Source(src, code)
+		if err != nil {
+			fmt.Println(err)
+			failed = true
+			continue
+		}
+		changed := string(out) != string(code)
+		if diffs && changed {
+			fmt.Print(formatter.Diff(src, code, out))
+			failed = true
+		}
+		if write && changed {
+			if err := os.WriteFile(src, out, info.Mode()); err != nil {
+				fmt.Println(err)
+				failed = true
+			}
+		} else if !write && !diffs {
+			fmt.Print(string(out))
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// lint module is report diagnostics of analyzers for source files.
+// Analyzers are configured by nearest config file of source file.
+// Exits with code 1 if any file has error.
+func lint(cmd string) {
+	args := strings.Fields(cmd)
+	asJSON := len(args) > 0 && args[0] == "--json"
+	if asJSON {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		fmt.Println("Usage: lint [--json] <file>...")
+		return
+	}
+	var builtins []string
+	defs := rt.BuiltIns()
+	for _, f := range defs.Funcs {
+		builtins = append(builtins, f.Name)
+	}
+	for _, v := range defs.Vars {
+		builtins = append(builtins, v.Name)
+	}
+	diags := diag.List{}
+	for _, src := range args {
+		if !strings.HasSuffix(src, fract.Extension) {
+			src += fract.Extension
+		}
+		code, err := os.ReadFile(src)
+		if err != nil {
+			fmt.Println("The Fract file is not exists: " + src)
+			os.Exit(1)
+		}
+		cfg, err := linter.LoadConfig(filepath.Dir(src))
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		p := parser.NewSource(rt, src, string(code))
+		// Analyzers are not run for invalid syntax trees.
+		if ds := p.Check(); len(ds) > 0 {
+			diags = append(diags, ds...)
+			continue
+		}
+		diags = append(diags, linter.New(cfg, builtins).Lint(src, p.Tree())...)
+	}
+	if asJSON {
+		bytes, _ := json.MarshalIndent(diags, "", "  ")
+		fmt.Println(string(bytes))
+	} else {
+		for _, d := range diags {
+			fmt.Println(d)
+			if d.Fix != "" {
+				fmt.Println("    fix: " + d.Fix)
+			}
+		}
+	}
+	if diags.HasErrors() {
+		os.Exit(1)
+	}
+}
+
+// test module is run tests of test files that matches to patterns.
+// Current directory is used if patterns are not given.
+// Exits with code 1 if any test is failed or any pattern is not matches.
+func test(cmd string) {
+	var (
+		patterns []string
+		verbose  bool
+		run      *regexp.Regexp
+	)
+	args := strings.Fields(cmd)
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-v":
+			verbose = true
+		case "-run":
+			if i+1 == len(args) {
+				fmt.Println("Usage: test [-v] [-run <regexp>] [pattern]...")
+				return
+			}
+			i++
+			var err error
+			if run, err = regexp.Compile(args[i]); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		default:
+			patterns = append(patterns, args[i])
+		}
+	}
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+	var files []string
+	for _, pattern := range patterns {
+		fs, err := tester.Files(pattern)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		files = append(files, fs...)
+	}
+	if len(files) == 0 {
+		fmt.Println("No test files.")
+		return
+	}
+	r := &tester.Runner{StdLib: stdlib, Run: run}
+	failed := false
+	for _, file := range files {
+		passed, skipped, fileFailed := 0, 0, false
+		for _, result := range r.File(file) {
+			switch result.Status {
+			case tester.Pass:
+				passed++
+			case tester.Skip:
+				skipped++
+			case tester.Fail:
+				fileFailed = true
+			}
+			if result.Name == "" {
+				fmt.Println(result.Msg)
+				continue
+			}
+			if verbose || result.Status != tester.Pass {
+				fmt.Println("--- " + result.Status.String() + ": " + result.Name)
+			}
+			if result.Status == tester.Fail && result.Pos != "" {
+				fmt.Println("    " + result.Pos + ": " + result.Msg)
+			} else if result.Msg != "" {
+				fmt.Println("    " + result.Msg)
+			}
+		}
+		if fileFailed {
+			failed = true
+			fmt.Println("FAIL " + file)
+		} else {
+			fmt.Printf("ok   %s (%d passed, %d skipped)\n", file, passed, skipped)
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// lsp module is run language server.
+func lsp(cmd string) {
+	if cmd != "" {
+		fmt.Println("This module can only be used!")
+		return
+	}
+	if err := lspserver.NewServer(rt, os.Stdin, os.Stdout).Serve(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// debug module is run debug adapter for source file.
+// Adapter listens local TCP address with --listen and serves first connection,
+// standard input and output are used if not given.
+func debug(cmd string) {
+	var addr, src string
+	args := strings.Fields(cmd)
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--listen" && i+1 < len(args):
+			i++
+			addr = args[i]
+		case src == "" && !strings.HasPrefix(args[i], "-"):
+			src = args[i]
+			if !strings.HasSuffix(src, fract.Extension) {
+				src += fract.Extension
+			}
+		default:
+			fmt.Println("Usage: debug [--listen <address>] [file]")
+			return
+		}
+	}
+	if addr == "" {
+		s := dap.NewServer(os.Stdin, os.Stdout)
+		s.StdLib, s.Program = stdlib, src
+		if err := s.Serve(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	// Only local connections are accepted if host is not given.
+	if strings.HasPrefix(addr, ":") {
+		addr = "127.0.0.1" + addr
+	}
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	defer l.Close()
+	fmt.Println("Debug adapter listening at " + l.Addr().String())
+	conn, err := l.Accept()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	defer conn.Close()
+	s := dap.NewServer(conn, conn)
+	s.StdLib, s.Program, s.Stdin = stdlib, src, os.Stdin
+	if err := s.Serve(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
 // make module is interpret source file.
 func make(cmd string) {
 	if cmd == "" {
 		fmt.Println("This module cannot only be used!")
 		return
-	} else if !strings.HasSuffix(cmd, fract.Extension) {
-		cmd += fract.Extension
+	} else if strings.HasSuffix(cmd, fract.BytecodeExtension) {
+		if info, err := os.Stat(cmd); err != nil || info.IsDir() {
+			fmt.Println("The bytecode file is not exists: " + cmd)
+			return
+		}
+	} else {
+		if !strings.HasSuffix(cmd, fract.Extension) {
+			cmd += fract.Extension
+		}
+		if info, err := os.Stat(cmd); err != nil || info.IsDir() {
+			fmt.Println("The Fract file is not exists: " + cmd)
+			return
+		}
 	}
-	if info, err := os.Stat(cmd); err != nil || info.IsDir() {
-		fmt.Println("The Fract file is not exists: " + cmd)
-		return
+	err := interp.New(interp.Options{StdLib: stdlib}).RunFile(cmd)
+	var p obj.Panic
+	if err != nil && !errors.As(err, &p) && !errors.As(err, new(interp.ExitError)) {
+		err = errors.New(cmd + ": " + err.Error())
 	}
-	p := parser.New(cmd)
-	p.AddBuiltInFuncs()
-	(&obj.Block{
-		Try: p.Interpret,
-		Catch: func(e obj.Panic) {
-			os.Exit(0)
-		},
-	}).Do()
+	exit(err, true)
 }
 
 // makeCheck is check command is valid source code path or not.
 func makeCheck(path string) bool {
-	if strings.HasSuffix(path, fract.Extension) {
+	if strings.HasSuffix(path, fract.Extension) || strings.HasSuffix(path, fract.BytecodeExtension) {
 		return true
 	}
 	info, err := os.Stat(path + fract.Extension)
@@ -169,6 +804,20 @@ func processCommand(namespace, cmd string) {
 		help(cmd)
 	case "version":
 		version(cmd)
+	case "build":
+		build(cmd)
+	case "check":
+		check(cmd)
+	case "lsp":
+		lsp(cmd)
+	case "fmt":
+		format(cmd)
+	case "lint":
+		lint(cmd)
+	case "test":
+		test(cmd)
+	case "debug":
+		debug(cmd)
 	default:
 		if makeCheck(namespace) {
 			make(namespace)
@@ -179,9 +828,10 @@ func processCommand(namespace, cmd string) {
 }
 
 func init() {
-	fract.ExecutablePath = filepath.Dir(os.Args[0])
+	stdlib = filepath.Join(filepath.Dir(os.Args[0]), fract.StdLib)
+	rt = parser.NewRuntime(stdlib)
 	// Check standard library.
-	if info, err := os.Stat(path.Join(fract.ExecutablePath, fract.StdLib)); err != nil || !info.IsDir() {
+	if info, err := os.Stat(stdlib); err != nil || !info.IsDir() {
 		fmt.Println("Standard library not found!")
 		input("\nPress enter for exit...")
 		os.Exit(1)
@@ -201,10 +851,14 @@ func init() {
 }
 
 func main() {
-	fmt.Println("Fract " + fract.Version + " (c) MIT License.\n" + "Fract Developer Team.\n")
-	fract.InteractiveShell = true
-	p = parser.NewStdin()
-	p.AddBuiltInFuncs()
+	fmt.Print("Fract " + fract.Version + " (c) MIT License.\n" + "Fract Developer Team.\n\n")
+	rt.Interactive = true
+	p = newSession()
+	reader = readline.New(rt.Stdin, int(os.Stdin.Fd()), os.Stdout)
+	reader.Complete = complete
+	if home, err := os.UserHomeDir(); err == nil {
+		reader.LoadHistory(filepath.Join(home, historyFile))
+	}
 	b := &obj.Block{
 		Try:   interpret,
 		Catch: catch,
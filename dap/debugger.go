@@ -0,0 +1,292 @@
+package dap
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/fract-lang/fract/ast"
+	"github.com/fract-lang/fract/oop"
+	"github.com/fract-lang/fract/parser"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// Modes of running.
+type mode uint8
+
+const (
+	run      mode = iota
+	pause         // Stop at next statement.
+	stepIn        // Stop at next statement of any function.
+	stepOver      // Stop at next statement of same or outer function.
+	stepOut       // Stop at next statement of outer function.
+	entry         // Stop at first statement.
+)
+
+type position struct {
+	file string // Absolute path.
+	line int
+}
+
+// debugger pauses interpreting by breakpoints and steps.
+type debugger struct {
+	mu          sync.Mutex
+	breakpoints map[string]map[int]bool // Lines by absolute paths of files.
+	panics      bool                    // Stop at panics.
+	mode        mode
+	depth       int      // Depth of last stop.
+	from        position // Position of last stop, cleared when left.
+	fromStmt    ast.Stmt // Statement of last stop.
+	reported    bool     // Panic is reported, cleared by next statement.
+	stop        func(reason, text string)
+	resume      chan struct{} // Nil if not stopped.
+	frames      []parser.Frame
+	refs        []interface{} // Values of variable references, reference is index plus one.
+}
+
+func newDebugger(stop func(reason, text string)) *debugger {
+	return &debugger{breakpoints: map[string]map[int]bool{}, panics: true, stop: stop}
+}
+
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+// setBreakpoints replaces breakpoints of file.
+func (d *debugger) setBreakpoints(path string, lines []int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	m := map[int]bool{}
+	for _, ln := range lines {
+		m[ln] = true
+	}
+	d.breakpoints[absPath(path)] = m
+}
+
+func (d *debugger) Stmt(s *parser.State) {
+	tk := s.Stmt().Token()
+	pos := position{line: tk.Line}
+	if tk.File != nil {
+		pos.file = absPath(tk.File.Path)
+	}
+	d.mu.Lock()
+	d.reported = false
+	// Other statements at line of last stop are skipped,
+	// statement of last stop is stopped again by loops.
+	moved := pos != d.from || s.Depth() != d.depth || s.Stmt() == d.fromStmt
+	reason := ""
+	switch {
+	case d.mode == pause:
+		reason = "pause"
+	case d.mode == entry:
+		reason = "entry"
+	case d.mode == stepIn && moved,
+		d.mode == stepOver && moved && s.Depth() <= d.depth,
+		d.mode == stepOut && s.Depth() < d.depth:
+		reason = "step"
+	case d.breakpoints[pos.file][pos.line] && moved:
+		reason = "breakpoint"
+	}
+	// Position of last stop is left if not in called function.
+	if moved && s.Depth() <= d.depth {
+		d.from, d.fromStmt = position{}, nil
+	}
+	if reason == "" {
+		d.mu.Unlock()
+		return
+	}
+	d.pause(s, pos, reason, "")
+}
+
+func (d *debugger) Panic(s *parser.State, cp obj.Panic) {
+	d.mu.Lock()
+	if !d.panics || d.reported {
+		d.mu.Unlock()
+		return
+	}
+	d.reported = true
+	pos := position{file: absPath(cp.File), line: cp.Line}
+	d.pause(s, pos, "exception", cp.Type+": "+cp.Text)
+}
+
+// pause interpreting until resume.
+// Lock of debugger is must be held and unlocked by pause.
+func (d *debugger) pause(s *parser.State, pos position, reason, text string) {
+	d.frames = s.Frames()
+	d.refs = nil
+	d.depth = s.Depth()
+	d.from, d.fromStmt = pos, s.Stmt()
+	d.mode = run
+	resume := make(chan struct{})
+	d.resume = resume
+	d.mu.Unlock()
+	d.stop(reason, text)
+	<-resume
+}
+
+// continueWith resumes interpreting with mode.
+// Returns false if interpreting is not paused.
+func (d *debugger) continueWith(m mode) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if m == pause {
+		if d.resume == nil {
+			d.mode = pause
+		}
+		return true
+	} else if d.resume == nil {
+		return false
+	}
+	d.mode = m
+	d.frames = nil
+	d.refs = nil
+	close(d.resume)
+	d.resume = nil
+	return true
+}
+
+// stackFrames returns frames of stopped state.
+func (d *debugger) stackFrames() []parser.Frame {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.frames
+}
+
+// ref returns variable reference of value.
+func (d *debugger) ref(v interface{}) int {
+	d.refs = append(d.refs, v)
+	return len(d.refs)
+}
+
+// scopes returns scopes of frame.
+func (d *debugger) scopes(frame int) ([]scope, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if frame < 0 || frame >= len(d.frames) {
+		return nil, fmt.Errorf("frame is not exist: %d", frame)
+	}
+	f := d.frames[frame]
+	scopes := []scope{}
+	if f.Locals != nil {
+		scopes = append(scopes, scope{Name: "Locals", VariablesReference: d.ref(f.Locals)})
+	}
+	scopes = append(scopes, scope{Name: "Globals", VariablesReference: d.ref(f.Globals)})
+	return scopes, nil
+}
+
+// variables returns children of variable reference.
+func (d *debugger) variables(ref int) ([]variable, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if ref < 1 || ref > len(d.refs) {
+		return nil, fmt.Errorf("variable reference is not exist: %d", ref)
+	}
+	vars := []variable{}
+	switch t := d.refs[ref-1].(type) {
+	case []oop.VarDef:
+		for _, v := range t {
+			vars = append(vars, d.variable(v.Name, v.Val))
+		}
+	case oop.Val:
+		switch t.Type {
+		case oop.List:
+			for i, e := range t.Data.(*oop.ListModel).Elems {
+				vars = append(vars, d.variable("["+strconv.Itoa(i)+"]", e))
+			}
+		case oop.Map:
+			m := t.Data.(oop.MapModel).Map
+			keys := make([]oop.Val, 0, len(m))
+			for k := range m {
+				keys = append(keys, k)
+			}
+			sort.Slice(keys, func(i, j int) bool { return display(keys[i]) < display(keys[j]) })
+			for _, k := range keys {
+				vars = append(vars, d.variable(display(k), m[k]))
+			}
+		case oop.StructIns:
+			for _, v := range t.Data.(oop.StructInstance).Fields.Vars {
+				vars = append(vars, d.variable(v.Name, v.Val))
+			}
+		case oop.ClassIns:
+			for _, v := range t.Data.(oop.ClassInstance).Defs.Vars {
+				vars = append(vars, d.variable(v.Name, v.Val))
+			}
+		}
+	}
+	return vars, nil
+}
+
+// evaluate returns variable of name in frame.
+func (d *debugger) evaluate(frame int, name string) (variable, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if frame < 0 || frame >= len(d.frames) {
+		return variable{}, fmt.Errorf("program is not paused")
+	}
+	f := d.frames[frame]
+	for _, vars := range [][]oop.VarDef{f.Locals, f.Globals} {
+		// Latest define is visible.
+		for i := len(vars) - 1; i >= 0; i-- {
+			if vars[i].Name == name {
+				return d.variable(name, vars[i].Val), nil
+			}
+		}
+	}
+	return variable{}, fmt.Errorf("name is not defined: %s", name)
+}
+
+// variable returns variable of value, values that have elements or fields are expandable.
+func (d *debugger) variable(name string, v oop.Val) variable {
+	res := variable{Name: name, Value: display(v), Type: typeName(v)}
+	switch v.Type {
+	case oop.List, oop.Map, oop.StructIns, oop.ClassIns:
+		res.VariablesReference = d.ref(v)
+	}
+	return res
+}
+
+// display returns text of value, strings are quoted.
+func display(v oop.Val) string {
+	switch v.Type {
+	case oop.String:
+		return strconv.Quote(v.String())
+	case oop.StructIns:
+		return v.Data.(oop.StructInstance).Name + v.String()[6:]
+	case oop.ClassIns:
+		return v.Data.(oop.ClassInstance).Name + "{...}"
+	}
+	return v.String()
+}
+
+// Names of types by codes.
+var typeNames = [...]string{
+	oop.None:      "none",
+	oop.Int:       "int",
+	oop.Float:     "float",
+	oop.String:    "string",
+	oop.Bool:      "bool",
+	oop.Func:      "func",
+	oop.List:      "list",
+	oop.Map:       "map",
+	oop.Package:   "package",
+	oop.StructDef: "struct",
+	oop.StructIns: "struct instance",
+	oop.ClassDef:  "class",
+	oop.ClassIns:  "class instance",
+	oop.BigInt:    "bigint",
+	oop.Decimal:   "decimal",
+	oop.Chan:      "chan",
+	oop.Iter:      "iterator",
+}
+
+func typeName(v oop.Val) string {
+	if int(v.Type) < len(typeNames) {
+		return typeNames[v.Type]
+	}
+	return ""
+}
@@ -0,0 +1,275 @@
+// Package dap implements Debug Adapter Protocol server of Fract.
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fract-lang/fract/parser"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// Thread of main goroutine, goroutines are not debugged.
+const mainThread = 1
+
+// Server of Debug Adapter Protocol.
+// Server debugs one program and program is started by configurationDone request.
+type Server struct {
+	StdLib  string    // Path of standard library.
+	Program string    // Program that debugged if not given by launch request.
+	Stdin   io.Reader // Input of program, defaults to empty input.
+
+	in      *bufio.Reader
+	out     io.Writer
+	mu      sync.Mutex // Guards writes of messages.
+	seq     int
+	d       *debugger
+	launch  *launchArguments
+	started bool
+}
+
+// NewServer returns server that reads requests from in and writes responses to out.
+func NewServer(in io.Reader, out io.Writer) *Server {
+	s := &Server{in: bufio.NewReader(in), out: out}
+	s.d = newDebugger(func(reason, text string) {
+		s.event("stopped", stoppedEvent{
+			Reason:            reason,
+			Description:       text,
+			Text:              text,
+			ThreadID:          mainThread,
+			AllThreadsStopped: true,
+		})
+	})
+	return s
+}
+
+// Serve processes requests until disconnect request.
+// Returns error if connection is broken.
+func (s *Server) Serve() error {
+	for {
+		data, err := readMessage(s.in)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		var req request
+		if err := json.Unmarshal(data, &req); err != nil {
+			return err
+		}
+		body, err := s.process(&req)
+		res := response{Type: "response", RequestSeq: req.Seq, Success: err == nil, Command: req.Command, Body: body}
+		if err != nil {
+			res.Message = err.Error()
+		}
+		s.send(&res)
+		switch req.Command {
+		case "initialize":
+			s.event("initialized", nil)
+		case "disconnect", "terminate":
+			return nil
+		}
+	}
+}
+
+// send message with next sequence number.
+func (s *Server) send(msg interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seq++
+	switch t := msg.(type) {
+	case *response:
+		t.Seq = s.seq
+	case *event:
+		t.Seq = s.seq
+	}
+	writeMessage(s.out, msg)
+}
+
+func (s *Server) event(name string, body interface{}) {
+	s.send(&event{Type: "event", Event: name, Body: body})
+}
+
+// process returns body of response of request.
+func (s *Server) process(req *request) (interface{}, error) {
+	unmarshal := func(v interface{}) error {
+		if len(req.Arguments) == 0 {
+			return nil
+		}
+		return json.Unmarshal(req.Arguments, v)
+	}
+	switch req.Command {
+	case "initialize":
+		return map[string]interface{}{
+			"supportsConfigurationDoneRequest": true,
+			"supportsEvaluateForHovers":        true,
+			"supportsTerminateRequest":         true,
+			"exceptionBreakpointFilters": []exceptionBreakpointsFilter{
+				{Filter: "panic", Label: "Panics", Default: true},
+			},
+		}, nil
+	case "launch":
+		var args launchArguments
+		if err := unmarshal(&args); err != nil {
+			return nil, err
+		}
+		if args.Program == "" {
+			args.Program = s.Program
+		}
+		if info, err := os.Stat(args.Program); err != nil || info.IsDir() {
+			return nil, fmt.Errorf("file is not exists: %s", args.Program)
+		}
+		s.launch = &args
+		return nil, nil
+	case "setBreakpoints":
+		var args setBreakpointsArguments
+		if err := unmarshal(&args); err != nil {
+			return nil, err
+		}
+		lines := make([]int, len(args.Breakpoints))
+		bps := make([]breakpoint, len(args.Breakpoints))
+		for i, bp := range args.Breakpoints {
+			lines[i] = bp.Line
+			bps[i] = breakpoint{Verified: true, Line: bp.Line}
+		}
+		s.d.setBreakpoints(args.Source.Path, lines)
+		return map[string]interface{}{"breakpoints": bps}, nil
+	case "setExceptionBreakpoints":
+		var args setExceptionBreakpointsArguments
+		if err := unmarshal(&args); err != nil {
+			return nil, err
+		}
+		s.d.mu.Lock()
+		s.d.panics = false
+		for _, filter := range args.Filters {
+			s.d.panics = s.d.panics || filter == "panic"
+		}
+		s.d.mu.Unlock()
+		return nil, nil
+	case "configurationDone":
+		if s.launch == nil {
+			return nil, errors.New("program is not launched")
+		} else if !s.started {
+			s.started = true
+			go s.run()
+		}
+		return nil, nil
+	case "threads":
+		return map[string]interface{}{"threads": []thread{{ID: mainThread, Name: "main"}}}, nil
+	case "stackTrace":
+		var args stackTraceArguments
+		if err := unmarshal(&args); err != nil {
+			return nil, err
+		}
+		frames := s.d.stackFrames()
+		res := []stackFrame{}
+		for i, f := range frames {
+			if i < args.StartFrame || args.Levels > 0 && len(res) == args.Levels {
+				continue
+			}
+			res = append(res, stackFrame{
+				ID:     i,
+				Name:   f.Func,
+				Source: &source{Name: filepath.Base(f.File), Path: absPath(f.File)},
+				Line:   f.Line,
+				Column: f.Column,
+			})
+		}
+		return map[string]interface{}{"stackFrames": res, "totalFrames": len(frames)}, nil
+	case "scopes":
+		var args scopesArguments
+		if err := unmarshal(&args); err != nil {
+			return nil, err
+		}
+		scopes, err := s.d.scopes(args.FrameID)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"scopes": scopes}, nil
+	case "variables":
+		var args variablesArguments
+		if err := unmarshal(&args); err != nil {
+			return nil, err
+		}
+		vars, err := s.d.variables(args.VariablesReference)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"variables": vars}, nil
+	case "evaluate":
+		var args evaluateArguments
+		if err := unmarshal(&args); err != nil {
+			return nil, err
+		}
+		v, err := s.d.evaluate(args.FrameID, strings.TrimSpace(args.Expression))
+		if err != nil {
+			return nil, err
+		}
+		return evaluateResponse{Result: v.Value, Type: v.Type, VariablesReference: v.VariablesReference}, nil
+	case "continue", "next", "stepIn", "stepOut", "pause":
+		m := map[string]mode{"continue": run, "next": stepOver, "stepIn": stepIn, "stepOut": stepOut, "pause": pause}[req.Command]
+		if !s.d.continueWith(m) {
+			return nil, errors.New("program is not paused")
+		}
+		if m == run {
+			return map[string]interface{}{"allThreadsContinued": true}, nil
+		}
+		return nil, nil
+	case "disconnect", "terminate":
+		return nil, nil
+	}
+	return nil, errors.New("command is not supported: " + req.Command)
+}
+
+// output writes outputs of program as output events.
+type output struct {
+	s        *Server
+	category string
+}
+
+func (o output) Write(b []byte) (int, error) {
+	o.s.event("output", outputEvent{Category: o.category, Output: string(b)})
+	return len(b), nil
+}
+
+// run program of launch and send events of exit.
+func (s *Server) run() {
+	rt := parser.NewRuntime(s.StdLib)
+	rt.Stdout = output{s: s, category: "stdout"}
+	rt.Stderr = output{s: s, category: "stderr"}
+	stdin := s.Stdin
+	if stdin == nil {
+		stdin = strings.NewReader("")
+	}
+	rt.Stdin = bufio.NewReader(stdin)
+	if !s.launch.NoDebug {
+		rt.Debugger = s.d
+		if s.launch.StopOnEntry {
+			s.d.mu.Lock()
+			s.d.mode = entry
+			s.d.mu.Unlock()
+		}
+	}
+	p := parser.New(rt, s.launch.Program)
+	p.AddBuiltInFuncs()
+	code := 0
+	if err := p.Run(); err != nil {
+		var exit obj.ExitError
+		if errors.As(err, &exit) {
+			code = exit.Code
+		} else {
+			code = 1
+			rt.Output(rt.Stderr, err.Error()+"\n")
+		}
+	}
+	s.event("exited", map[string]int{"exitCode": code})
+	s.event("terminated", nil)
+}
@@ -0,0 +1,143 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Edit of line.
+type edit struct {
+	op   byte // ' ', '-' or '+'.
+	a, b int  // Indexes of line in old and new text.
+	text string
+}
+
+// Diff returns unified diff of old and new code.
+// Returns empty string if codes are same.
+func Diff(path string, old, new []byte) string {
+	a, b := diffLines(string(old)), diffLines(string(new))
+	edits := editScript(a, b)
+	const context = 3
+	var sb strings.Builder
+	for i := 0; i < len(edits); {
+		if edits[i].op == ' ' {
+			i++
+			continue
+		}
+		// Hunk is extended while changes are closer than context lines.
+		last := i
+		for j := i + 1; j < len(edits) && j-last <= 2*context; j++ {
+			if edits[j].op != ' ' {
+				last = j
+			}
+		}
+		start, end := i-context, last+context+1
+		if start < 0 {
+			start = 0
+		}
+		if end > len(edits) {
+			end = len(edits)
+		}
+		if sb.Len() == 0 {
+			sb.WriteString("--- " + path + ".orig\n+++ " + path + "\n")
+		}
+		hunk := edits[start:end]
+		acount, bcount := 0, 0
+		for _, e := range hunk {
+			if e.op != '+' {
+				acount++
+			}
+			if e.op != '-' {
+				bcount++
+			}
+		}
+		sb.WriteString(fmt.Sprintf("@@ -%s +%s @@\n", hunkRange(hunk[0].a, acount), hunkRange(hunk[0].b, bcount)))
+		for _, e := range hunk {
+			sb.WriteString(string(e.op) + e.text + "\n")
+		}
+		i = end
+	}
+	return sb.String()
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+func diffLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// editScript returns shortest edits of lines by Myers algorithm.
+func editScript(a, b []string) []edit {
+	n, m := len(a), len(b)
+	max := n + m
+	v := make([]int, 2*max+2)
+	var trace [][]int // Copies of v before each step, indexed by k+d.
+	for d := 0; d <= max; d++ {
+		trace = append(trace, append([]int(nil), v[max-d:max+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || k != d && v[max+k-1] < v[max+k+1] {
+				x = v[max+k+1]
+			} else {
+				x = v[max+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[max+k] = x
+			if x >= n && y >= m {
+				return backtrack(a, b, trace)
+			}
+		}
+	}
+	return nil
+}
+
+func backtrack(a, b []string, trace [][]int) []edit {
+	x, y := len(a), len(b)
+	var edits []edit
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		get := func(k int) int { return v[k+d] }
+		k := x - y
+		var prevK int
+		if k == -d || k != d && get(k-1) < get(k+1) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := 0
+		if d > 0 {
+			prevX = get(prevK)
+		}
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, edit{op: ' ', a: x, b: y, text: a[x]})
+		}
+		if d > 0 {
+			if x == prevX {
+				edits = append(edits, edit{op: '+', a: x, b: prevY, text: b[prevY]})
+			} else {
+				edits = append(edits, edit{op: '-', a: prevX, b: y, text: a[prevX]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
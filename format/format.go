@@ -0,0 +1,436 @@
+// Package format implements canonical formatting of Fract code.
+package format
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fract-lang/fract/lex"
+	"github.com/fract-lang/fract/pkg/fract"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+const indent = "    "
+
+// line is output line of code.
+type line struct {
+	tokens []obj.Token
+	blank  bool // Blank line is before line.
+	depth  int  // Indent count.
+	pad    int  // Spaces after name of aligned variable.
+	text   string
+	// Offsets of text to align, -1 if not exist.
+	comment int // Before trailing comment.
+	brace   int // Before block of single line case.
+}
+
+// Source returns formatted code.
+// Returns error if code is not tokenized.
+func Source(path string, src []byte) (out []byte, err error) {
+	code := string(src)
+	lines := strings.Split(code, "\n")
+	for i, ln := range lines {
+		lines[i] = strings.TrimRightFunc(ln, unicode.IsSpace)
+	}
+	l := &lex.Lex{File: &obj.File{Path: path, Lines: lines}}
+	var tokens []obj.Token
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				cp, ok := r.(obj.Panic)
+				if !ok {
+					panic(r)
+				}
+				err = cp
+			}
+		}()
+		tokens = l.Source()
+	}()
+	if err != nil {
+		return nil, err
+	}
+	fl := split(tokens)
+	fl = join(fl)
+	fl = breakBlocks(fl)
+	indentLines(fl)
+	align(fl)
+	p := new(printer)
+	for _, ln := range fl {
+		p.print(ln)
+	}
+	alignColumns(fl, func(ln *line) *int { return &ln.brace })
+	alignColumns(fl, func(ln *line) *int { return &ln.comment })
+	var sb strings.Builder
+	for i, ln := range fl {
+		if ln.blank && i > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(strings.Repeat(indent, ln.depth) + ln.text + "\n")
+	}
+	text := sb.String()
+	if strings.Contains(code, "\r\n") {
+		text = strings.ReplaceAll(text, "\n", "\r\n")
+	}
+	return []byte(text), nil
+}
+
+func isOpen(tk obj.Token) bool {
+	return tk.Type == fract.Brace && (tk.Val == "(" || tk.Val == "[" || tk.Val == "{")
+}
+
+func isClose(tk obj.Token) bool {
+	return tk.Type == fract.Brace && (tk.Val == ")" || tk.Val == "]" || tk.Val == "}")
+}
+
+// operand reports token is end of operand.
+func operand(tk obj.Token) bool {
+	switch tk.Type {
+	case fract.Name, fract.Value, fract.Interpolation, fract.None:
+		return true
+	}
+	return isClose(tk)
+}
+
+// split returns lines of tokens.
+// Statements that separated by statement terminators are placed to new lines.
+func split(tokens []obj.Token) []*line {
+	var (
+		lines []*line
+		ln    *line
+		end   int // Last line of previous token.
+		depth int // Count of open braces.
+		start int // Count of open braces at begin of line.
+	)
+	for _, tk := range tokens {
+		if tk.Type == fract.StatementTerminator && depth == start {
+			ln = nil
+			continue
+		}
+		if ln == nil || tk.Line > end {
+			ln = &line{blank: tk.Line > end+1}
+			lines = append(lines, ln)
+			start = depth
+		}
+		ln.tokens = append(ln.tokens, tk)
+		end = tk.Line + strings.Count(tk.Val, "\n")
+		if isOpen(tk) {
+			depth++
+		} else if isClose(tk) {
+			depth--
+		}
+	}
+	return lines
+}
+
+// takesBlock reports block of statement is may be given at next line.
+func takesBlock(ln *line) bool {
+	last := ln.tokens[len(ln.tokens)-1]
+	if isOpen(last) || last.Type == fract.Comma || last.Type == fract.Operator || last.Type == fract.Comment {
+		return false
+	}
+	for _, tk := range ln.tokens {
+		switch tk.Type {
+		case fract.If, fract.Else, fract.Loop, fract.Func, fract.Try, fract.Catch, fract.Finally,
+			fract.Class, fract.Struct, fract.Match, fract.Select, fract.Case:
+			return true
+		}
+	}
+	return false
+}
+
+// join lines of block braces and else, catch and finally keywords to previous lines.
+func join(lines []*line) []*line {
+	var joined []*line
+	for _, ln := range lines {
+		if n := len(joined); n > 0 {
+			prev := joined[n-1]
+			first, last := ln.tokens[0], prev.tokens[len(prev.tokens)-1]
+			if first.Type == fract.Brace && first.Val == "{" && takesBlock(prev) ||
+				(first.Type == fract.Else || first.Type == fract.Catch || first.Type == fract.Finally) &&
+					last.Type == fract.Brace && last.Val == "}" {
+				prev.tokens = append(prev.tokens, ln.tokens...)
+				continue
+			}
+		}
+		joined = append(joined, ln)
+	}
+	return joined
+}
+
+// breakBlocks places content of multiline braces to own lines.
+func breakBlocks(lines []*line) []*line {
+	// Positions of braces that closed at other lines.
+	type pos struct{ line, index int }
+	multiline := map[pos]bool{}
+	var opens []pos
+	for i, ln := range lines {
+		for j, tk := range ln.tokens {
+			if tk.Type != fract.Brace {
+				continue
+			} else if tk.Val == "{" {
+				opens = append(opens, pos{i, j})
+			} else if tk.Val == "}" && len(opens) > 0 {
+				open := opens[len(opens)-1]
+				opens = opens[:len(opens)-1]
+				if open.line != i {
+					multiline[open], multiline[pos{i, j}] = true, true
+				}
+			}
+		}
+	}
+	var broken []*line
+	for i, ln := range lines {
+		cur := &line{blank: ln.blank}
+		broken = append(broken, cur)
+		for j, tk := range ln.tokens {
+			// Close brace is placed to new line.
+			if j > 0 && tk.Val == "}" && multiline[pos{i, j}] && len(cur.tokens) > 0 {
+				cur = &line{}
+				broken = append(broken, cur)
+			}
+			cur.tokens = append(cur.tokens, tk)
+			// Content after open brace is placed to new line.
+			if tk.Val == "{" && multiline[pos{i, j}] && j+1 < len(ln.tokens) && ln.tokens[j+1].Type != fract.Comment {
+				cur = &line{}
+				broken = append(broken, cur)
+			}
+		}
+	}
+	return broken
+}
+
+// indentLines sets indent counts of lines.
+// Lines are indented once for open braces of each previous line.
+func indentLines(lines []*line) {
+	var opens []int // Lines of open braces.
+	for i, ln := range lines {
+		j := 0
+		for ; j < len(ln.tokens) && isClose(ln.tokens[j]); j++ {
+			if len(opens) > 0 {
+				opens = opens[:len(opens)-1]
+			}
+		}
+		for k, open := range opens {
+			if k == 0 || opens[k-1] != open {
+				ln.depth++
+			}
+		}
+		for ; j < len(ln.tokens); j++ {
+			if tk := ln.tokens[j]; isOpen(tk) {
+				opens = append(opens, i)
+			} else if isClose(tk) && len(opens) > 0 {
+				opens = opens[:len(opens)-1]
+			}
+		}
+	}
+}
+
+// isVarSpec reports line is variable of group.
+func isVarSpec(ln *line) bool {
+	if len(ln.tokens) < 3 || ln.tokens[0].Type != fract.Name || ln.tokens[1].Val != "=" {
+		return false
+	}
+	balance := 0
+	for _, tk := range ln.tokens {
+		if isOpen(tk) {
+			balance++
+		} else if isClose(tk) {
+			balance--
+		}
+	}
+	return balance == 0
+}
+
+// align setters of consecutive variables of var groups.
+func align(lines []*line) {
+	for i := 0; i < len(lines); i++ {
+		ln := lines[i]
+		if len(ln.tokens) < 2 || ln.tokens[0].Type != fract.Var || ln.tokens[1].Val != "(" {
+			continue
+		}
+		var run []*line
+		flush := func() {
+			max := 0
+			for _, spec := range run {
+				if n := utf8.RuneCountInString(spec.tokens[0].Val); n > max {
+					max = n
+				}
+			}
+			for _, spec := range run {
+				spec.pad = max - utf8.RuneCountInString(spec.tokens[0].Val)
+			}
+			run = nil
+		}
+		for i++; i < len(lines) && lines[i].depth > ln.depth; i++ {
+			spec := lines[i]
+			if spec.blank {
+				flush()
+			}
+			if spec.tokens[0].Type == fract.Comment {
+				continue
+			}
+			if spec.depth == ln.depth+1 && isVarSpec(spec) {
+				run = append(run, spec)
+			} else {
+				flush()
+			}
+		}
+		flush()
+		i--
+	}
+}
+
+// literal reports open brace is map literal or like.
+// open is innermost open brace of brace.
+func literal(prev *obj.Token, open string) bool {
+	if prev == nil {
+		return open != "" && open != "{"
+	}
+	switch prev.Type {
+	case fract.Operator, fract.Comma, fract.Colon, fract.Return, fract.Yield, fract.In, fract.Case, fract.Struct:
+		return true
+	}
+	return isOpen(*prev)
+}
+
+// space reports space is required between tokens.
+// open is innermost open brace of current token, literal braces are "map"
+// and parentheses of variable groups are "var".
+func space(prev, cur obj.Token, open string, unary bool) bool {
+	switch {
+	case unary,
+		isOpen(prev) && prev.Val != "{",
+		isClose(cur) && cur.Val != "}",
+		cur.Type == fract.Comma, cur.Type == fract.StatementTerminator, cur.Type == fract.Colon,
+		cur.Type == fract.Dot, prev.Type == fract.Dot, prev.Type == fract.Macro, prev.Type == fract.Params:
+		return false
+	case prev.Val == "{" && prev.Type == fract.Brace:
+		return cur.Val != "}" && open != "map"
+	case cur.Val == "}" && cur.Type == fract.Brace:
+		return open != "map"
+	case cur.Val == "{" && cur.Type == fract.Brace:
+		return prev.Type != fract.Struct
+	case prev.Type == fract.Colon:
+		return open != "["
+	case cur.Type == fract.Params:
+		return !operand(prev)
+	case cur.Val == "(" && cur.Type == fract.Brace:
+		return !operand(prev) && prev.Type != fract.Func
+	case cur.Val == "[" && cur.Type == fract.Brace:
+		return !operand(prev)
+	case open == "(" && (cur.Val == "=" && cur.Type == fract.Operator || prev.Val == "=" && prev.Type == fract.Operator):
+		// Default values of parameters and keyword arguments.
+		return false
+	}
+	return true
+}
+
+// alignColumns aligns offsets of consecutive lines.
+// Offsets of other alignments are shifted too.
+func alignColumns(lines []*line, offset func(*line) *int) {
+	for i := 0; i < len(lines); {
+		j := i + 1
+		if *offset(lines[i]) != -1 {
+			for j < len(lines) && *offset(lines[j]) != -1 && !lines[j].blank && lines[j].depth == lines[i].depth {
+				j++
+			}
+		}
+		max := 0
+		for _, ln := range lines[i:j] {
+			if off := *offset(ln); off != -1 && utf8.RuneCountInString(ln.text[:off]) > max {
+				max = utf8.RuneCountInString(ln.text[:off])
+			}
+		}
+		for _, ln := range lines[i:j] {
+			off := *offset(ln)
+			if off == -1 {
+				continue
+			}
+			pad := max - utf8.RuneCountInString(ln.text[:off])
+			ln.text = ln.text[:off] + strings.Repeat(" ", pad) + ln.text[off:]
+			if ln.comment > off {
+				ln.comment += pad
+			}
+			if ln.brace > off {
+				ln.brace += pad
+			}
+		}
+		i = j
+	}
+}
+
+// Printer of lines.
+type printer struct {
+	opens []string // Open braces of previous tokens.
+}
+
+// print sets text of line.
+func (p *printer) print(ln *line) {
+	var sb strings.Builder
+	ln.comment, ln.brace = -1, -1
+	unary := false
+	var last *obj.Token // Previous token that is not comment.
+	for i, tk := range ln.tokens {
+		open := ""
+		if len(p.opens) > 0 {
+			open = p.opens[len(p.opens)-1]
+		}
+		var prev *obj.Token
+		if i > 0 {
+			prev = &ln.tokens[i-1]
+			switch {
+			case tk.Type == fract.Comment && i == len(ln.tokens)-1 && !strings.Contains(tk.Val, "\n"):
+				ln.comment = sb.Len()
+			case ln.tokens[0].Type == fract.Case && tk.Val == "{" && ln.brace == -1 && singleLine(ln.tokens[i:]):
+				ln.brace = sb.Len()
+			}
+			// Keywords of variables are functions if not declaration.
+			call := i > 1 && prev.Type == fract.Var && tk.Val == "("
+			if !call && space(*prev, tk, open, unary) {
+				sb.WriteByte(' ')
+			}
+			if i == 1 {
+				sb.WriteString(strings.Repeat(" ", ln.pad))
+			}
+		}
+		sb.WriteString(tk.Val)
+		// Unary operator and pin of pattern are not spaced with operand.
+		// Operators after inline comments are unary by token before comment.
+		unary = tk.Type == fract.Operator && (tk.Val == "-" || tk.Val == "+" || tk.Val == "<-" || tk.Val == "^") &&
+			(last == nil || !operand(*last))
+		if tk.Type != fract.Comment {
+			last = &ln.tokens[i]
+		}
+		switch {
+		case tk.Val == "{" && tk.Type == fract.Brace && literal(prev, open):
+			p.opens = append(p.opens, "map")
+		case tk.Val == "(" && tk.Type == fract.Brace && i == 1 && prev.Type == fract.Var:
+			p.opens = append(p.opens, "var")
+		case isOpen(tk):
+			p.opens = append(p.opens, tk.Val)
+		case isClose(tk) && len(p.opens) > 0:
+			p.opens = p.opens[:len(p.opens)-1]
+		}
+	}
+	ln.text = sb.String()
+}
+
+// singleLine reports block of tokens is closed at end of line.
+func singleLine(tokens []obj.Token) bool {
+	if last := tokens[len(tokens)-1]; last.Type == fract.Comment {
+		tokens = tokens[:len(tokens)-1]
+	}
+	count := 0
+	for i, tk := range tokens {
+		if isOpen(tk) {
+			count++
+		} else if isClose(tk) {
+			count--
+		}
+		if count == 0 {
+			return i == len(tokens)-1
+		}
+	}
+	return false
+}
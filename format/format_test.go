@@ -0,0 +1,53 @@
+package format
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestFormat formats input files of testdata, outputs are must be same with golden files.
+func TestFormat(t *testing.T) {
+	inputs, _ := filepath.Glob("testdata/*.input")
+	if len(inputs) == 0 {
+		t.Fatal("no input files in testdata")
+	}
+	for _, input := range inputs {
+		src, err := os.ReadFile(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := os.ReadFile(strings.TrimSuffix(input, ".input") + ".golden")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := Source(input, src)
+		if err != nil {
+			t.Fatalf("%s: %v", input, err)
+		}
+		if string(got) != string(want) {
+			t.Errorf("%s:\n%s", input, Diff(input, want, got))
+		}
+	}
+}
+
+// TestIdempotent formats samples and formatted codes are not changed by formatting again.
+func TestIdempotent(t *testing.T) {
+	paths, _ := filepath.Glob("../samples/*.fract")
+	golden, _ := filepath.Glob("testdata/*.golden")
+	for _, path := range append(append(paths, golden...), "../test.fract") {
+		code, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		once, err := Source(path, code)
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		twice, _ := Source(path, once)
+		if string(once) != string(twice) {
+			t.Errorf("%s: formatting is not idempotent:\n%s", path, Diff(path, once, twice))
+		}
+	}
+}
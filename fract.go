@@ -0,0 +1,314 @@
+// Package fract is Go embedding API of Fract.
+//
+//	interp := fract.New(fract.Options{StdLib: "/usr/lib/fract/stdlib"})
+//	if err := interp.RunFile("main.fract"); err != nil {
+//		log.Fatal(err)
+//	}
+package fract
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/fract-lang/fract/bytecode"
+	"github.com/fract-lang/fract/oop"
+	"github.com/fract-lang/fract/parser"
+	"github.com/fract-lang/fract/pkg/diag"
+	"github.com/fract-lang/fract/pkg/fract"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// ExitError is returned if program is exited by exit function.
+type ExitError = obj.ExitError
+
+// Panic is returned if program is panicked.
+// Type is type of panic like "ValuePanic", position is
+// position of panic in code and Trace is stack trace of calls.
+type Panic = obj.Panic
+
+// Options of interpreter.
+type Options struct {
+	StdLib      string    // Path of standard library, defaults to "stdlib".
+	Stdout      io.Writer // Defaults to os.Stdout.
+	Stderr      io.Writer // Defaults to os.Stderr.
+	Stdin       io.Reader // Defaults to os.Stdin.
+	Interactive bool      // Print values of expression statements like interactive shell.
+}
+
+// Param is parameter of host function.
+type Param struct {
+	Name     string
+	Default  interface{} // Default value, parameter is required if nil.
+	Variadic bool        // Params parameter, takes rest of arguments as list.
+}
+
+// Func is function of host.
+//
+// Fn is any Go function. Arguments are converted to types of Go parameters
+// and results are converted to Fract values. A non-nil error as last result
+// is thrown as panic. Results except error are returned as multiple values.
+// Fn is called as is if it is built-in function (func(obj.Token, []oop.VarDef) oop.Val).
+type Func struct {
+	Name   string
+	Fn     interface{}
+	Params []Param // Parameters are named arg1, arg2... if nil.
+}
+
+// Package of host that importable with open statement.
+// Names of functions and constants are must be public.
+type Package struct {
+	Name   string // Dotted names are allowed like standard library packages.
+	Funcs  []Func
+	Consts map[string]interface{}
+}
+
+// Interpreter of Fract.
+// Interpreters are not share any state,
+// so several interpreters can run side by side.
+type Interpreter struct {
+	rt      *parser.Runtime
+	session *parser.Parser // Session of RunString.
+	main    *parser.Parser // Parser of last run program.
+}
+
+// New returns new interpreter by options.
+func New(opts Options) *Interpreter {
+	rt := parser.NewRuntime(opts.StdLib)
+	if rt.StdLib == "" {
+		rt.StdLib = fract.StdLib
+	}
+	if opts.Stdout != nil {
+		rt.Stdout = opts.Stdout
+	}
+	if opts.Stderr != nil {
+		rt.Stderr = opts.Stderr
+	}
+	if opts.Stdin != nil {
+		if r, ok := opts.Stdin.(*bufio.Reader); ok {
+			rt.Stdin = r
+		} else {
+			rt.Stdin = bufio.NewReader(opts.Stdin)
+		}
+	}
+	rt.Interactive = opts.Interactive
+	return &Interpreter{rt: rt}
+}
+
+// defs returns defines of functions and constants.
+func defs(funcs []Func, consts map[string]interface{}) (oop.DefMap, error) {
+	var defs oop.DefMap
+	for _, f := range funcs {
+		var params []oop.Param
+		for _, param := range f.Params {
+			fnParam := oop.Param{Name: param.Name, Params: param.Variadic}
+			if param.Default != nil {
+				val, err := oop.ValOf(param.Default)
+				if err != nil {
+					return defs, fmt.Errorf("%s: default value of %s: %w", f.Name, param.Name, err)
+				}
+				fnParam.DefaultVal = val
+			}
+			params = append(params, fnParam)
+		}
+		fn, err := oop.NativeFn(f.Name, f.Fn, params)
+		if err != nil {
+			return defs, err
+		}
+		defs.Funcs = append(defs.Funcs, fn)
+	}
+	// Sort names for deterministic errors.
+	names := make([]string, 0, len(consts))
+	for name := range consts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		val, err := oop.ValOf(consts[name])
+		if err != nil {
+			return defs, fmt.Errorf("%s: %w", name, err)
+		}
+		val.Const = true
+		defs.Vars = append(defs.Vars, &oop.Var{Name: name, Val: val})
+	}
+	return defs, nil
+}
+
+// Register adds functions of host to built-in functions.
+// Functions are callable from programs that run after registration.
+func (i *Interpreter) Register(funcs ...Func) error {
+	defs, err := defs(funcs, nil)
+	if err != nil {
+		return err
+	}
+	return i.rt.Define(defs)
+}
+
+// Const adds constant of host to built-in defines.
+// Value is converted to Fract value like arguments of functions.
+func (i *Interpreter) Const(name string, val interface{}) error {
+	defs, err := defs(nil, map[string]interface{}{name: val})
+	if err != nil {
+		return err
+	}
+	return i.rt.Define(defs)
+}
+
+// RegisterPackage adds package of host.
+func (i *Interpreter) RegisterPackage(pkg Package) error {
+	defs, err := defs(pkg.Funcs, pkg.Consts)
+	if err != nil {
+		return err
+	}
+	return i.rt.DefinePackage(pkg.Name, defs)
+}
+
+// load returns parser of source file or bytecode file.
+func (i *Interpreter) load(path string) (*parser.Parser, error) {
+	if !strings.HasSuffix(path, fract.BytecodeExtension) {
+		if _, err := os.Stat(path); err != nil {
+			return nil, err
+		}
+		return parser.New(i.rt, path), nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	prog, err := bytecode.Decode(f)
+	if err != nil {
+		return nil, err
+	}
+	return parser.NewBytecode(i.rt, prog), nil
+}
+
+// RunFile interprets source file or bytecode file.
+// Returns error if file is not loaded or program is panicked.
+func (i *Interpreter) RunFile(path string) error {
+	p, err := i.load(path)
+	if err != nil {
+		return err
+	}
+	p.AddBuiltInFuncs()
+	i.main = p
+	return p.Run()
+}
+
+// RunString interprets code in session of interpreter.
+// Code is not require package clause and
+// definitions of previous codes are kept.
+func (i *Interpreter) RunString(code string) error {
+	if i.session == nil {
+		i.session = parser.NewString(i.rt)
+		i.session.AddBuiltInFuncs()
+	}
+	i.main = i.session
+	return i.session.Eval(code)
+}
+
+// Lookup returns top-level define of last run program by name.
+// Returns *oop.Fn for functions and Go value of value for variables.
+func (i *Interpreter) Lookup(name string) (interface{}, error) {
+	if i.main != nil && name != "" {
+		if fn := i.main.Func(name); fn != nil {
+			return fn, nil
+		}
+		if v := i.main.Var(name); v != nil {
+			return v.Val.Interface(), nil
+		}
+	}
+	return nil, fmt.Errorf("name is not defined: %s", name)
+}
+
+// Var stores value of top-level variable of last run program
+// to value that pointed by ptr.
+func (i *Interpreter) Var(name string, ptr interface{}) error {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("value is not a non-nil pointer: %T", ptr)
+	}
+	var v oop.VarDef
+	if i.main != nil && name != "" {
+		v = i.main.Var(name)
+	}
+	if v == nil {
+		return fmt.Errorf("name is not defined: %s", name)
+	}
+	val, err := v.Val.GoVal(rv.Type().Elem())
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	rv.Elem().Set(val)
+	return nil
+}
+
+// Call calls top-level function of last run program by name.
+func (i *Interpreter) Call(name string, args ...interface{}) ([]interface{}, error) {
+	var fn *oop.Fn
+	if i.main != nil && name != "" {
+		fn = i.main.Func(name)
+	}
+	if fn == nil {
+		return nil, fmt.Errorf("function is not defined: %s", name)
+	}
+	return i.CallFn(fn, args...)
+}
+
+// CallFn calls function with Go arguments and returns Go values of results.
+// Results are empty if function is not returns value and
+// have an element for each value of multiple returns.
+// Returns Panic if function is panicked.
+func (i *Interpreter) CallFn(fn *oop.Fn, args ...interface{}) ([]interface{}, error) {
+	vals := make([]oop.Val, len(args))
+	for j, arg := range args {
+		val, err := oop.ValOf(arg)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %w", j+1, err)
+		}
+		vals[j] = val
+	}
+	p := i.main
+	if p == nil {
+		p = parser.NewString(i.rt)
+	}
+	ret, err := p.CallFunc(fn, vals)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case ret.Data == nil:
+		return []interface{}{}, nil
+	case ret.Tag == "function_multiple_returns":
+		return ret.Interface().([]interface{}), nil
+	}
+	return []interface{}{ret.Interface()}, nil
+}
+
+// Diagnostic is error or warning of code.
+type Diagnostic = diag.Diagnostic
+
+// Check returns all diagnostics of source file without interpret.
+func (i *Interpreter) Check(path string) ([]Diagnostic, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	return parser.New(i.rt, path).Check(), nil
+}
+
+// Compile source file to bytecode program.
+func (i *Interpreter) Compile(path string) (prog *bytecode.Program, err error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = parser.Error(r)
+		}
+	}()
+	return parser.New(i.rt, path).Compile(), nil
+}
@@ -0,0 +1,68 @@
+package fract_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fract-lang/fract"
+)
+
+func TestInterpreters(t *testing.T) {
+	var out1, out2 bytes.Buffer
+	i1 := fract.New(fract.Options{StdLib: "stdlib", Stdout: &out1})
+	i2 := fract.New(fract.Options{StdLib: "stdlib", Stdout: &out2})
+	// Defines of sessions are kept between runs and not shared by interpreters.
+	for _, run := range []struct {
+		interp *fract.Interpreter
+		code   string
+	}{
+		{i1, "x := 1"},
+		{i2, "x := 'two'"},
+		{i1, "println(x + 1)"},
+		{i2, "println(x + '!')"},
+	} {
+		if err := run.interp.RunString(run.code); err != nil {
+			t.Fatalf("%s: %v", run.code, err)
+		}
+	}
+	if out1.String() != "2\n" || out2.String() != "two!\n" {
+		t.Errorf("got %q and %q, want %q and %q", out1.String(), out2.String(), "2\n", "two!\n")
+	}
+}
+
+func TestErrors(t *testing.T) {
+	interp := fract.New(fract.Options{StdLib: "stdlib", Stdout: new(bytes.Buffer)})
+	err := interp.RunString("x := 1\nprintln(x / 0)")
+	var cp fract.Panic
+	if !errors.As(err, &cp) {
+		t.Fatalf("got %v, want panic", err)
+	}
+	if cp.Type != "DivideByZeroPanic" || cp.Line != 2 || cp.Column != 11 {
+		t.Errorf("got %s at %d:%d, want DivideByZeroPanic at 2:11", cp.Type, cp.Line, cp.Column)
+	}
+	// Exit is returned as error instead of exit of process.
+	var exit fract.ExitError
+	if err := interp.RunString("exit(3)"); !errors.As(err, &exit) || exit.Code != 3 {
+		t.Errorf("got %v, want exit status 3", err)
+	}
+	if err := interp.RunFile("not_exists.fract"); err == nil {
+		t.Error("got nil error of not existing file")
+	}
+}
+
+func TestStdin(t *testing.T) {
+	var stdout bytes.Buffer
+	interp := fract.New(fract.Options{
+		StdLib: "stdlib",
+		Stdout: &stdout,
+		Stdin:  strings.NewReader("fract\r\n"),
+	})
+	if err := interp.RunString("println('hello ' + input('name: '))"); err != nil {
+		t.Fatal(err)
+	}
+	if want := "name: hello fract\n"; stdout.String() != want {
+		t.Errorf("got %q, want %q", stdout.String(), want)
+	}
+}
@@ -0,0 +1,60 @@
+package functions
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/fract-lang/fract/oop"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// Env is input and output environment of built-in functions.
+type Env struct {
+	Stdout io.Writer
+	Stdin  *bufio.Reader
+	mu     *sync.Mutex // Guards outputs of goroutines.
+}
+
+// NewEnv returns environment by input and output.
+func NewEnv(stdout io.Writer, stdin *bufio.Reader) Env {
+	return Env{Stdout: stdout, Stdin: stdin, mu: new(sync.Mutex)}
+}
+
+// Output writes string to writer.
+// Outputs of concurrent goroutines are not mixed.
+func (e *Env) Output(w io.Writer, s string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	io.WriteString(w, s)
+}
+
+// Input returns input from command-line.
+func (e *Env) Input(tk obj.Token, args []oop.VarDef) oop.Val {
+	e.Output(e.Stdout, args[0].Val.String())
+	ln, _ := e.Stdin.ReadString('\n')
+	return oop.Val{Data: strings.TrimRight(ln, "\r\n"), Type: oop.String}
+}
+
+// text returns text of print values.
+func text(args []oop.VarDef) string {
+	var sb strings.Builder
+	for _, d := range args[0].Val.Data.(*oop.ListModel).Elems {
+		fmt.Fprint(&sb, d)
+	}
+	return sb.String()
+}
+
+// Print values to cli.
+func (e *Env) Print(tk obj.Token, args []oop.VarDef) oop.Val {
+	e.Output(e.Stdout, text(args))
+	return oop.Val{}
+}
+
+// Println print values to cli with new line.
+func (e *Env) Println(tk obj.Token, args []oop.VarDef) oop.Val {
+	e.Output(e.Stdout, text(args)+"\n")
+	return oop.Val{}
+}
@@ -3,13 +3,14 @@ package functions
 // Built-In functions.
 
 import (
-	"bufio"
 	"fmt"
-	"os"
+	"math"
+	"math/big"
 	"strconv"
 	"strings"
 
 	"github.com/fract-lang/fract/oop"
+	"github.com/fract-lang/fract/pkg/decimal"
 	"github.com/fract-lang/fract/pkg/fract"
 	"github.com/fract-lang/fract/pkg/obj"
 	"github.com/fract-lang/fract/pkg/str"
@@ -21,8 +22,7 @@ func Exit(tk obj.Token, args []oop.VarDef) oop.Val {
 	if code.Type != oop.Int {
 		fract.Panic(tk, obj.ValuePanic, "Exit code is only be integer!")
 	}
-	os.Exit(int(code.Data.(float64)))
-	return oop.Val{}
+	panic(obj.ExitError{Code: int(code.Data.(int64))})
 }
 
 // Float convert object to float.
@@ -33,35 +33,95 @@ func Float(tk obj.Token, args []oop.VarDef) oop.Val {
 	}
 }
 
-// Input returns input from command-line.
-func Input(tk obj.Token, args []oop.VarDef) oop.Val {
-	args[0].Val.Print()
-	//! Don't use fmt.Scanln
-	s := bufio.NewScanner(os.Stdin)
-	s.Scan()
-	return oop.Val{Data: s.Text(), Type: oop.String}
-}
-
 // Int convert object to integer.
 func Int(tk obj.Token, args []oop.VarDef) oop.Val {
 	switch args[1].Val.Data { // Cast type.
 	case "strcode":
 		codes := oop.NewListModel()
 		for _, byt := range []byte(args[0].Val.String()) {
-			codes.PushBack(oop.Val{Data: float64(byt), Type: oop.Int})
+			codes.PushBack(oop.Val{Data: int64(byt), Type: oop.Int})
 		}
 		return oop.Val{Data: codes, Type: oop.List}
 	default: // Object.
-		return oop.Val{
-			Data: str.Conv(args[0].Val.String()),
-			Type: oop.Int,
+		val := args[0].Val
+		switch val.Type {
+		case oop.Int:
+			return oop.Val{Data: val.Data, Type: oop.Int}
+		case oop.BigInt, oop.Decimal:
+			i := val.BigInt()
+			if !i.IsInt64() {
+				fract.Panic(tk, obj.ArithmeticPanic, "Value is out of integer range!")
+			}
+			return oop.Val{Data: i.Int64(), Type: oop.Int}
+		case oop.String:
+			if i, err := strconv.ParseInt(val.String(), 10, 64); err == nil {
+				return oop.Val{Data: i, Type: oop.Int}
+			}
+		}
+		f := str.Conv(val.String())
+		if f != f || f < math.MinInt64 || f >= math.MaxInt64 {
+			fract.Panic(tk, obj.ArithmeticPanic, "Value is out of integer range!")
+		}
+		return oop.Val{Data: int64(f), Type: oop.Int}
+	}
+}
+
+// BigInt convert object to big integer.
+func BigInt(tk obj.Token, args []oop.VarDef) oop.Val {
+	val := args[0].Val
+	switch val.Type {
+	case oop.Int, oop.BigInt, oop.Decimal:
+		return oop.Val{Data: new(big.Int).Set(val.BigInt()), Type: oop.BigInt}
+	case oop.Bool:
+		return oop.Val{Data: big.NewInt(int64(val.Float64())), Type: oop.BigInt}
+	case oop.Float:
+		f := val.Data.(float64)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			fract.Panic(tk, obj.ArithmeticPanic, "NaN and infinity is cannot convert to bigint!")
+		}
+		i, _ := big.NewFloat(f).Int(nil)
+		return oop.Val{Data: i, Type: oop.BigInt}
+	case oop.String:
+		if i, ok := new(big.Int).SetString(val.String(), 10); ok {
+			return oop.Val{Data: i, Type: oop.BigInt}
+		} else if d, ok := decimal.Parse(val.String()); ok {
+			return oop.Val{Data: d.Int(), Type: oop.BigInt}
 		}
 	}
+	fract.Panic(tk, obj.ValuePanic, "Value is cannot convert to bigint!")
+	return oop.Val{}
+}
+
+// Decimal convert object to decimal.
+func Decimal(tk obj.Token, args []oop.VarDef) oop.Val {
+	val := args[0].Val
+	switch val.Type {
+	case oop.Int, oop.BigInt:
+		return oop.Val{Data: decimal.New(val.BigInt()), Type: oop.Decimal}
+	case oop.Decimal:
+		return oop.Val{Data: val.Data, Type: oop.Decimal}
+	case oop.Bool:
+		return oop.Val{Data: decimal.New(big.NewInt(int64(val.Float64()))), Type: oop.Decimal}
+	case oop.Float:
+		f := val.Data.(float64)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			fract.Panic(tk, obj.ArithmeticPanic, "NaN and infinity is cannot convert to decimal!")
+		}
+		// Shortest representation, so 0.1 is converted to 0.1 exactly.
+		d, _ := decimal.Parse(strconv.FormatFloat(f, 'g', -1, 64))
+		return oop.Val{Data: d, Type: oop.Decimal}
+	case oop.String:
+		if d, ok := decimal.Parse(val.String()); ok {
+			return oop.Val{Data: d, Type: oop.Decimal}
+		}
+	}
+	fract.Panic(tk, obj.ValuePanic, "Value is cannot convert to decimal!")
+	return oop.Val{}
 }
 
 // Len returns length of object.
 func Len(tk obj.Token, args []oop.VarDef) oop.Val {
-	return oop.Val{Data: float64(args[0].Val.Len()), Type: oop.Int}
+	return oop.Val{Data: int64(args[0].Val.Len()), Type: oop.Int}
 }
 
 // Calloc list by size.
@@ -70,16 +130,16 @@ func Calloc(tk obj.Token, args []oop.VarDef) oop.Val {
 	if size.Type != oop.Int {
 		fract.Panic(tk, obj.ValuePanic, "Size is only be integer!")
 	}
-	sizeInt := size.Data.(float64)
+	sizeInt := size.Data.(int64)
 	if sizeInt < 0 {
 		fract.Panic(tk, obj.ValuePanic, "Size should be minimum zero!")
 	}
 	value := oop.Val{Type: oop.List}
 	if sizeInt > 0 {
-		var index float64
+		var index int64
 		list := oop.NewListModel()
 		for ; index < sizeInt; index++ {
-			list.PushBack(oop.Val{Data: "0", Type: oop.Int})
+			list.PushBack(oop.Val{Data: int64(0), Type: oop.Int})
 		}
 		value.Data = list
 	} else {
@@ -111,27 +171,12 @@ func Realloc(tk obj.Token, args []oop.VarDef) oop.Val {
 		return val
 	}
 	for ; length <= size; length++ {
-		list.PushBack(oop.Val{Data: "0", Type: oop.Int})
+		list.PushBack(oop.Val{Data: int64(0), Type: oop.Int})
 	}
 	val.Data = list
 	return val
 }
 
-// Print values to cli.
-func Print(tk obj.Token, args []oop.VarDef) oop.Val {
-	for _, d := range args[0].Val.Data.(*oop.ListModel).Elems {
-		fmt.Print(d)
-	}
-	return oop.Val{}
-}
-
-// Print values to cli with new line.
-func Println(tk obj.Token, args []oop.VarDef) oop.Val {
-	Print(tk, args)
-	println()
-	return oop.Val{}
-}
-
 // Range returns list by parameters.
 func Range(tk obj.Token, args []oop.VarDef) oop.Val {
 	start := args[0].Val
@@ -144,24 +189,45 @@ func Range(tk obj.Token, args []oop.VarDef) oop.Val {
 	} else if step.Type != oop.Int && step.Type != oop.Float {
 		fract.Panic(tk, obj.ValuePanic, `"step" argument should be numeric!`)
 	}
-	startFloat := start.Data.(float64)
-	toFloat := to.Data.(float64)
-	stepFloat := step.Data.(float64)
-	if stepFloat <= 0 {
-		return oop.Val{Type: oop.List}
+	list := oop.NewListModel()
+	if start.Type == oop.Int && to.Type == oop.Int && step.Type == oop.Int {
+		startInt := start.Data.(int64)
+		toInt := to.Data.(int64)
+		stepInt := step.Data.(int64)
+		if stepInt <= 0 {
+			fract.Panic(tk, obj.ValuePanic, `"step" argument should be greater than zero!`)
+		}
+		// Stop before overflow of next element.
+		if startInt <= toInt {
+			for ; startInt <= toInt; startInt += stepInt {
+				list.PushBack(oop.Val{Data: startInt, Type: oop.Int})
+				if startInt > math.MaxInt64-stepInt {
+					break
+				}
+			}
+		} else {
+			for ; startInt >= toInt; startInt -= stepInt {
+				list.PushBack(oop.Val{Data: startInt, Type: oop.Int})
+				if startInt < math.MinInt64+stepInt {
+					break
+				}
+			}
+		}
+		return oop.Val{Data: list, Type: oop.List}
 	}
-	typ := oop.Int
-	if start.Type == oop.Float || to.Type == oop.Float || step.Type == oop.Float {
-		typ = oop.Float
+	startFloat := start.Float64()
+	toFloat := to.Float64()
+	stepFloat := step.Float64()
+	if !(stepFloat > 0) { // NaN is not greater than zero.
+		fract.Panic(tk, obj.ValuePanic, `"step" argument should be greater than zero!`)
 	}
-	list := oop.NewListModel()
 	if startFloat <= toFloat {
 		for ; startFloat <= toFloat; startFloat += stepFloat {
-			list.PushBack(oop.Val{Data: startFloat, Type: typ})
+			list.PushBack(oop.Val{Data: startFloat, Type: oop.Float})
 		}
 	} else {
 		for ; startFloat >= toFloat; startFloat -= stepFloat {
-			list.PushBack(oop.Val{Data: startFloat, Type: typ})
+			list.PushBack(oop.Val{Data: startFloat, Type: oop.Float})
 		}
 	}
 	return oop.Val{Data: list, Type: oop.List}
@@ -195,7 +261,7 @@ func String(tk obj.Token, args []oop.VarDef) oop.Val {
 			if element.Type != oop.Int {
 				sb.WriteByte(' ')
 			}
-			sb.WriteByte(byte(element.Data.(float64)))
+			sb.WriteByte(byte(element.Float64()))
 		}
 		return oop.Val{Data: sb.String(), Type: oop.String}
 	default: // Object.
@@ -204,15 +270,48 @@ func String(tk obj.Token, args []oop.VarDef) oop.Val {
 	}
 }
 
+// Panic raises panic with message and type.
+// Caught errors are raised again with original position and stack trace.
 func Panic(tk obj.Token, args []oop.VarDef) oop.Val {
-	p := obj.Panic{Msg: args[0].Val.String()}
-	if fract.TryCount > 0 {
-		panic(p)
+	if ins, ok := args[0].Val.Data.(oop.StructInstance); ok && ins.Panic != nil {
+		panic(*ins.Panic)
+	}
+	typ := args[1].Val
+	if typ.Type != oop.String || typ.Data == "" {
+		fract.Panic(tk, obj.ValuePanic, "Panic type is must be non-empty string!")
 	}
-	fmt.Println("panic: " + p.Msg)
-	panic("")
+	msg := args[0].Val.String()
+	cp := obj.Panic{Msg: msg, Text: msg, Type: typ.String(), Line: tk.Line, Column: tk.Column}
+	if cp.Type != obj.PlainPanic {
+		cp.Msg = cp.Type + ": " + msg
+	}
+	if tk.File != nil {
+		cp.File = tk.File.Path
+	}
+	panic(cp)
 }
 
 func Type(tk obj.Token, args []oop.VarDef) oop.Val {
-	return oop.Val{Data: float64(args[0].Val.Type), Type: oop.Int}
+	return oop.Val{Data: int64(args[0].Val.Type), Type: oop.Int}
+}
+
+// Chan returns new channel by buffer size.
+func Chan(tk obj.Token, args []oop.VarDef) oop.Val {
+	size := args[0].Val
+	if size.Type != oop.Int {
+		fract.Panic(tk, obj.ValuePanic, "Buffer size is must be integer!")
+	} else if size.Data.(int64) < 0 {
+		fract.Panic(tk, obj.ValuePanic, "Buffer size is cannot be negative!")
+	}
+	return oop.Val{Data: oop.NewChannel(int(size.Data.(int64))), Type: oop.Chan}
+}
+
+// Close channel.
+func Close(tk obj.Token, args []oop.VarDef) oop.Val {
+	ch := args[0].Val
+	if ch.Type != oop.Chan {
+		fract.Panic(tk, obj.ValuePanic, "Value is not channel!")
+	}
+	ch.Data.(*oop.Channel).Close(tk)
+	return oop.Val{}
 }
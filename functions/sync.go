@@ -0,0 +1,144 @@
+package functions
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/fract-lang/fract/oop"
+	"github.com/fract-lang/fract/pkg/fract"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// Sync returns defines of sync package.
+func Sync() oop.DefMap {
+	return oop.DefMap{Funcs: []*oop.Fn{
+		{Name: "WaitGroup", Src: WaitGroup},
+		{Name: "Mutex", Src: Mutex},
+	}}
+}
+
+// Atomic returns defines of atomic package.
+func Atomic() oop.DefMap {
+	return oop.DefMap{Funcs: []*oop.Fn{{
+		Name:              "Int",
+		Src:               AtomicInt,
+		DefaultParamCount: 1,
+		Params: []oop.Param{{
+			Name:       "value",
+			DefaultVal: oop.Val{Data: int64(0), Type: oop.Int},
+		}},
+	}}}
+}
+
+// method returns function value of builtin.
+func method(name string, src oop.Builtin, params ...oop.Param) *oop.Var {
+	fn := &oop.Fn{Name: name, Src: src, Params: params}
+	for _, param := range params {
+		if param.DefaultVal.Data != nil {
+			fn.DefaultParamCount++
+		}
+	}
+	return &oop.Var{Name: name, Val: oop.Val{Data: fn, Type: oop.Func, Const: true}}
+}
+
+// WaitGroup returns new wait group for waiting goroutines.
+func WaitGroup(tk obj.Token, args []oop.VarDef) oop.Val {
+	wg := new(sync.WaitGroup)
+	ins := oop.StructInstance{Name: "WaitGroup"}
+	ins.Fields.Vars = []oop.VarDef{
+		method("Add", func(tk obj.Token, args []oop.VarDef) oop.Val {
+			delta := args[0].Val
+			if delta.Type != oop.Int {
+				fract.Panic(tk, obj.ValuePanic, "Delta is must be integer!")
+			}
+			defer func() {
+				if recover() != nil {
+					fract.Panic(tk, obj.ValuePanic, "Wait group counter is cannot be negative!")
+				}
+			}()
+			wg.Add(int(delta.Data.(int64)))
+			return oop.Val{}
+		}, oop.Param{Name: "delta", DefaultVal: oop.Val{Data: int64(1), Type: oop.Int}}),
+		method("Done", func(tk obj.Token, args []oop.VarDef) oop.Val {
+			defer func() {
+				if recover() != nil {
+					fract.Panic(tk, obj.ValuePanic, "Wait group counter is cannot be negative!")
+				}
+			}()
+			wg.Done()
+			return oop.Val{}
+		}),
+		method("Wait", func(tk obj.Token, args []oop.VarDef) oop.Val {
+			wg.Wait()
+			return oop.Val{}
+		}),
+	}
+	return oop.Val{Data: ins, Type: oop.StructIns}
+}
+
+// Mutex returns new mutual exclusion lock.
+func Mutex(tk obj.Token, args []oop.VarDef) oop.Val {
+	// Channel is used instead of sync.Mutex because unlock of
+	// unlocked sync.Mutex is not recoverable.
+	lock := make(chan struct{}, 1)
+	ins := oop.StructInstance{Name: "Mutex"}
+	ins.Fields.Vars = []oop.VarDef{
+		method("Lock", func(tk obj.Token, args []oop.VarDef) oop.Val {
+			lock <- struct{}{}
+			return oop.Val{}
+		}),
+		method("TryLock", func(tk obj.Token, args []oop.VarDef) oop.Val {
+			select {
+			case lock <- struct{}{}:
+				return oop.Val{Data: true, Type: oop.Bool}
+			default:
+				return oop.Val{Data: false, Type: oop.Bool}
+			}
+		}),
+		method("Unlock", func(tk obj.Token, args []oop.VarDef) oop.Val {
+			select {
+			case <-lock:
+			default:
+				fract.Panic(tk, obj.ValuePanic, "Mutex is not locked!")
+			}
+			return oop.Val{}
+		}),
+	}
+	return oop.Val{Data: ins, Type: oop.StructIns}
+}
+
+// intArg returns integer value of argument.
+func intArg(tk obj.Token, arg oop.VarDef) int64 {
+	if arg.Val.Type != oop.Int {
+		fract.Panic(tk, obj.ValuePanic, "Value is must be integer!")
+	}
+	return arg.Val.Data.(int64)
+}
+
+// AtomicInt returns new integer that accessed atomically.
+func AtomicInt(tk obj.Token, args []oop.VarDef) oop.Val {
+	n := new(int64)
+	*n = intArg(tk, args[0])
+	result := func(i int64) oop.Val { return oop.Val{Data: i, Type: oop.Int} }
+	ins := oop.StructInstance{Name: "Int"}
+	ins.Fields.Vars = []oop.VarDef{
+		method("Add", func(tk obj.Token, args []oop.VarDef) oop.Val {
+			return result(atomic.AddInt64(n, intArg(tk, args[0])))
+		}, oop.Param{Name: "delta", DefaultVal: oop.Val{Data: int64(1), Type: oop.Int}}),
+		method("Load", func(tk obj.Token, args []oop.VarDef) oop.Val {
+			return result(atomic.LoadInt64(n))
+		}),
+		method("Store", func(tk obj.Token, args []oop.VarDef) oop.Val {
+			atomic.StoreInt64(n, intArg(tk, args[0]))
+			return oop.Val{}
+		}, oop.Param{Name: "value"}),
+		method("Swap", func(tk obj.Token, args []oop.VarDef) oop.Val {
+			return result(atomic.SwapInt64(n, intArg(tk, args[0])))
+		}, oop.Param{Name: "value"}),
+		method("CompareAndSwap", func(tk obj.Token, args []oop.VarDef) oop.Val {
+			swapped := atomic.CompareAndSwapInt64(n, intArg(tk, args[0]), intArg(tk, args[1]))
+			return oop.Val{Data: swapped, Type: oop.Bool}
+		}, oop.Param{Name: "old"}, oop.Param{Name: "new"}),
+	}
+	return oop.Val{Data: ins, Type: oop.StructIns}
+}
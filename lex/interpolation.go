@@ -0,0 +1,121 @@
+package lex
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/fract-lang/fract/pkg/fract"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// Format specifier of interpolation; flags, width, precision and verb.
+var formatRgx = regexp.MustCompile(`^[-+0]*\d*(\.\d+)?[sdxXobfeEgG]?$`)
+
+// Interpolation is part of interpolated string literal.
+type Interpolation struct {
+	Text   string      // Text before expression.
+	Tokens []obj.Token // Tokens of expression.
+	Format string      // Format specifier of expression, empty if not given.
+}
+
+// Interpolations returns parts of interpolated string literal token
+// and text after last expression.
+func Interpolations(tk obj.Token) ([]Interpolation, string) {
+	// Literal is lexed again at same column for correct positions of tokens.
+	fullLn := strings.Repeat(" ", tk.Column-1) + tk.Val
+	l := &Lex{File: &obj.File{Path: tk.File.Path, Lines: []string{fullLn}}, Line: 1}
+	var parts []Interpolation
+	sb := new(strings.Builder)
+	for l.Column = tk.Column + 1; l.Column < len(fullLn); l.Column++ {
+		c := fullLn[l.Column-1]
+		switch {
+		case (c == '{' || c == '}') && fullLn[l.Column] == c:
+			sb.WriteByte(c)
+			l.Column++
+		case c == '{':
+			part := Interpolation{Text: sb.String()}
+			sb.Reset()
+			part.Tokens, part.Format = l.interpolation(fullLn)
+			for i := range part.Tokens {
+				part.Tokens[i].File = tk.File
+				part.Tokens[i].Line = tk.Line
+			}
+			parts = append(parts, part)
+		case !l.strseq(sb, fullLn):
+			sb.WriteByte(c)
+		}
+	}
+	return parts, sb.String()
+}
+
+// interpolation lexes expression of interpolation that opened at current column.
+// Returns tokens and format specifier of expression, column is set to close brace.
+func (l *Lex) interpolation(fullLn string) ([]obj.Token, string) {
+	colon, end := interpolationEnd(fullLn, l.Column-1)
+	if end == -1 {
+		l.error("Close brace of interpolation is not found!")
+	}
+	format := ""
+	exprEnd := end
+	if colon != -1 {
+		format = fullLn[colon+1 : end]
+		if !formatRgx.MatchString(format) {
+			l.Column = colon + 2
+			l.error("Invalid format specifier!")
+		}
+		exprEnd = colon
+	}
+	var tokens []obj.Token
+	sub := &Lex{File: l.File, Line: l.Line, Column: l.Column + 1, Comments: true}
+	for sub.Column-1 < exprEnd && strings.TrimSpace(fullLn[sub.Column-1:exprEnd]) != "" {
+		tk := sub.Token()
+		if tk.Type == fract.Comment {
+			sub.Column = tk.Column
+			sub.error("Comments are cannot used in interpolations!")
+		}
+		tokens = append(tokens, tk)
+		sub.lastTk = tk
+	}
+	if len(tokens) == 0 {
+		l.error("Expression is not given!")
+	}
+	l.Column = end + 1
+	return tokens, format
+}
+
+// interpolationEnd returns index of close brace of interpolation that opened
+// at index i and index of colon of format specifier.
+// Colon is -1 if format is not given, end is -1 if close brace is not found.
+func interpolationEnd(ln string, i int) (colon, end int) {
+	colon = -1
+	depth := 0
+	for i++; i < len(ln); i++ {
+		switch ln[i] {
+		case '\'': // Skip string.
+			for i++; i < len(ln) && ln[i] != '\''; i++ {
+				if ln[i] == '\\' {
+					i++
+				}
+			}
+		case '`': // Skip raw string.
+			for i++; i < len(ln) && ln[i] != '`'; i++ {
+			}
+		case '(', '[', '{':
+			depth++
+		case ')', ']':
+			depth--
+		case '}':
+			if depth == 0 {
+				return colon, i
+			}
+			depth--
+		case ':':
+			if depth == 0 && colon == -1 {
+				colon = i
+			}
+		case '"':
+			return colon, -1
+		}
+	}
+	return colon, -1
+}
@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"math/big"
 	"regexp"
+	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
+	"github.com/fract-lang/fract/pkg/diag"
 	"github.com/fract-lang/fract/pkg/fract"
 	"github.com/fract-lang/fract/pkg/obj"
 	"github.com/fract-lang/fract/pkg/str"
@@ -14,27 +17,47 @@ import (
 
 // Lexer of Fract.
 type Lex struct {
-	lastTk obj.Token
+	lastTk    obj.Token
+	lineLn    int    // Line of counts of braces.
+	lineCount [3]int // Counts of parentheses, braces and brackets at begin of line.
+	failed    bool   // Last line has error and it is skipped.
 
 	File         *obj.File
 	Column       int // Last column.
 	Line         int // Last line.
 	Finished     bool
 	RangeComment bool
+	RawString    bool // Raw string is not closed at last line.
 	Braces       int
 	Brackets     int
 	Parentheses  int
+	// Errors are added to diagnostics and tokenizing is continued if not nil.
+	Diags *diag.List
+	// Comments are returned as tokens and lines of file are not changed if true.
+	Comments bool
 }
 
 // error thrown exception.
-func (l Lex) error(msg string) {
-	fmt.Printf("File: %s\nPosition: %d:%d\n", l.File.Path, l.Line, l.Column)
+func (l Lex) error(msg string) { panic(l.panic(msg)) }
+
+// panic returns syntax panic of current position.
+func (l Lex) panic(msg string) obj.Panic {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("File: %s\nPosition: %d:%d\n", l.File.Path, l.Line, l.Column))
 	if !l.RangeComment { // Ignore multiline comment error.
-		fmt.Println("    " + strings.ReplaceAll(l.File.Lines[l.Line-1], "\t", " "))
-		fmt.Println(str.Full(4+l.Column-2, ' ') + "^")
+		sb.WriteString("    " + strings.ReplaceAll(l.File.Lines[l.Line-1], "\t", " ") + "\n")
+		sb.WriteString(str.Full(4+l.Column-2, ' ') + "^\n")
+	}
+	sb.WriteString(msg)
+	return obj.Panic{
+		Msg:    sb.String(),
+		Text:   msg,
+		Type:   obj.SyntaxPanic,
+		File:   l.File.Path,
+		Line:   l.Line,
+		Column: l.Column,
+		Fatal:  true,
 	}
-	fmt.Println(msg)
-	panic(nil)
 }
 
 // Check expected bracket or like and returns true if require retokenize, returns false if not.
@@ -43,7 +66,10 @@ func (l *Lex) checkExpected(msg string) bool {
 	if l.Finished {
 		if l.File.Path != "<stdin>" {
 			l.Line-- // Subtract for correct line number.
-			l.error(msg)
+			if l.Diags == nil {
+				l.error(msg)
+			}
+			l.Diags.Add(diag.FromPanic(l.panic(msg)))
 		}
 		return false
 	}
@@ -56,6 +82,9 @@ func (l *Lex) Next() []obj.Token {
 	if l.Finished {
 		return tokens
 	}
+	// Begin of last statement in tokens and counts of braces at begin.
+	// Statement is dropped if it has error, so it is not reported again by parser.
+	stmt, stmtCount := 0, [3]int{l.Parentheses, l.Braces, l.Brackets}
 tokenize:
 	if l.lastTk.Type != fract.StatementTerminator {
 		// Restore to defaults.
@@ -66,7 +95,7 @@ tokenize:
 		l.lastTk.Val = ""
 	}
 	// Tokenize line.
-	tk := l.Token()
+	tk := l.token()
 	for tk.Type != fract.NA {
 		if tk.Type == fract.StatementTerminator {
 			if l.Parentheses == 0 && l.Braces == 0 && l.Brackets == 0 {
@@ -77,13 +106,28 @@ tokenize:
 		if !l.RangeComment && tk.Type != fract.Ignore {
 			tokens = append(tokens, tk)
 			l.lastTk = tk
+			if l.Parentheses == 0 && l.Brackets == 0 &&
+				(tk.Type == fract.StatementTerminator || tk.Type == fract.Brace && tk.Val == "{") {
+				stmt, stmtCount = len(tokens), [3]int{l.Parentheses, l.Braces, l.Brackets}
+			}
 		}
-		tk = l.Token()
+		tk = l.token()
+	}
+	if l.failed {
+		l.failed = false
+		if tokens = tokens[:stmt]; stmt == 0 {
+			tokens = nil
+		}
+		l.Parentheses, l.Braces, l.Brackets = stmtCount[0], stmtCount[1], stmtCount[2]
 	}
 	l.lastTk = tk
 	l.Line++
 	l.Finished = l.Line > len(l.File.Lines)
 	switch {
+	case l.RawString:
+		if l.checkExpected("Raw string is expected to close...") {
+			goto tokenize
+		}
 	case l.Parentheses > 0:
 		if l.checkExpected("Parentheses is expected to close...") {
 			goto tokenize
@@ -104,8 +148,89 @@ tokenize:
 	return tokens
 }
 
+// Source returns all tokens of file with comments.
+// Values of tokens are same with code, statement terminators are not
+// removed and multiline comments are one token.
+func (l *Lex) Source() []obj.Token {
+	var tokens []obj.Token
+	l.Comments = true
+	for l.Line = 1; l.Line <= len(l.File.Lines); l.Line++ {
+		ln := l.File.Lines[l.Line-1]
+		l.Column = 1
+		l.lastTk = obj.Token{}
+		if l.RangeComment {
+			last := &tokens[len(tokens)-1]
+			i := strings.Index(ln, "*/")
+			if i == -1 {
+				last.Val += "\n" + ln
+				continue
+			}
+			last.Val += "\n" + ln[:i+2]
+			l.Column = i + 3
+			l.RangeComment = false
+		}
+		for tk := l.Token(); tk.Type != fract.NA; tk = l.Token() {
+			if tk.Type == fract.StatementTerminator {
+				l.Line++
+			}
+			if tk.Line == l.Line {
+				tk.Val = ln[tk.Column-1 : l.Column-1]
+			} else { // Multiline raw string.
+				ln = l.File.Lines[l.Line-1]
+			}
+			tokens = append(tokens, tk)
+			if tk.Type != fract.Comment {
+				l.lastTk = tk
+			}
+		}
+	}
+	l.Finished = true
+	l.Line = len(l.File.Lines)
+	switch {
+	case l.RawString:
+		l.error("Raw string is expected to close...")
+	case l.Parentheses > 0:
+		l.error("Parentheses is expected to close...")
+	case l.Braces > 0:
+		l.error("Brace is expected to close...")
+	case l.Brackets > 0:
+		l.error("Bracket is expected to close...")
+	case l.RangeComment:
+		l.error("Multiline comment is expected to close...")
+	}
+	return tokens
+}
+
+// token returns next token.
+// Errors are added to diagnostics and rest of line is skipped if diagnostics are collected,
+// statement of line is dropped by Next.
+func (l *Lex) token() (tk obj.Token) {
+	if l.Diags == nil {
+		return l.Token()
+	}
+	if l.lineLn != l.Line {
+		l.lineLn = l.Line
+		l.lineCount = [3]int{l.Parentheses, l.Braces, l.Brackets}
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			cp, ok := r.(obj.Panic)
+			if !ok {
+				panic(r)
+			}
+			l.Diags.Add(diag.FromPanic(cp))
+			l.failed = true
+			// Braces of skipped part of line are ignored.
+			l.Parentheses, l.Braces, l.Brackets = l.lineCount[0], l.lineCount[1], l.lineCount[2]
+			l.Column = len(l.File.Lines[l.Line-1]) + 1
+			tk = obj.Token{Type: fract.NA, File: l.File}
+		}
+	}()
+	return l.Token()
+}
+
 var (
-	numRgx  = *regexp.MustCompile(`^(-|)((\d+((\.\d+)|(\.\d+)?(e|E)(\-|\+)\d+)?)|(0x[[:xdigit:]]+))(\s|[[:punct:]]|$)`)
+	numRgx  = *regexp.MustCompile(`^(-|)((\d+((\.\d+)|(\.\d+)?(e|E)(\-|\+)\d+)?)|(0x[[:xdigit:]]+))(n|d)?(\s|[[:punct:]]|$)`)
 	nameRgx = *regexp.MustCompile(`^[\p{L}|_]([\p{L}0-9_]+)?([[:punct:]]|\s|$)`)
 )
 
@@ -151,6 +276,27 @@ func (l *Lex) strseq(sb *strings.Builder, fullLn string) bool {
 		sb.WriteByte('\a')
 	case 'v':
 		sb.WriteByte('\v')
+	case 'x', 'u', 'U': // Hexadecimal byte or unicode code point.
+		n := 2
+		if c := fullLn[l.Column-1]; c == 'u' {
+			n = 4
+		} else if c == 'U' {
+			n = 8
+		}
+		if l.Column+n > len(fullLn) {
+			l.error("Invalid escape sequence!")
+		}
+		code, err := strconv.ParseUint(fullLn[l.Column:l.Column+n], 16, 32)
+		if err != nil {
+			l.error("Invalid escape sequence!")
+		} else if n == 2 {
+			sb.WriteByte(byte(code))
+		} else if !utf8.ValidRune(rune(code)) {
+			l.error("Invalid unicode code point!")
+		} else {
+			sb.WriteRune(rune(code))
+		}
+		l.Column += n
 	default:
 		l.error("Invalid escape sequence!")
 	}
@@ -161,23 +307,69 @@ func (l *Lex) lexString(tk *obj.Token, quote byte, fullLn string) {
 	sb := new(strings.Builder)
 	sb.WriteByte(quote)
 	l.Column++
+	interpolated := false
 	for ; l.Column < len(fullLn)+1; l.Column++ {
 		c := fullLn[l.Column-1]
 		if c == quote { // Finish?
 			sb.WriteByte(c)
 			break
+		} else if quote == '"' && (c == '{' || c == '}') { // Interpolation?
+			if l.Column < len(fullLn) && fullLn[l.Column] == c { // Escaped brace.
+				sb.WriteByte(c)
+				l.Column++
+				continue
+			} else if c == '}' {
+				l.error("Close brace is must be escaped in string literals!")
+			}
+			interpolated = true
+			l.interpolation(fullLn)
 		} else if !l.strseq(sb, fullLn) {
 			sb.WriteByte(c)
 		}
 	}
 	tk.Val = sb.String()
-	if tk.Val[len(tk.Val)-1] != quote {
+	// Quote at end of line is not closed by itself.
+	if len(tk.Val) == 1 || tk.Val[len(tk.Val)-1] != quote {
 		l.error("Close quote is not found!")
 	}
+	// Value of interpolated string is code of literal, parts are lexed by parser.
+	if interpolated {
+		tk.Val = fullLn[tk.Column-1 : l.Column]
+		tk.Type = fract.Interpolation
+		l.Column = tk.Column
+		return
+	}
 	tk.Type = fract.Value
 	l.Column -= sb.Len() - 1
 }
 
+// lexRawString lexes raw string literal, raw strings may span lines.
+// Line and column are set to after close backtick.
+func (l *Lex) lexRawString(tk *obj.Token, fullLn string) {
+	sb := new(strings.Builder)
+	sb.WriteByte('`')
+	ln := fullLn[l.Column:]
+	for {
+		if i := strings.IndexByte(ln, '`'); i != -1 { // Finish?
+			sb.WriteString(ln[:i+1])
+			tk.Val = sb.String()
+			tk.Type = fract.Value
+			l.Column = len(l.File.Lines[l.Line-1]) - len(ln) + i + 2
+			return
+		}
+		sb.WriteString(ln)
+		if l.Line == len(l.File.Lines) {
+			break
+		}
+		l.Line++
+		ln = l.File.Lines[l.Line-1]
+		sb.WriteByte('\n')
+	}
+	// Close backtick is not found, next lines are required.
+	l.RawString = true
+	l.Column = len(ln) + 1
+}
+
 func (l *Lex) lexname(tk *obj.Token, chk string) bool {
 	// Remove punct.
 	if chk[len(chk)-1] != '_' {
@@ -198,7 +390,7 @@ func (l *Lex) Token() obj.Token {
 	fullLn := l.File.Lines[l.Line-1] // Full line.
 	// Line is finished.
 	if l.Column > len(fullLn) {
-		if l.RangeComment {
+		if l.RangeComment && !l.Comments {
 			l.File.Lines[l.Line-1] = ""
 		}
 		return tk
@@ -240,23 +432,30 @@ func (l *Lex) Token() obj.Token {
 		(l.lastTk.Type == fract.Brace && l.lastTk.Val != "}" && l.lastTk.Val != "]" && l.lastTk.Val != ")") ||
 		l.lastTk.Type == fract.StatementTerminator || l.lastTk.Type == fract.Loop ||
 		l.lastTk.Type == fract.Comma || l.lastTk.Type == fract.In || l.lastTk.Type == fract.If ||
-		l.lastTk.Type == fract.Else || l.lastTk.Type == fract.Return || l.lastTk.Type == fract.Colon)) ||
+		l.lastTk.Type == fract.Else || l.lastTk.Type == fract.Return || l.lastTk.Type == fract.Colon ||
+		l.lastTk.Type == fract.Match || l.lastTk.Type == fract.Case || l.lastTk.Type == fract.Yield)) ||
 		isKeyword(ln, "nan"): // Numeric oop.
 		if chk == "" {
 			chk = "NaN"
 			l.Column += 3
 		} else {
 			// Remove punct.
-			if lst := chk[len(chk)-1]; lst < '0' || lst > '9' {
+			if lst := rune(chk[len(chk)-1]); unicode.IsSpace(lst) || unicode.IsPunct(lst) || unicode.IsSymbol(lst) {
 				chk = chk[:len(chk)-1]
 			}
 			l.Column += len(chk)
+			// Literal suffix of bigint or decimal.
+			suffix := ""
+			if lst := chk[len(chk)-1]; lst == 'n' || (lst == 'd' && !strings.HasPrefix(chk, "0x")) {
+				suffix = string(lst)
+				chk = chk[:len(chk)-1]
+			}
 			if strings.HasPrefix(chk, "0x") {
 				// Parse hexadecimal to decimal.
 				bigInt := new(big.Int)
 				bigInt.SetString(chk[2:], 16)
 				chk = bigInt.String()
-			} else {
+			} else if suffix == "" {
 				// Parse floating-point.
 				bigFloat := new(big.Float)
 				_, f := bigFloat.SetString(chk)
@@ -264,14 +463,30 @@ func (l *Lex) Token() obj.Token {
 					chk = bigFloat.String()
 				}
 			}
+			chk += suffix
 		}
 		tk.Val = chk
 		tk.Type = fract.Value
 		return tk
 	case strings.HasPrefix(ln, "//"):
+		if l.Comments {
+			tk.Val = ln
+			tk.Type = fract.Comment
+			break
+		}
 		l.File.Lines[l.Line-1] = l.File.Lines[l.Line-1][:l.Column-1] // Remove comment from original line.
 		return tk
 	case strings.HasPrefix(ln, "/*"):
+		if l.Comments {
+			tk.Type = fract.Comment
+			if i := strings.Index(ln[2:], "*/"); i != -1 {
+				tk.Val = ln[:i+4]
+				break
+			}
+			tk.Val = ln
+			l.RangeComment = true
+			break
+		}
 		l.RangeComment = true
 		tk.Val = "/*"
 		tk.Type = fract.Ignore
@@ -282,6 +497,9 @@ func (l *Lex) Token() obj.Token {
 		l.lexString(&tk, '\'', fullLn)
 	case ln[0] == '"':
 		l.lexString(&tk, '"', fullLn)
+	case ln[0] == '`':
+		l.lexRawString(&tk, fullLn)
+		return tk
 	case ln[0] == ';':
 		tk.Val = ";"
 		tk.Type = fract.StatementTerminator
@@ -376,6 +594,9 @@ func (l *Lex) Token() obj.Token {
 		}
 		tk.Val = "]"
 		tk.Type = fract.Brace
+	case strings.HasPrefix(ln, "<-"):
+		tk.Val = "<-"
+		tk.Type = fract.Operator
 	case strings.HasPrefix(ln, "<<"):
 		tk.Val = "<<"
 		tk.Type = fract.Operator
@@ -463,12 +684,18 @@ func (l *Lex) Token() obj.Token {
 	case isKeyword(ln, "return"):
 		tk.Val = "return"
 		tk.Type = fract.Return
+	case isKeyword(ln, "yield"):
+		tk.Val = "yield"
+		tk.Type = fract.Yield
 	case isKeyword(ln, "try"):
 		tk.Val = "try"
 		tk.Type = fract.Try
 	case isKeyword(ln, "catch"):
 		tk.Val = "catch"
 		tk.Type = fract.Catch
+	case isKeyword(ln, "finally"):
+		tk.Val = "finally"
+		tk.Type = fract.Finally
 	case isKeyword(ln, "open"):
 		tk.Val = "open"
 		tk.Type = fract.Import
@@ -493,6 +720,15 @@ func (l *Lex) Token() obj.Token {
 	case isKeyword(ln, "none"):
 		tk.Val = "none"
 		tk.Type = fract.None
+	case isKeyword(ln, "match"):
+		tk.Val = "match"
+		tk.Type = fract.Match
+	case isKeyword(ln, "case"):
+		tk.Val = "case"
+		tk.Type = fract.Case
+	case isKeyword(ln, "select"):
+		tk.Val = "select"
+		tk.Type = fract.Select
 	default: // Alternates
 		// Check variable name.
 		if chk := getName(ln); chk != "" { // Name.
@@ -0,0 +1,83 @@
+package lex
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fract-lang/fract/pkg/fract"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// tokenize returns all tokens of code.
+func tokenize(code string) (tokens []obj.Token) {
+	l := &Lex{File: &obj.File{Path: "test.fract", Lines: strings.Split(code, "\n")}, Line: 1}
+	for !l.Finished {
+		tokens = append(tokens, l.Next()...)
+	}
+	return tokens
+}
+
+func TestStrings(t *testing.T) {
+	tests := []struct {
+		code string
+		typ  uint8
+		want string
+	}{
+		{`"a\tb\\\""`, fract.Value, "\"a\tb\\\"\""},
+		{`'café'`, fract.Value, "'café'"},
+		{`"\x41\U0001F600"`, fract.Value, `"A😀"`},
+		{`'{x}'`, fract.Value, `'{x}'`},
+		{`"{{x}}"`, fract.Value, `"{x}"`},
+		{`"a {x}"`, fract.Interpolation, `"a {x}"`},
+		{"`a\\n\"{x}\"`", fract.Value, "`a\\n\"{x}\"`"},
+		{"`first\n  second`", fract.Value, "`first\n  second`"},
+	}
+	for _, test := range tests {
+		tokens := tokenize(test.code + " + x")
+		if len(tokens) != 3 {
+			t.Errorf("%s: got %d tokens, want 3", test.code, len(tokens))
+			continue
+		}
+		if tk := tokens[0]; tk.Type != test.typ || tk.Val != test.want {
+			t.Errorf("%s: got %d %q, want %d %q", test.code, tk.Type, tk.Val, test.typ, test.want)
+		}
+	}
+}
+
+func TestRawStringPosition(t *testing.T) {
+	tokens := tokenize("x := `a\nb` + y")
+	if len(tokens) != 5 {
+		t.Fatalf("got %d tokens, want 5", len(tokens))
+	}
+	// Tokens after raw string are at last line of it.
+	if tk := tokens[4]; tk.Val != "y" || tk.Line != 2 || tk.Column != 6 {
+		t.Errorf("got %s at %d:%d, want y at 2:6", tk.Val, tk.Line, tk.Column)
+	}
+}
+
+func TestStringErrors(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{`"\q"`, "Invalid escape sequence!"},
+		{`"\x4"`, "Invalid escape sequence!"},
+		{`"\u12g4"`, "Invalid escape sequence!"},
+		{`"\UFFFFFFFF"`, "Invalid unicode code point!"},
+		{`"abc`, "Close quote is not found!"},
+		{`x := '`, "Close quote is not found!"},
+		{`"a}"`, "Close brace is must be escaped in string literals!"},
+		{"x := `abc", "Raw string is expected to close..."},
+	}
+	for _, test := range tests {
+		func() {
+			defer func() {
+				cp, ok := recover().(obj.Panic)
+				if !ok || cp.Text != test.want {
+					t.Errorf("%s: got %q, want %q", test.code, cp.Text, test.want)
+				}
+			}()
+			tokenize(test.code)
+		}()
+	}
+}
@@ -0,0 +1,138 @@
+package lint
+
+import (
+	"fmt"
+	"unicode"
+
+	"github.com/fract-lang/fract/ast"
+	"github.com/fract-lang/fract/pkg/diag"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// Unused reports local variables that never used.
+var Unused = &Analyzer{
+	ID:       "unused",
+	Doc:      "reports local variables that declared but not used",
+	Severity: diag.Warning,
+	Run: func(p *Pass) {
+		for _, o := range p.Info.Objects {
+			if o.Kind != Var || o.Level == 0 || o.Used {
+				continue
+			}
+			fix := "Remove declaration of " + o.Name.Val
+			if o.Loop {
+				fix = "Replace " + o.Name.Val + " with _"
+			}
+			p.Report(o.Name, fmt.Sprintf("%q is declared but not used", o.Name.Val), fix)
+		}
+	},
+}
+
+// Shadow reports declarations of names that already visible.
+// Interpreter panics at these declarations.
+var Shadow = &Analyzer{
+	ID:       "shadow",
+	Doc:      "reports declarations of names that already defined in outer scopes",
+	Severity: diag.Error,
+	Run: func(p *Pass) {
+		for _, r := range p.Info.Redecls {
+			msg := fmt.Sprintf("%q shadows built-in define", r.Obj.Name.Val)
+			if r.Prev.Kind != Builtin {
+				msg = fmt.Sprintf("%q shadows declaration at line %d", r.Obj.Name.Val, r.Prev.Name.Line)
+			}
+			fix := "Rename " + r.Obj.Name.Val
+			if r.Obj.Kind == Var && r.Prev.Kind == Var {
+				fix += " or assign with ="
+			}
+			p.Report(r.Obj.Name, msg, fix)
+		}
+	},
+}
+
+// Unreachable reports statements after return, break, continue, panic or exit.
+var Unreachable = &Analyzer{
+	ID:       "unreachable",
+	Doc:      "reports statements that never run",
+	Severity: diag.Warning,
+	Run: func(p *Pass) {
+		for _, stmts := range p.Info.Blocks {
+			for i, stmt := range stmts {
+				if !terminates(stmt) {
+					continue
+				}
+				for _, next := range stmts[i+1:] {
+					if _, ok := next.(*ast.Pragma); !ok {
+						p.Report(stmtStart(next), "unreachable code", "Remove unreachable statements")
+						break
+					}
+				}
+				break
+			}
+		}
+	},
+}
+
+// stmtStart returns first token of statement.
+func stmtStart(stmt ast.Stmt) obj.Token {
+	if s, ok := stmt.(*ast.ExprStmt); ok {
+		return exprStart(s.X)
+	}
+	return stmt.Token()
+}
+
+func exprStart(e ast.Expr) obj.Token {
+	switch t := e.(type) {
+	case *ast.Call:
+		return exprStart(t.Fn)
+	case *ast.Selector:
+		return exprStart(t.X)
+	case *ast.Index:
+		return exprStart(t.X)
+	case *ast.Binary:
+		return exprStart(t.Left)
+	case *ast.Compare:
+		return exprStart(t.Left)
+	case *ast.Logical:
+		return exprStart(t.Left)
+	}
+	return e.Token()
+}
+
+// terminates returns true if statement never continues to next statement, returns false if not.
+func terminates(stmt ast.Stmt) bool {
+	switch s := stmt.(type) {
+	case *ast.Return, *ast.Break, *ast.Continue:
+		return true
+	case *ast.ExprStmt:
+		if call, ok := s.X.(*ast.Call); ok {
+			if name, ok := call.Fn.(*ast.Name); ok {
+				return name.Tk.Val == "panic" || name.Tk.Val == "exit"
+			}
+		}
+	case *ast.Block:
+		return len(s.Stmts) > 0 && terminates(s.Stmts[len(s.Stmts)-1])
+	case *ast.If:
+		return s.Else != nil && terminates(s.Body) && terminates(s.Else)
+	}
+	return false
+}
+
+// Private reports selections of not exported names of imported packages.
+var Private = &Analyzer{
+	ID:       "private",
+	Doc:      "reports accesses to not exported names of packages",
+	Severity: diag.Error,
+	Run: func(p *Pass) {
+		for _, s := range p.Info.Selects {
+			if name := s.Name.Val; name != "" && !unicode.IsUpper(rune(name[0])) {
+				p.Report(s.Name, fmt.Sprintf("%q is not exported by package", name), "Use an exported (upper case) name")
+			}
+		}
+	},
+}
+
+func init() {
+	for _, a := range []*Analyzer{Unused, Shadow, Unreachable, Private} {
+		Register(a)
+	}
+}
@@ -0,0 +1,198 @@
+// Package lint implements static analyzers of Fract code.
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fract-lang/fract/ast"
+	"github.com/fract-lang/fract/pkg/diag"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// ConfigFile is name of project config file of linter.
+// Config file is searched from directory of code to root directory.
+const ConfigFile = ".fractlint.json"
+
+// Analyzer is check of code.
+type Analyzer struct {
+	ID       string // Name for configs and pragmas.
+	Doc      string
+	Severity diag.Severity // Default severity of diagnostics.
+	Run      func(*Pass)
+}
+
+// Pass is run of analyzer on syntax tree of file.
+type Pass struct {
+	Analyzer *Analyzer
+	Path     string
+	Tree     *ast.Block
+	Info     *Info
+	severity diag.Severity
+	diags    *diag.List
+}
+
+// Report adds diagnostic with suggested fix at position of token.
+func (p *Pass) Report(tk obj.Token, msg, fix string) {
+	p.diags.Add(diag.Diagnostic{
+		Severity: p.severity,
+		Code:     p.Analyzer.ID,
+		Span: diag.Span{
+			File:      p.Path,
+			Line:      tk.Line,
+			Column:    tk.Column,
+			EndLine:   tk.Line,
+			EndColumn: tk.Column + len(tk.Val),
+		},
+		Message: msg,
+		Fix:     fix,
+	})
+}
+
+var analyzers []*Analyzer
+
+// Register adds analyzer to default analyzers of linter.
+// Panics if ID of analyzer is already registered.
+func Register(a *Analyzer) {
+	if Lookup(a.ID) != nil {
+		panic("lint: analyzer is already registered: " + a.ID)
+	}
+	analyzers = append(analyzers, a)
+}
+
+// Analyzers returns registered analyzers.
+func Analyzers() []*Analyzer { return append([]*Analyzer(nil), analyzers...) }
+
+// Lookup returns registered analyzer by ID, returns nil if not exist.
+func Lookup(id string) *Analyzer {
+	for _, a := range analyzers {
+		if a.ID == id {
+			return a
+		}
+	}
+	return nil
+}
+
+// Config of linter.
+type Config struct {
+	Disable  []string                 `json:"disable"`  // IDs of disabled analyzers.
+	Severity map[string]diag.Severity `json:"severity"` // Severities by IDs of analyzers.
+}
+
+// LoadConfig reads config file of directory or nearest parent directory.
+// Returns empty config if config file is not exist.
+func LoadConfig(dir string) (Config, error) {
+	var cfg Config
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return cfg, err
+	}
+	for {
+		path := filepath.Join(dir, ConfigFile)
+		data, err := os.ReadFile(path)
+		if err == nil {
+			if err := json.Unmarshal(data, &cfg); err != nil {
+				return cfg, fmt.Errorf("%s: %v", path, err)
+			}
+			ids := cfg.Disable
+			for id := range cfg.Severity {
+				ids = append(ids, id)
+			}
+			for _, id := range ids {
+				if Lookup(id) == nil {
+					return cfg, fmt.Errorf("%s: analyzer is not exist: %s", path, id)
+				}
+			}
+			return cfg, nil
+		} else if !os.IsNotExist(err) {
+			return cfg, err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return cfg, nil
+		}
+		dir = parent
+	}
+}
+
+// Linter runs analyzers on syntax trees.
+type Linter struct {
+	Analyzers []*Analyzer
+	Config    Config
+	Builtins  []string // Names that defined before code.
+}
+
+// New returns linter with registered analyzers.
+func New(cfg Config, builtins []string) *Linter {
+	return &Linter{Analyzers: Analyzers(), Config: cfg, Builtins: builtins}
+}
+
+// suppressions returns analyzer IDs by suppressed lines of nolint pragmas.
+// Pragma suppresses its line and line of next statement, all analyzers are
+// suppressed if IDs are not given.
+func suppressions(info *Info) map[int][]string {
+	lines := map[int][]string{}
+	for _, stmts := range info.Blocks {
+		for i, stmt := range stmts {
+			pragma, ok := stmt.(*ast.Pragma)
+			if !ok || pragma.Name.Val != "nolint" {
+				continue
+			}
+			ids := []string{}
+			for _, arg := range pragma.Args {
+				ids = append(ids, arg.Val)
+			}
+			lines[pragma.Tk.Line] = ids
+			if i+1 < len(stmts) {
+				lines[stmts[i+1].Token().Line] = ids
+			}
+		}
+	}
+	return lines
+}
+
+func suppressed(lines map[int][]string, d diag.Diagnostic) bool {
+	ids, ok := lines[d.Span.Line]
+	if !ok {
+		return false
+	} else if len(ids) == 0 {
+		return true
+	}
+	for _, id := range ids {
+		if id == d.Code {
+			return true
+		}
+	}
+	return false
+}
+
+// Lint returns diagnostics of syntax tree of file.
+func (l *Linter) Lint(path string, tree *ast.Block) diag.List {
+	info := Resolve(tree, l.Builtins)
+	disabled := map[string]bool{}
+	for _, id := range l.Config.Disable {
+		disabled[id] = true
+	}
+	var diags diag.List
+	for _, a := range l.Analyzers {
+		if disabled[a.ID] {
+			continue
+		}
+		pass := &Pass{Analyzer: a, Path: path, Tree: tree, Info: info, severity: a.Severity, diags: &diags}
+		if severity, ok := l.Config.Severity[a.ID]; ok {
+			pass.severity = severity
+		}
+		a.Run(pass)
+	}
+	lines := suppressions(info)
+	var result diag.List
+	for _, d := range diags {
+		if !suppressed(lines, d) {
+			result.Add(d)
+		}
+	}
+	result.Sort()
+	return result
+}
@@ -0,0 +1,78 @@
+package lint_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/fract-lang/fract/lint"
+	"github.com/fract-lang/fract/parser"
+	"github.com/fract-lang/fract/pkg/diag"
+)
+
+// lintSource returns texts of diagnostics of code by config.
+func lintSource(t *testing.T, cfg lint.Config, src string) []string {
+	t.Helper()
+	rt := parser.NewRuntime("../stdlib")
+	p := parser.NewSource(rt, "x.fract", src)
+	if diags := p.Check(); len(diags) > 0 {
+		t.Fatal(diags)
+	}
+	var builtins []string
+	for _, f := range rt.BuiltIns().Funcs {
+		builtins = append(builtins, f.Name)
+	}
+	var texts []string
+	for _, d := range lint.New(cfg, builtins).Lint("x.fract", p.Tree()) {
+		texts = append(texts, d.String())
+	}
+	return texts
+}
+
+// TestLint reports diagnostics of analyzers and suppressions of pragmas.
+func TestLint(t *testing.T) {
+	const src = "package main\n\nfunc f(a) {\n    x := 1\n    #nolint unused\n    y := 2\n    len := 3\n" +
+		"    return a\n    print(len)\n}\nf(1)\nopen reflect\nprintln(reflect.NameOfType(1), reflect.typeCode)\n"
+	want := []string{
+		"x.fract:4:5: warning: \"x\" is declared but not used (unused)",
+		"x.fract:7:5: error: \"len\" shadows built-in define (shadow)",
+		"x.fract:9:5: warning: unreachable code (unreachable)",
+		"x.fract:13:40: error: \"typeCode\" is not exported by package (private)",
+	}
+	if got := lintSource(t, lint.Config{}, src); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// TestConfig disables analyzers and changes severities by config file of parent directory.
+func TestConfig(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "src")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(root, lint.ConfigFile)
+	if err := os.WriteFile(path, []byte(`{"disable": ["unreachable"], "severity": {"unused": "error"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := lint.LoadConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cfg.Disable, []string{"unreachable"}) || cfg.Severity["unused"] != diag.Error {
+		t.Fatalf("got %+v", cfg)
+	}
+	const src = "package main\n\nfunc f() {\n    x := 1\n    return\n    f()\n}\n"
+	want := []string{"x.fract:4:5: error: \"x\" is declared but not used (unused)"}
+	if got := lintSource(t, cfg, src); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"disable": ["spelling"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lint.LoadConfig(dir); err == nil {
+		t.Error("got nil error for not existing analyzer")
+	}
+}
@@ -0,0 +1,353 @@
+package lint
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/fract-lang/fract/ast"
+	"github.com/fract-lang/fract/pkg/fract"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// Kind of object.
+type Kind uint8
+
+const (
+	Builtin Kind = iota
+	Var
+	Param
+	Catch
+	Func
+	Type
+	Package
+	Field
+)
+
+// Object is declared name of code.
+type Object struct {
+	Name  obj.Token
+	Kind  Kind
+	Level int  // Depth of scope, file scope is 0.
+	Loop  bool // Variable of loop.
+	Used  bool
+}
+
+// Redeclaration is declare of name that already visible.
+type Redeclaration struct {
+	Obj  *Object
+	Prev *Object
+}
+
+// Info is resolved names of syntax tree.
+type Info struct {
+	Objects []*Object
+	Redecls []Redeclaration
+	Selects []*ast.Selector // Selections of imported packages.
+	Blocks  [][]ast.Stmt    // Statements of all blocks.
+}
+
+type scope struct {
+	parent *scope
+	objs   map[string]*Object
+	level  int
+}
+
+// Resolver of names.
+type resolver struct {
+	info  *Info
+	scope *scope
+}
+
+// Resolve returns resolved names of syntax tree.
+// Builtins are names that defined before code.
+func Resolve(tree *ast.Block, builtins []string) *Info {
+	r := &resolver{info: &Info{}}
+	r.scope = &scope{objs: map[string]*Object{}, level: -1}
+	for _, name := range builtins {
+		r.scope.objs[name] = &Object{Name: obj.Token{Val: name}, Kind: Builtin, Level: -1}
+	}
+	r.open()
+	r.stmts(tree.Stmts)
+	return r.info
+}
+
+func (r *resolver) open() {
+	r.scope = &scope{parent: r.scope, objs: map[string]*Object{}, level: r.scope.level + 1}
+}
+
+func (r *resolver) close() { r.scope = r.scope.parent }
+
+func (r *resolver) lookup(name string) *Object {
+	for s := r.scope; s != nil; s = s.parent {
+		if o, ok := s.objs[name]; ok {
+			return o
+		}
+	}
+	return nil
+}
+
+// declare name to current scope.
+// Redeclarations are recorded for all names except parameters and fields,
+// interpreter panics for them.
+func (r *resolver) declare(tk obj.Token, kind Kind) *Object {
+	if tk.Val == "" || tk.Val == "_" {
+		return nil
+	}
+	o := &Object{Name: tk, Kind: kind, Level: r.scope.level}
+	if kind != Param && kind != Field {
+		if prev := r.lookup(tk.Val); prev != nil && prev.Kind != Field {
+			r.info.Redecls = append(r.info.Redecls, Redeclaration{Obj: o, Prev: prev})
+		}
+	}
+	r.scope.objs[tk.Val] = o
+	r.info.Objects = append(r.info.Objects, o)
+	return o
+}
+
+func (r *resolver) use(tk obj.Token) {
+	if o := r.lookup(tk.Val); o != nil {
+		o.Used = true
+	}
+}
+
+// block resolves statements in new scope.
+func (r *resolver) block(b *ast.Block) {
+	if b == nil {
+		return
+	}
+	r.open()
+	r.stmts(b.Stmts)
+	r.close()
+}
+
+func (r *resolver) params(params []ast.Param) {
+	for _, param := range params {
+		if param.Default != nil {
+			r.expr(param.Default)
+		}
+		r.declare(param.Name, Param)
+	}
+}
+
+// function resolves parameters and body of function.
+func (r *resolver) function(params []ast.Param, body *ast.Block) {
+	r.open()
+	r.params(params)
+	if body != nil {
+		r.stmts(body.Stmts)
+	}
+	r.close()
+}
+
+// packageName returns name of imported package.
+func packageName(s *ast.Import) string {
+	if s.Alias.Val != "" {
+		return s.Alias.Val
+	} else if s.Path.Type == fract.Name {
+		return s.Path.Val[strings.LastIndexByte(s.Path.Val, '.')+1:]
+	}
+	return filepath.Base(s.Path.Val[1 : len(s.Path.Val)-1])
+}
+
+func (r *resolver) stmts(stmts []ast.Stmt) {
+	r.info.Blocks = append(r.info.Blocks, stmts)
+	for _, stmt := range stmts {
+		r.stmt(stmt)
+	}
+}
+
+func (r *resolver) stmt(stmt ast.Stmt) {
+	switch s := stmt.(type) {
+	case *ast.ExprStmt:
+		r.expr(s.X)
+	case *ast.VarDecl:
+		for _, spec := range s.Specs {
+			r.expr(spec.Val)
+			r.declare(spec.Name, Var)
+		}
+	case *ast.ShortVarDecl:
+		for _, val := range s.Vals {
+			r.expr(val)
+		}
+		for _, name := range s.Names {
+			r.declare(name.Name, Var)
+		}
+	case *ast.Assign:
+		// Variables are not used by only set.
+		if _, ok := s.Target.(*ast.Name); !ok || s.Setter.Val != "=" {
+			r.expr(s.Target)
+		}
+		r.expr(s.Val)
+	case *ast.If:
+		r.expr(s.Cond)
+		r.block(s.Body)
+		if s.Else != nil {
+			r.stmt(s.Else)
+		}
+	case *ast.Loop:
+		if s.Cond != nil {
+			r.expr(s.Cond)
+		}
+		if s.Iter != nil {
+			r.expr(s.Iter)
+		}
+		r.open()
+		for _, tk := range []obj.Token{s.Key, s.Elem} {
+			if o := r.declare(tk, Var); o != nil {
+				o.Loop = true
+			}
+		}
+		r.stmts(s.Body.Stmts)
+		r.close()
+	case *ast.Return:
+		for _, val := range s.Vals {
+			r.expr(val)
+		}
+	case *ast.Yield:
+		r.expr(s.Val)
+	case *ast.FuncDecl:
+		r.declare(s.Name, Func)
+		r.function(s.Params, s.Body)
+	case *ast.StructDecl:
+		r.declare(s.Name, Type)
+	case *ast.ClassDecl:
+		r.declare(s.Name, Type)
+		r.open()
+		for _, decl := range s.Vars {
+			for _, spec := range decl.Specs {
+				r.expr(spec.Val)
+				r.declare(spec.Name, Field)
+			}
+		}
+		for _, decl := range s.Funcs {
+			r.declare(decl.Name, Field)
+		}
+		for _, decl := range s.Funcs {
+			r.function(decl.Params, decl.Body)
+		}
+		r.close()
+	case *ast.TryCatch:
+		r.block(s.Try)
+		for _, c := range s.Catches {
+			r.open()
+			r.declare(c.Name, Catch)
+			r.stmts(c.Body.Stmts)
+			r.close()
+		}
+		r.block(s.Finally)
+	case *ast.Import:
+		name := s.Path
+		name.Val = packageName(s)
+		r.declare(name, Package)
+	case *ast.Defer:
+		r.expr(s.Call)
+	case *ast.Go:
+		r.expr(s.Call)
+	case *ast.Send:
+		r.expr(s.Ch)
+		r.expr(s.Val)
+	case *ast.Select:
+		for _, c := range s.Cases {
+			if c.Comm != nil {
+				r.stmt(c.Comm)
+			}
+			r.open()
+			r.declare(c.Name, Var)
+			r.stmts(c.Body.Stmts)
+			r.close()
+		}
+	case *ast.Match:
+		r.expr(s.Val)
+		for _, c := range s.Cases {
+			r.open()
+			for _, pattern := range c.Patterns {
+				r.pattern(pattern)
+			}
+			r.stmts(c.Body.Stmts)
+			r.close()
+		}
+	case *ast.Block:
+		r.block(s)
+	}
+}
+
+// pattern resolves pattern of match case.
+// Names are bound, pinned expressions are used.
+func (r *resolver) pattern(e ast.Expr) {
+	switch t := e.(type) {
+	case *ast.Name:
+		r.declare(t.Tk, Var)
+	case *ast.Pin:
+		r.expr(t.Expr)
+	case *ast.Rest:
+		r.declare(t.Name, Var)
+	case *ast.List:
+		for _, elem := range t.Elems {
+			r.pattern(elem)
+		}
+	case *ast.Call:
+		r.expr(t.Fn)
+		for _, arg := range t.Args {
+			r.pattern(arg.Val)
+		}
+	default:
+		r.expr(e)
+	}
+}
+
+func (r *resolver) expr(e ast.Expr) {
+	switch t := e.(type) {
+	case *ast.Name:
+		r.use(t.Tk)
+	case *ast.Interpolation:
+		for _, e := range t.Exprs {
+			r.expr(e)
+		}
+	case *ast.Binary:
+		r.expr(t.Left)
+		r.expr(t.Right)
+	case *ast.Compare:
+		r.expr(t.Left)
+		r.expr(t.Right)
+	case *ast.Logical:
+		r.expr(t.Left)
+		r.expr(t.Right)
+	case *ast.Selector:
+		r.expr(t.X)
+		if name, ok := t.X.(*ast.Name); ok {
+			if o := r.lookup(name.Tk.Val); o != nil && o.Kind == Package {
+				r.info.Selects = append(r.info.Selects, t)
+			}
+		}
+	case *ast.Index:
+		r.expr(t.X)
+		r.expr(t.Index)
+	case *ast.Call:
+		r.expr(t.Fn)
+		for _, arg := range t.Args {
+			r.expr(arg.Val)
+		}
+	case *ast.List:
+		for _, elem := range t.Elems {
+			r.expr(elem)
+		}
+	case *ast.Map:
+		for i := range t.Keys {
+			r.expr(t.Keys[i])
+			r.expr(t.Vals[i])
+		}
+	case *ast.Comprehension:
+		r.expr(t.Iter)
+		r.open()
+		r.declare(t.Name, Param)
+		r.expr(t.Select)
+		if t.Filter != nil {
+			r.expr(t.Filter)
+		}
+		r.close()
+	case *ast.Func:
+		r.function(t.Params, t.Body)
+	case *ast.Receive:
+		r.expr(t.Ch)
+	}
+}
@@ -0,0 +1,343 @@
+// Package lsp implements Language Server Protocol server of Fract.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fract-lang/fract/oop"
+	"github.com/fract-lang/fract/parser"
+	"github.com/fract-lang/fract/pkg/diag"
+	"github.com/fract-lang/fract/pkg/fract"
+)
+
+// Document that opened by client.
+type document struct {
+	uri   string
+	lines []string
+	index *index
+}
+
+// Server of Language Server Protocol.
+// Requests are processed in order of receive.
+type Server struct {
+	rt       *parser.Runtime
+	in       *bufio.Reader
+	out      io.Writer
+	docs     map[string]*document
+	builtins oop.DefMap
+	methods  []completionItem // Methods of lists, strings and maps.
+	shutdown bool
+}
+
+// NewServer returns server that reads requests from in and writes responses to out.
+func NewServer(rt *parser.Runtime, in io.Reader, out io.Writer) *Server {
+	s := &Server{
+		rt:       rt,
+		in:       bufio.NewReader(in),
+		out:      out,
+		docs:     map[string]*document{},
+		builtins: rt.BuiltIns(),
+	}
+	seen := map[string]bool{}
+	add := func(typ string, funcs []*oop.Fn) {
+		for _, f := range funcs {
+			if !seen[f.Name] {
+				seen[f.Name] = true
+				s.methods = append(s.methods, completionItem{Label: f.Name, Kind: completionMethod, Detail: typ + "." + f.Signature()[5:]})
+			}
+		}
+	}
+	add("list", oop.NewListModel().Defs.Funcs)
+	add("string", oop.NewStringModel("").Defs.Funcs)
+	add("map", oop.NewMapModel().Defs.Funcs)
+	return s
+}
+
+// Serve processes requests until exit notification.
+// Returns error if connection is broken.
+func (s *Server) Serve() error {
+	for {
+		data, err := readMessage(s.in)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		var req request
+		if err := json.Unmarshal(data, &req); err != nil {
+			s.reply(nil, nil, &responseError{Code: codeParseError, Message: err.Error()})
+			continue
+		}
+		if req.Method == "exit" {
+			return nil
+		}
+		s.handle(&req)
+	}
+}
+
+// handle request and reply if request is not notification.
+func (s *Server) handle(req *request) {
+	var (
+		result interface{}
+		rerr   *responseError
+	)
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				rerr = &responseError{Code: codeInternalError, Message: fmt.Sprint(r)}
+			}
+		}()
+		result, rerr = s.process(req)
+	}()
+	if req.ID != nil {
+		s.reply(req.ID, result, rerr)
+	}
+}
+
+func (s *Server) reply(id *json.RawMessage, result interface{}, rerr *responseError) {
+	if rerr != nil {
+		writeMessage(s.out, errorResponse{JSONRPC: "2.0", ID: id, Error: *rerr})
+		return
+	}
+	writeMessage(s.out, response{JSONRPC: "2.0", ID: id, Result: result})
+}
+
+func (s *Server) notify(method string, params interface{}) {
+	writeMessage(s.out, notification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+// process returns result of request.
+func (s *Server) process(req *request) (interface{}, *responseError) {
+	unmarshal := func(v interface{}) *responseError {
+		if err := json.Unmarshal(req.Params, v); err != nil {
+			return &responseError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		return nil
+	}
+	if s.shutdown && req.ID != nil {
+		return nil, &responseError{Code: codeInvalidRequest, Message: "server is shut down"}
+	}
+	switch req.Method {
+	case "initialize":
+		return map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync":   1, // Full content.
+				"definitionProvider": true,
+				"hoverProvider":      true,
+				"completionProvider": map[string]interface{}{"triggerCharacters": []string{"."}},
+			},
+			"serverInfo": map[string]string{"name": "fract", "version": fract.Version},
+		}, nil
+	case "initialized", "$/cancelRequest", "$/setTrace":
+		return nil, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		s.update(params.TextDocument.URI, params.TextDocument.Text)
+		return nil, nil
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		if n := len(params.ContentChanges); n > 0 {
+			s.update(params.TextDocument.URI, params.ContentChanges[n-1].Text)
+		}
+		return nil, nil
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		delete(s.docs, params.TextDocument.URI)
+		s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: params.TextDocument.URI, Diagnostics: []Diagnostic{}})
+		return nil, nil
+	case "textDocument/definition", "textDocument/hover", "textDocument/completion":
+		var params positionParams
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		doc := s.docs[params.TextDocument.URI]
+		if doc == nil {
+			return nil, nil
+		}
+		switch req.Method {
+		case "textDocument/definition":
+			return doc.definition(params.Position), nil
+		case "textDocument/hover":
+			return s.hover(doc, params.Position), nil
+		}
+		return s.completion(doc, params.Position), nil
+	}
+	if req.ID == nil { // Unknown notifications are ignored.
+		return nil, nil
+	}
+	return nil, &responseError{Code: codeMethodNotFound, Message: "method is not supported: " + req.Method}
+}
+
+// update content of document and publish diagnostics of it.
+func (s *Server) update(uri, text string) {
+	p := parser.NewSource(s.rt, uriPath(uri), text)
+	diags := p.Check()
+	doc := &document{uri: uri, lines: strings.Split(text, "\n"), index: newIndex(p.Tree(), p.Tokens)}
+	s.docs[uri] = doc
+	ds := make([]Diagnostic, len(diags))
+	for i, d := range diags {
+		ds[i] = doc.diagnostic(d)
+	}
+	s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: uri, Diagnostics: ds})
+}
+
+// line returns line of document by zero based index.
+func (doc *document) line(i int) string {
+	if i < 0 || i >= len(doc.lines) {
+		return ""
+	}
+	return strings.TrimRight(doc.lines[i], "\r")
+}
+
+// nameRange returns range of name at line.
+func (doc *document) nameRange(line int, name string) Range {
+	col := nameColumn(doc.line(line), name)
+	return Range{
+		Start: Position{Line: line, Character: col},
+		End:   Position{Line: line, Character: col + len(name)},
+	}
+}
+
+func (doc *document) diagnostic(d diag.Diagnostic) Diagnostic {
+	severity := 1
+	if d.Severity == diag.Warning {
+		severity = 2
+	}
+	start := Position{Line: d.Span.Line - 1, Character: d.Span.Column - 1}
+	end := Position{Line: d.Span.EndLine - 1, Character: d.Span.EndColumn - 1}
+	if start.Line < 0 {
+		start, end = Position{}, Position{}
+	}
+	// Positions are extended to name at position.
+	if end == start {
+		if name, col := wordAt(doc.line(start.Line), start.Character); name != "" && col == start.Character {
+			end.Character += len(name)
+		} else {
+			end.Character++
+		}
+	}
+	return Diagnostic{
+		Range:    Range{Start: start, End: end},
+		Severity: severity,
+		Code:     d.Code,
+		Source:   "fract",
+		Message:  d.Message,
+	}
+}
+
+// definition returns location of define of name at position.
+func (doc *document) definition(pos Position) interface{} {
+	name, _ := wordAt(doc.line(pos.Line), pos.Character)
+	if name == "" {
+		return nil
+	}
+	ln := doc.index.lookup(name, pos.Line+1)
+	if ln == -1 {
+		return nil
+	}
+	return Location{URI: doc.uri, Range: doc.nameRange(ln-1, name)}
+}
+
+func (s *Server) hover(doc *document, pos Position) interface{} {
+	name, _ := wordAt(doc.line(pos.Line), pos.Character)
+	if name == "" {
+		return nil
+	}
+	text := ""
+	if ln := doc.index.lookup(name, pos.Line+1); ln != -1 {
+		for _, f := range doc.index.defs.Funcs {
+			if f.Name == name && f.Line == ln {
+				text = f.Signature()
+			}
+		}
+		for _, v := range doc.index.defs.Vars {
+			if v.Name == name && v.Line == ln {
+				text = defKind(v.Val.Type) + " " + name
+			}
+		}
+	} else if i := s.builtins.FuncIndexByName(name); i != -1 {
+		text = s.builtins.Funcs[i].Signature()
+	} else if i := s.builtins.VarIndexByName(name); i != -1 {
+		text = "const " + name
+	}
+	if text == "" {
+		return nil
+	}
+	return hover{Contents: markupContent{Kind: "markdown", Value: "```fract\n" + text + "\n```"}}
+}
+
+// defKind returns keyword of define by value type.
+func defKind(typ uint8) string {
+	switch typ {
+	case oop.StructDef:
+		return "struct"
+	case oop.ClassDef:
+		return "class"
+	case oop.Package:
+		return "package"
+	}
+	return "var"
+}
+
+func (s *Server) completion(doc *document, pos Position) []completionItem {
+	ln := doc.line(pos.Line)
+	if pos.Character > len(ln) {
+		pos.Character = len(ln)
+	}
+	_, start := wordAt(ln[:pos.Character], pos.Character)
+	// Methods of selected value.
+	if start > 0 && ln[start-1] == '.' {
+		return s.methods
+	}
+	items := []completionItem{}
+	seen := map[string]bool{}
+	add := func(item completionItem) {
+		if !seen[item.Label] {
+			seen[item.Label] = true
+			items = append(items, item)
+		}
+	}
+	line := pos.Line + 1
+	for _, f := range doc.index.defs.Funcs {
+		if doc.index.visible(f, line) {
+			add(completionItem{Label: f.Name, Kind: completionFunction, Detail: f.Signature()})
+		}
+	}
+	for _, v := range doc.index.defs.Vars {
+		if !doc.index.visible(v, line) {
+			continue
+		}
+		kind := completionVariable
+		switch v.Val.Type {
+		case oop.StructDef:
+			kind = completionStruct
+		case oop.ClassDef:
+			kind = completionClass
+		}
+		add(completionItem{Label: v.Name, Kind: kind, Detail: defKind(v.Val.Type) + " " + v.Name})
+	}
+	for _, f := range s.builtins.Funcs {
+		add(completionItem{Label: f.Name, Kind: completionFunction, Detail: f.Signature()})
+	}
+	for _, v := range s.builtins.Vars {
+		add(completionItem{Label: v.Name, Kind: completionVariable, Detail: "const " + v.Name})
+	}
+	return items
+}
@@ -0,0 +1,113 @@
+package lsp_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/fract-lang/fract/lsp"
+	"github.com/fract-lang/fract/parser"
+)
+
+// TestLanguageServer opens document with error and requests definition,
+// hover and completion of names in it, changed document is checked again.
+func TestLanguageServer(t *testing.T) {
+	const uri = "file:///x/main.fract"
+	const code = "package main\n\nfunc add(a, b) {\n    return a + b\n}\n\ntotal := add(1, 2)\nprintln(total)\nx := 'ok\n"
+	inr, inw := io.Pipe()
+	outr, outw := io.Pipe()
+	go lsp.NewServer(parser.NewRuntime("../stdlib"), inr, outw).Serve()
+	out := bufio.NewReader(outr)
+	// Requests are written by another goroutine because pipes are not buffered.
+	requests := make(chan []byte, 16)
+	defer close(requests)
+	go func() {
+		for data := range requests {
+			fmt.Fprintf(inw, "Content-Length: %d\r\n\r\n%s", len(data), data)
+		}
+	}()
+	id := 0
+	send := func(method string, params interface{}) {
+		msg := map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params}
+		if !strings.HasPrefix(method, "textDocument/did") {
+			id++
+			msg["id"] = id
+		}
+		data, _ := json.Marshal(msg)
+		requests <- data
+	}
+	// receive returns result of response or params of notification.
+	receive := func() json.RawMessage {
+		var length int
+		if _, err := fmt.Fscanf(out, "Content-Length: %d\r\n\r\n", &length); err != nil {
+			t.Fatal(err)
+		}
+		data := make([]byte, length)
+		io.ReadFull(out, data)
+		var msg struct {
+			Result, Params, Error json.RawMessage
+		}
+		json.Unmarshal(data, &msg)
+		if msg.Error != nil {
+			t.Fatalf("error response: %s", data)
+		} else if msg.Params != nil {
+			return msg.Params
+		}
+		return msg.Result
+	}
+	at := func(line, character int) map[string]interface{} {
+		return map[string]interface{}{
+			"textDocument": map[string]string{"uri": uri},
+			"position":     map[string]int{"line": line, "character": character},
+		}
+	}
+
+	send("initialize", map[string]interface{}{})
+	receive()
+	send("textDocument/didOpen", map[string]interface{}{"textDocument": map[string]string{"uri": uri, "text": code}})
+	diags := receive()
+	send("textDocument/definition", at(7, 10))
+	def := receive()
+	send("textDocument/hover", at(6, 10))
+	hover := receive()
+	for _, c := range []struct {
+		name string
+		got  json.RawMessage
+		want string
+	}{
+		{"diagnostics", diags, `{"uri":"` + uri + `","diagnostics":[{"range":{"start":{"line":8,"character":8},` +
+			`"end":{"line":8,"character":9}},"severity":1,"code":"SyntaxPanic","source":"fract","message":"Close quote is not found!"}]}`},
+		{"definition", def, `{"uri":"` + uri + `","range":{"start":{"line":6,"character":0},"end":{"line":6,"character":5}}}`},
+		{"hover", hover, `{"contents":{"kind":"markdown","value":"` + "```fract\\nfunc add(a, b)\\n```" + `"}}`},
+	} {
+		if string(c.got) != c.want {
+			t.Errorf("%s: got %s, want %s", c.name, c.got, c.want)
+		}
+	}
+	send("textDocument/completion", at(7, 10))
+	var items []struct{ Label, Detail string }
+	json.Unmarshal(receive(), &items)
+	details := map[string]string{}
+	for _, item := range items {
+		details[item.Label] = item.Detail
+	}
+	for label, detail := range map[string]string{"add": "func add(a, b)", "total": "var total", "println": "func println(...value=[])"} {
+		if details[label] != detail {
+			t.Errorf("completion %s: got %q, want %q", label, details[label], detail)
+		}
+	}
+	// Fixed document has no diagnostics.
+	send("textDocument/didChange", map[string]interface{}{
+		"textDocument":   map[string]interface{}{"uri": uri, "version": 2},
+		"contentChanges": []map[string]string{{"text": strings.Replace(code, "'ok", "'ok'", 1)}},
+	})
+	if got, want := string(receive()), `{"uri":"`+uri+`","diagnostics":[]}`; got != want {
+		t.Errorf("diagnostics of change: got %s, want %s", got, want)
+	}
+	send("shutdown", nil)
+	receive()
+	send("exit", nil)
+}
@@ -0,0 +1,240 @@
+package lsp
+
+import (
+	"github.com/fract-lang/fract/ast"
+	"github.com/fract-lang/fract/oop"
+	"github.com/fract-lang/fract/pkg/fract"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// Lines of scope.
+type scope struct {
+	start, end int
+}
+
+func (s scope) contains(line int) bool { return s.start <= line && line <= s.end }
+
+// Index of defines of code.
+// Defines of all scopes are included, so names are may be defined several times.
+type index struct {
+	defs   oop.DefMap
+	scopes map[interface{}]scope // Visible lines of defines.
+	closes map[[2]int]int        // Lines of close braces by positions of open braces.
+	scope  scope                 // Current scope.
+}
+
+// newIndex returns index of syntax tree of tokens.
+func newIndex(tree *ast.Block, tokens [][]obj.Token) *index {
+	x := &index{
+		scopes: map[interface{}]scope{},
+		closes: map[[2]int]int{},
+		scope:  scope{start: 1, end: int(^uint(0) >> 1)},
+	}
+	var opens []obj.Token
+	for _, tks := range tokens {
+		for _, tk := range tks {
+			if tk.Type != fract.Brace || (tk.Val != "{" && tk.Val != "}") {
+				continue
+			} else if tk.Val == "{" {
+				opens = append(opens, tk)
+			} else if len(opens) > 0 {
+				open := opens[len(opens)-1]
+				opens = opens[:len(opens)-1]
+				x.closes[[2]int{open.Line, open.Column}] = tk.Line
+			}
+		}
+	}
+	if tree != nil {
+		x.addDefs(tree.Stmts)
+	}
+	return x
+}
+
+// blockScope returns scope of block that opened by brace at position.
+func (x *index) blockScope(line, column int) scope {
+	if end, ok := x.closes[[2]int{line, column}]; ok {
+		return scope{start: line, end: end}
+	}
+	return scope{start: line, end: line}
+}
+
+func (x *index) addVar(tk obj.Token, typ uint8) {
+	if tk.Val == "" || tk.Val == "_" {
+		return
+	}
+	var v oop.VarDef = &oop.Var{Name: tk.Val, Line: tk.Line, Val: oop.Val{Type: typ}}
+	x.defs.Vars = append(x.defs.Vars, v)
+	x.scopes[v] = x.scope
+}
+
+// enter returns scope of block and sets it as current scope.
+func (x *index) enter(b *ast.Block) scope {
+	prev := x.scope
+	if b != nil {
+		x.scope = x.blockScope(b.Tk.Line, b.Tk.Column)
+	}
+	return prev
+}
+
+func (x *index) addBlock(b *ast.Block) {
+	if b != nil {
+		prev := x.enter(b)
+		x.addDefs(b.Stmts)
+		x.scope = prev
+	}
+}
+
+func (x *index) addFunc(s *ast.FuncDecl) {
+	fn := &oop.Fn{Name: s.Name.Val, Line: s.Name.Line, Block: s.Body}
+	x.defs.Funcs = append(x.defs.Funcs, fn)
+	x.scopes[fn] = x.scope
+	prev := x.enter(s.Body)
+	for _, param := range s.Params {
+		fn.Params = append(fn.Params, oop.Param{Name: param.Name.Val, Params: param.Params, Type: param.Type})
+		if param.Default != nil {
+			fn.DefaultParamCount++
+		}
+		x.addVar(param.Name, oop.None)
+	}
+	x.scope = prev
+	x.addBlock(s.Body)
+}
+
+func (x *index) addDefs(stmts []ast.Stmt) {
+	for _, stmt := range stmts {
+		switch s := stmt.(type) {
+		case *ast.VarDecl:
+			for _, spec := range s.Specs {
+				x.addVar(spec.Name, oop.None)
+			}
+		case *ast.ShortVarDecl:
+			for _, name := range s.Names {
+				x.addVar(name.Name, oop.None)
+			}
+		case *ast.FuncDecl:
+			x.addFunc(s)
+		case *ast.StructDecl:
+			x.addVar(s.Name, oop.StructDef)
+		case *ast.ClassDecl:
+			x.addVar(s.Name, oop.ClassDef)
+			// Members are visible in body of class.
+			prev := x.scope
+			x.scope = scope{start: s.Name.Line, end: s.Name.Line}
+			for pos, end := range x.closes {
+				if pos[0] == s.Name.Line && pos[1] > s.Name.Column {
+					x.scope.end = end
+					break
+				}
+			}
+			for _, decl := range s.Vars {
+				x.addDefs([]ast.Stmt{decl})
+			}
+			for _, decl := range s.Funcs {
+				x.addFunc(decl)
+			}
+			x.scope = prev
+		case *ast.Import:
+			if s.Alias.Val != "" {
+				x.addVar(s.Alias, oop.Package)
+			}
+		case *ast.If:
+			x.addBlock(s.Body)
+			if s.Else != nil {
+				x.addDefs([]ast.Stmt{s.Else})
+			}
+		case *ast.Loop:
+			prev := x.enter(s.Body)
+			x.addVar(s.Key, oop.None)
+			x.addVar(s.Elem, oop.None)
+			x.scope = prev
+			x.addBlock(s.Body)
+		case *ast.TryCatch:
+			x.addBlock(s.Try)
+			for _, c := range s.Catches {
+				prev := x.enter(c.Body)
+				x.addVar(c.Name, oop.StructIns)
+				x.scope = prev
+				x.addBlock(c.Body)
+			}
+			x.addBlock(s.Finally)
+		case *ast.Match:
+			for _, c := range s.Cases {
+				x.addBlock(c.Body)
+			}
+		case *ast.Select:
+			for _, c := range s.Cases {
+				prev := x.enter(c.Body)
+				x.addVar(c.Name, oop.None)
+				x.scope = prev
+				x.addBlock(c.Body)
+			}
+		case *ast.Block:
+			x.addBlock(s)
+		}
+	}
+}
+
+// visible reports define is visible at line.
+func (x *index) visible(def interface{}, line int) bool { return x.scopes[def].contains(line) }
+
+// lookup returns line of define of name that visible at line.
+// Nearest define before line is preferred, returns -1 if not defined.
+func (x *index) lookup(name string, line int) int {
+	found, foundScope := -1, scope{}
+	check := func(def interface{}, defLine int) {
+		if !x.visible(def, line) {
+			return
+		}
+		s := x.scopes[def]
+		// Inner scopes and later defines are shadow others.
+		if found == -1 || (defLine <= line && (found > line || s.start > foundScope.start ||
+			(s.start == foundScope.start && defLine > found))) {
+			found, foundScope = defLine, s
+		}
+	}
+	for _, f := range x.defs.Funcs {
+		if f.Name == name {
+			check(f, f.Line)
+		}
+	}
+	for _, v := range x.defs.Vars {
+		if v.Name == name {
+			check(v, v.Line)
+		}
+	}
+	return found
+}
+
+// isNameByte reports byte is part of name.
+func isNameByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+// wordAt returns name at column of line and start column of it.
+// Column is zero based.
+func wordAt(ln string, col int) (string, int) {
+	if col > len(ln) {
+		col = len(ln)
+	}
+	start, end := col, col
+	for start > 0 && isNameByte(ln[start-1]) {
+		start--
+	}
+	for end < len(ln) && isNameByte(ln[end]) {
+		end++
+	}
+	return ln[start:end], start
+}
+
+// nameColumn returns zero based column of name in line, returns 0 if not found.
+func nameColumn(ln, name string) int {
+	for i := 0; i+len(name) <= len(ln); i++ {
+		if ln[i:i+len(name)] != name {
+			continue
+		}
+		if (i == 0 || !isNameByte(ln[i-1])) && (i+len(name) == len(ln) || !isNameByte(ln[i+len(name)])) {
+			return i
+		}
+	}
+	return 0
+}
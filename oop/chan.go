@@ -0,0 +1,45 @@
+package oop
+
+import (
+	"github.com/fract-lang/fract/pkg/fract"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// Channel for communication of goroutines.
+type Channel struct {
+	C chan Val
+}
+
+// NewChannel returns channel by buffer size.
+// Channel is unbuffered if size is zero.
+func NewChannel(size int) *Channel { return &Channel{C: make(chan Val, size)} }
+
+// closedPanic throws panic for operations on closed channel.
+func closedPanic(tk obj.Token, msg string) {
+	if r := recover(); r != nil {
+		fract.Panic(tk, obj.ValuePanic, msg)
+	}
+}
+
+// Send value to channel, blocks until value is received
+// or buffered. Panics if channel is closed.
+func (c *Channel) Send(tk obj.Token, v Val) {
+	defer closedPanic(tk, "Send on closed channel!")
+	c.C <- v
+}
+
+// Recv returns received value of channel, blocks until value is sent.
+// Returns none and false if channel is closed and drained.
+func (c *Channel) Recv() (Val, bool) {
+	v, ok := <-c.C
+	if !ok {
+		return Val{Data: "none", Type: None}, false
+	}
+	return v, true
+}
+
+// Close channel. Panics if channel is already closed.
+func (c *Channel) Close(tk obj.Token) {
+	defer closedPanic(tk, "Channel is already closed!")
+	close(c.C)
+}
@@ -18,12 +18,21 @@ type Class struct {
 }
 
 func (c *Class) CallConstructor(model FuncCallModel) ClassInstance {
-	ins := ClassInstance{Name: c.Name, File: c.File, Defs: c.Defs}
-	this := &Var{Name: "this", Val: Val{Data: ins, Type: ClassIns, Mut: true}}
-	model.Func().Args = []VarDef{this}
-	for _, fn := range ins.Defs.Funcs {
-		fn.Args = append(fn.Args, this)
+	ins := ClassInstance{Name: c.Name, File: c.File}
+	this := &Var{Name: "this", Val: Val{Type: ClassIns, Mut: true}}
+	// Each instance has own fields and methods.
+	for _, v := range c.Defs.Vars {
+		val := *v.Val.Get("")
+		val.Mut, val.Const = v.Val.Mut, v.Val.Const
+		ins.Defs.Vars = append(ins.Defs.Vars, &Var{Name: v.Name, Line: v.Line, Val: val})
+	}
+	for _, fn := range c.Defs.Funcs {
+		cpy := *fn
+		cpy.Args = []VarDef{this}
+		ins.Defs.Funcs = append(ins.Defs.Funcs, &cpy)
 	}
+	this.Val.Data = ins
+	model.Func().Args = []VarDef{this}
 	if c.Constructor.Line != 0 { // Call custom constructor.
 		model.Call()
 	}
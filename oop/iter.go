@@ -0,0 +1,10 @@
+package oop
+
+// Iterator is lazy sequence of values.
+type Iterator interface {
+	// Next returns next value, returns false if values are finished.
+	Next() (Val, bool)
+	// Close releases iterator if values are not finished.
+	// Next returns false after close.
+	Close()
+}
@@ -0,0 +1,459 @@
+package oop
+
+// Conversions between Go values and Fract values.
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+
+	"github.com/fract-lang/fract/pkg/decimal"
+	"github.com/fract-lang/fract/pkg/fract"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// Builtin is signature of built-in functions.
+type Builtin = func(obj.Token, []VarDef) Val
+
+var (
+	valType     = reflect.TypeOf(Val{})
+	bigIntType  = reflect.TypeOf((*big.Int)(nil))
+	decimalType = reflect.TypeOf(decimal.Decimal{})
+	fnType      = reflect.TypeOf((*Fn)(nil))
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// TypeName returns name of value type.
+func TypeName(t uint8) string {
+	switch t {
+	case None:
+		return "none"
+	case Int:
+		return "int"
+	case Float:
+		return "float"
+	case String:
+		return "string"
+	case Bool:
+		return "bool"
+	case Func:
+		return "function"
+	case List:
+		return "list"
+	case Map:
+		return "map"
+	case Package:
+		return "package"
+	case StructDef:
+		return "struct"
+	case StructIns:
+		return "struct instance"
+	case ClassDef:
+		return "class"
+	case ClassIns:
+		return "class instance"
+	case BigInt:
+		return "bigint"
+	case Decimal:
+		return "decimal"
+	case Chan:
+		return "chan"
+	case Iter:
+		return "iterator"
+	}
+	return "unknown"
+}
+
+// ValOf returns Fract value of Go value.
+//
+// Booleans, integers, floats and strings are converted to primitive types,
+// *big.Int and decimal.Decimal to bigint and decimal, slices and arrays to
+// lists, maps to maps, structs to struct instances of exported fields and
+// functions to built-in functions. Nil values are none.
+func ValOf(v interface{}) (Val, error) {
+	if val, ok := v.(Val); ok {
+		return val, nil
+	}
+	return valOf(reflect.ValueOf(v))
+}
+
+func valOf(rv reflect.Value) (Val, error) {
+	if !rv.IsValid() {
+		return Val{Data: "none", Type: None}, nil
+	}
+	switch t := rv.Type(); t {
+	case valType:
+		return rv.Interface().(Val), nil
+	case bigIntType:
+		if rv.IsNil() {
+			return Val{Data: "none", Type: None}, nil
+		}
+		return Val{Data: new(big.Int).Set(rv.Interface().(*big.Int)), Type: BigInt}, nil
+	case decimalType:
+		d := rv.Interface().(decimal.Decimal)
+		if d.Unscaled == nil {
+			d.Unscaled = new(big.Int)
+		}
+		return Val{Data: d, Type: Decimal}, nil
+	case fnType:
+		if rv.IsNil() {
+			return Val{Data: "none", Type: None}, nil
+		}
+		return Val{Data: rv.Interface(), Type: Func}, nil
+	}
+	switch rv.Kind() {
+	case reflect.Bool:
+		return Val{Data: rv.Bool(), Type: Bool}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Val{Data: rv.Int(), Type: Int}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u := rv.Uint(); u > math.MaxInt64 {
+			return Val{Data: new(big.Int).SetUint64(u), Type: BigInt}, nil
+		}
+		return Val{Data: int64(rv.Uint()), Type: Int}, nil
+	case reflect.Float32, reflect.Float64:
+		return Val{Data: rv.Float(), Type: Float}, nil
+	case reflect.String:
+		return Val{Data: rv.String(), Type: String}, nil
+	case reflect.Slice, reflect.Array:
+		list := NewListModel()
+		for i := 0; i < rv.Len(); i++ {
+			elem, err := valOf(rv.Index(i))
+			if err != nil {
+				return Val{}, err
+			}
+			list.PushBack(elem)
+		}
+		return Val{Data: list, Type: List}, nil
+	case reflect.Map:
+		m := NewMapModel()
+		iter := rv.MapRange()
+		for iter.Next() {
+			key, err := valOf(iter.Key())
+			if err != nil {
+				return Val{}, err
+			}
+			val, err := valOf(iter.Value())
+			if err != nil {
+				return Val{}, err
+			}
+			m.Map[key] = val
+		}
+		return Val{Data: m, Type: Map}, nil
+	case reflect.Struct:
+		ins := StructInstance{Name: rv.Type().Name()}
+		for i := 0; i < rv.NumField(); i++ {
+			f := rv.Type().Field(i)
+			if f.PkgPath != "" { // Unexported.
+				continue
+			}
+			val, err := valOf(rv.Field(i))
+			if err != nil {
+				return Val{}, err
+			}
+			ins.Fields.Vars = append(ins.Fields.Vars, &Var{Name: f.Name, Val: val})
+		}
+		return Val{Data: ins, Type: StructIns}, nil
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return Val{Data: "none", Type: None}, nil
+		}
+		return valOf(rv.Elem())
+	case reflect.Func:
+		if rv.IsNil() {
+			return Val{Data: "none", Type: None}, nil
+		}
+		fn, err := NativeFn("", rv.Interface(), nil)
+		if err != nil {
+			return Val{}, err
+		}
+		return Val{Data: fn, Type: Func}, nil
+	}
+	return Val{}, fmt.Errorf("unsupported Go type: %s", rv.Type())
+}
+
+// Interface returns natural Go value of value.
+//
+// Ints are int64, floats are float64, lists are []interface{},
+// maps are map[interface{}]interface{}, struct instances are
+// map[string]interface{}, functions are *Fn and none is nil.
+// Values of other types are returned as is.
+func (v Val) Interface() interface{} {
+	switch v.Type {
+	case None:
+		return nil
+	case BigInt:
+		return new(big.Int).Set(v.Data.(*big.Int))
+	case List:
+		elems := v.Data.(*ListModel).Elems
+		list := make([]interface{}, len(elems))
+		for i, elem := range elems {
+			list[i] = elem.Interface()
+		}
+		return list
+	case Map:
+		m := map[interface{}]interface{}{}
+		for key, val := range v.Data.(MapModel).Map {
+			m[key.Interface()] = val.Interface()
+		}
+		return m
+	case StructIns:
+		m := map[string]interface{}{}
+		for _, f := range v.Data.(StructInstance).Fields.Vars {
+			m[f.Name] = f.Val.Interface()
+		}
+		return m
+	case Int, Float, String, Bool, Decimal, Func:
+		return v.Data
+	}
+	return v
+}
+
+// GoVal returns value converted to Go type.
+// Returns error if value is not convertible to type.
+func (v Val) GoVal(t reflect.Type) (reflect.Value, error) {
+	fail := func() (reflect.Value, error) {
+		return reflect.Value{}, fmt.Errorf("%s is cannot convert to %s", TypeName(v.Type), t)
+	}
+	switch t {
+	case valType:
+		return reflect.ValueOf(v), nil
+	case bigIntType:
+		switch v.Type {
+		case Int, BigInt:
+			return reflect.ValueOf(new(big.Int).Set(v.BigInt())), nil
+		case None:
+			return reflect.Zero(t), nil
+		}
+		return fail()
+	case decimalType:
+		if v.Type != Int && v.Type != BigInt && v.Type != Decimal {
+			return fail()
+		}
+		return reflect.ValueOf(v.Decimal()), nil
+	case fnType:
+		switch v.Type {
+		case Func:
+			return reflect.ValueOf(v.Data), nil
+		case None:
+			return reflect.Zero(t), nil
+		}
+		return fail()
+	}
+	rv := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Interface:
+		if v.Type == None {
+			return rv, nil
+		}
+		data := reflect.ValueOf(v.Interface())
+		if !data.Type().Implements(t) {
+			return fail()
+		}
+		rv.Set(data)
+	case reflect.Bool:
+		if v.Type != Bool {
+			return fail()
+		}
+		rv.SetBool(v.Data.(bool))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Type != Int && v.Type != BigInt {
+			return fail()
+		}
+		i := v.BigInt()
+		if !i.IsInt64() || rv.OverflowInt(i.Int64()) {
+			return reflect.Value{}, fmt.Errorf("%s is out of range of %s", i, t)
+		}
+		rv.SetInt(i.Int64())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Type != Int && v.Type != BigInt {
+			return fail()
+		}
+		i := v.BigInt()
+		if !i.IsUint64() || rv.OverflowUint(i.Uint64()) {
+			return reflect.Value{}, fmt.Errorf("%s is out of range of %s", i, t)
+		}
+		rv.SetUint(i.Uint64())
+	case reflect.Float32, reflect.Float64:
+		if !v.IsNum() {
+			return fail()
+		}
+		rv.SetFloat(v.Float64())
+	case reflect.String:
+		if v.Type != String {
+			return fail()
+		}
+		rv.SetString(v.Data.(string))
+	case reflect.Slice:
+		if v.Type == None {
+			return rv, nil
+		} else if v.Type != List {
+			return fail()
+		}
+		elems := v.Data.(*ListModel).Elems
+		rv.Set(reflect.MakeSlice(t, len(elems), len(elems)))
+		for i, elem := range elems {
+			ev, err := elem.GoVal(t.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			rv.Index(i).Set(ev)
+		}
+	case reflect.Array:
+		if v.Type != List || v.Len() != t.Len() {
+			return fail()
+		}
+		for i, elem := range v.Data.(*ListModel).Elems {
+			ev, err := elem.GoVal(t.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			rv.Index(i).Set(ev)
+		}
+	case reflect.Map:
+		if v.Type == None {
+			return rv, nil
+		} else if v.Type != Map {
+			return fail()
+		}
+		rv.Set(reflect.MakeMap(t))
+		for key, val := range v.Data.(MapModel).Map {
+			kv, err := key.GoVal(t.Key())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			vv, err := val.GoVal(t.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			rv.SetMapIndex(kv, vv)
+		}
+	case reflect.Struct:
+		if v.Type != StructIns {
+			return fail()
+		}
+		for _, f := range v.Data.(StructInstance).Fields.Vars {
+			field, ok := t.FieldByName(f.Name)
+			if !ok || field.PkgPath != "" {
+				continue
+			}
+			fv, err := f.Val.GoVal(field.Type)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("field %s: %w", f.Name, err)
+			}
+			rv.FieldByIndex(field.Index).Set(fv)
+		}
+	case reflect.Ptr:
+		if v.Type == None {
+			return rv, nil
+		}
+		ev, err := v.GoVal(t.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		rv.Set(reflect.New(t.Elem()))
+		rv.Elem().Set(ev)
+	default:
+		return fail()
+	}
+	return rv, nil
+}
+
+// NativeFn returns function that calls Go function.
+//
+// Arguments are converted to types of Go parameters and results are
+// converted to Fract values. Last result is thrown as panic if it is a
+// non-nil error. Function returns multiple values if it has more than
+// one result except the error.
+// Parameters are named arg1, arg2... if params is nil.
+// Parameter of variadic Go function is params parameter.
+func NativeFn(name string, fn interface{}, params []Param) (*Fn, error) {
+	if builtin, ok := fn.(Builtin); ok {
+		return newFn(name, builtin, params), nil
+	}
+	rv := reflect.ValueOf(fn)
+	if rv.Kind() != reflect.Func || rv.IsNil() {
+		return nil, fmt.Errorf("%s is not a function", name)
+	}
+	t := rv.Type()
+	if params == nil {
+		params = make([]Param, t.NumIn())
+		for i := range params {
+			params[i].Name = "arg" + strconv.Itoa(i+1)
+		}
+	} else if len(params) != t.NumIn() {
+		return nil, fmt.Errorf("%s: %d parameters are given for %d Go parameters", name, len(params), t.NumIn())
+	}
+	params = append([]Param(nil), params...)
+	for i, param := range params {
+		if param.DefaultVal.Data != nil {
+			if _, err := param.DefaultVal.GoVal(t.In(i)); err != nil {
+				return nil, fmt.Errorf("%s: default value of %s: %w", name, param.Name, err)
+			}
+		}
+	}
+	if t.IsVariadic() {
+		last := &params[len(params)-1]
+		last.Params = true
+		if last.DefaultVal.Data == nil {
+			last.DefaultVal = Val{Data: NewListModel(), Type: List}
+		}
+	}
+	returnsErr := t.NumOut() > 0 && t.Out(t.NumOut()-1) == errorType
+	return newFn(name, func(tk obj.Token, args []VarDef) Val {
+		in := make([]reflect.Value, 0, len(args))
+		for i, arg := range args {
+			pt := t.In(i)
+			if t.IsVariadic() && i == len(args)-1 {
+				for _, elem := range arg.Val.Data.(*ListModel).Elems {
+					in = append(in, goArg(tk, params[i].Name, elem, pt.Elem()))
+				}
+				break
+			}
+			in = append(in, goArg(tk, params[i].Name, arg.Val, pt))
+		}
+		out := rv.Call(in)
+		if returnsErr {
+			if err := out[len(out)-1]; !err.IsNil() {
+				fract.Panic(tk, obj.PlainPanic, err.Interface().(error).Error())
+			}
+			out = out[:len(out)-1]
+		}
+		vals := make([]Val, len(out))
+		for i, result := range out {
+			val, err := valOf(result)
+			if err != nil {
+				fract.Panic(tk, obj.ValuePanic, err.Error())
+			}
+			vals[i] = val
+		}
+		switch len(vals) {
+		case 0:
+			return Val{}
+		case 1:
+			return vals[0]
+		}
+		return Val{Data: NewListModel(vals...), Type: List, Tag: "function_multiple_returns"}
+	}, params), nil
+}
+
+// goArg returns argument converted to Go type.
+func goArg(tk obj.Token, name string, val Val, t reflect.Type) reflect.Value {
+	rv, err := val.GoVal(t)
+	if err != nil {
+		fract.Panic(tk, obj.ValuePanic, "Invalid argument of '"+name+"': "+err.Error())
+	}
+	return rv
+}
+
+func newFn(name string, src Builtin, params []Param) *Fn {
+	fn := &Fn{Name: name, Src: src, Params: params}
+	for _, param := range params {
+		if param.DefaultVal.Data != nil {
+			fn.DefaultParamCount++
+		}
+	}
+	return fn
+}
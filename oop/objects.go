@@ -1,6 +1,11 @@
 package oop
 
-import "github.com/fract-lang/fract/pkg/obj"
+import (
+	"strings"
+
+	"github.com/fract-lang/fract/ast"
+	"github.com/fract-lang/fract/bytecode"
+)
 
 // Var instance.
 type Var struct {
@@ -13,11 +18,30 @@ type Var struct {
 type Fn struct {
 	Name              string
 	Src               interface{}
-	Line              int // Line of define.
-	Tokens            [][]obj.Token
+	Line              int            // Line of define.
+	Block             *ast.Block     // Nil if built-in or compiled function.
+	Code              *bytecode.Func // Nil if not compiled function.
 	Params            []Param
 	Args              []VarDef // Default vars.
 	DefaultParamCount int
+	Scope             *Scope // Captured defines of closure, nil if not closure.
+	Generator         bool   // Calls are returns iterator instead of process.
+}
+
+// Scope of closure.
+type Scope struct {
+	Defs  DefMap  // Captured local defines.
+	Outer *DefMap // Defines of enclosing scope, shared by closures of scope.
+	FnLen int     // Count of functions of enclosing scope at capture.
+}
+
+// Funcs returns captured functions and functions that declared to enclosing scope after capture.
+func (s *Scope) Funcs() []*Fn {
+	funcs := s.Defs.Funcs[:len(s.Defs.Funcs):len(s.Defs.Funcs)]
+	if len(s.Outer.Funcs) > s.FnLen {
+		funcs = append(funcs, s.Outer.Funcs[s.FnLen:]...)
+	}
+	return funcs
 }
 
 // Param instance.
@@ -27,3 +51,29 @@ type Param struct {
 	Params     bool
 	Type       string
 }
+
+// Signature returns declaration text of function.
+func (f *Fn) Signature() string {
+	params := make([]string, len(f.Params))
+	for i, p := range f.Params {
+		param := p.Name
+		if p.Type != "" {
+			param = p.Type + " " + param
+		}
+		if p.Params {
+			param = "..." + param
+		}
+		if i >= len(f.Params)-f.DefaultParamCount {
+			switch {
+			case p.DefaultVal.Type == String:
+				param += "='" + p.DefaultVal.String() + "'"
+			case p.DefaultVal.Data != nil && p.DefaultVal.Data != "":
+				param += "=" + p.DefaultVal.String()
+			default:
+				param += "=..."
+			}
+		}
+		params[i] = param
+	}
+	return "func " + f.Name + "(" + strings.Join(params, ", ") + ")"
+}
@@ -27,10 +27,10 @@ func NewStringModel(val string) StringModel {
 		{Name: "sub", Src: str.subF, Params: []Param{{Name: "start"}, {Name: "len"}}},
 		{Name: "index", Src: str.indexF, DefaultParamCount: 1, Params: []Param{{Name: "sub"}}},
 		{Name: "indexLast", Src: str.indexLastF, DefaultParamCount: 1, Params: []Param{{Name: "sub"}}},
-		{Name: "split", Src: str.splitF, DefaultParamCount: 1, Params: []Param{{Name: "sep"}, {Name: "count", DefaultVal: Val{Data: -1., Type: Int}}}},
+		{Name: "split", Src: str.splitF, DefaultParamCount: 1, Params: []Param{{Name: "sep"}, {Name: "count", DefaultVal: Val{Data: int64(-1), Type: Int}}}},
 		{Name: "hasPrefix", Src: str.hasPrefixF, Params: []Param{{Name: "sub"}}},
 		{Name: "hasSuffix", Src: str.hasSuffixF, Params: []Param{{Name: "sub"}}},
-		{Name: "replace", Src: str.replaceF, DefaultParamCount: 1, Params: []Param{{Name: "old"}, {Name: "new"}, {Name: "count", DefaultVal: Val{Data: 1., Type: Int}}}},
+		{Name: "replace", Src: str.replaceF, DefaultParamCount: 1, Params: []Param{{Name: "old"}, {Name: "new"}, {Name: "count", DefaultVal: Val{Data: int64(1), Type: Int}}}},
 		{Name: "replaceAll", Src: str.replaceAllF, Params: []Param{{Name: "old"}, {Name: "new"}}},
 	}
 	return str
@@ -83,11 +83,11 @@ func (s *StringModel) subF(tk obj.Token, args []VarDef) Val {
 	if lenArg.Type != Int {
 		fract.Panic(tk, obj.ValuePanic, "Length must be integer!")
 	}
-	index := int(startArg.Data.(float64))
+	index := int(startArg.Data.(int64))
 	if index < 0 || index > len(s.Value) {
 		fract.Panic(tk, obj.OutOfRangePanic, "Out of range!")
 	}
-	length := int(lenArg.Data.(float64))
+	length := int(lenArg.Data.(int64))
 	if length < 0 {
 		return Val{Data: "", Type: String}
 	} else if index+length > len(s.Value) {
@@ -101,7 +101,7 @@ func (s *StringModel) indexF(tk obj.Token, args []VarDef) Val {
 	if sub.Type != String {
 		fract.Panic(tk, obj.OutOfRangePanic, "Value is not string!")
 	}
-	return Val{Data: float64(strings.Index(s.Value, sub.String())), Type: Int}
+	return Val{Data: int64(strings.Index(s.Value, sub.String())), Type: Int}
 }
 
 func (s *StringModel) indexLastF(tk obj.Token, args []VarDef) Val {
@@ -109,7 +109,7 @@ func (s *StringModel) indexLastF(tk obj.Token, args []VarDef) Val {
 	if sub.Type != String {
 		fract.Panic(tk, obj.OutOfRangePanic, "Value is not string!")
 	}
-	return Val{Data: float64(strings.LastIndex(s.Value, sub.String())), Type: Int}
+	return Val{Data: int64(strings.LastIndex(s.Value, sub.String())), Type: Int}
 }
 
 func (s *StringModel) splitF(tk obj.Token, args []VarDef) Val {
@@ -121,7 +121,7 @@ func (s *StringModel) splitF(tk obj.Token, args []VarDef) Val {
 	if countArg.Type != Int {
 		fract.Panic(tk, obj.ValuePanic, "Count must be integer!")
 	}
-	count := int(countArg.Data.(float64))
+	count := int(countArg.Data.(int64))
 	list := NewListModel()
 	parts := strings.SplitN(s.Value, sep.String(), count)
 	list.Elems = make(ListType, len(parts))
@@ -162,7 +162,7 @@ func (s *StringModel) replaceF(tk obj.Token, args []VarDef) Val {
 	if countArg.Data == "" {
 		count = len(s.Value) - 1
 	} else {
-		count = int(countArg.Data.(float64))
+		count = int(countArg.Data.(int64))
 	}
 	old := args[0].Val
 	if old.Type != String {
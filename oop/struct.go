@@ -18,8 +18,18 @@ func (s *Struct) CallConstructor(args []VarDef) StructInstance {
 	return ins
 }
 
+// IsInstance returns true if instance is based on struct, returns false if not.
+// Builtin files are loaded for each importer, so they are compared by path.
+func (s *Struct) IsInstance(ins StructInstance) bool {
+	if ins.Name != s.Name || ins.File == nil {
+		return false
+	}
+	return ins.File == s.Lex.File || ins.File.Builtin && ins.File.Path == s.Lex.File.Path
+}
+
 type StructInstance struct {
 	File   *obj.File
 	Name   string // Name of based struct.
 	Fields DefMap
+	Panic  *obj.Panic // Caught panic of error, nil if not caught by catch block.
 }
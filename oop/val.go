@@ -2,9 +2,13 @@ package oop
 
 import (
 	"fmt"
+	"io"
+	"math"
+	"math/big"
 	"reflect"
 	"strings"
 
+	"github.com/fract-lang/fract/pkg/decimal"
 	"github.com/fract-lang/fract/pkg/str"
 )
 
@@ -22,6 +26,10 @@ const (
 	StructIns uint8 = 10
 	ClassDef  uint8 = 11
 	ClassIns  uint8 = 12
+	BigInt    uint8 = 13
+	Decimal   uint8 = 14
+	Chan      uint8 = 15
+	Iter      uint8 = 16
 )
 
 // Val instance.
@@ -82,6 +90,10 @@ func (v Val) String() string {
 		return "object.struct"
 	case ClassDef:
 		return "object.class"
+	case Chan:
+		return "object.chan"
+	case Iter:
+		return "object.iterator"
 	case List:
 		return fmt.Sprint(v.Data.(*ListModel).Elems)
 	case Map:
@@ -105,7 +117,7 @@ func (v Val) String() string {
 		return "object.classins"
 	case None:
 		return "none"
-	case Int, Float:
+	case Int, Float, BigInt, Decimal:
 		return fmt.Sprint(v.Data)
 	case Bool:
 		if v.Data == true {
@@ -120,11 +132,11 @@ func (v Val) String() string {
 	}
 }
 
-func (v Val) Print() bool {
+func (v Val) Print(w io.Writer) bool {
 	if v.Data == nil {
 		return false
 	}
-	fmt.Print(v.String())
+	fmt.Fprint(w, v.String())
 	return true
 }
 
@@ -147,13 +159,122 @@ func (v Val) Len() int {
 		return v.Data.(*ListModel).Len
 	case Map:
 		return len(v.Data.(MapModel).Map)
+	case Chan:
+		return len(v.Data.(*Channel).C)
 	}
 	return -1
 }
 
+// IsNum returns true if value is numeric, returns false if not.
+func (v Val) IsNum() bool {
+	switch v.Type {
+	case Int, Float, BigInt, Decimal:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsBig returns true if value is arbitrary-precision numeric, returns false if not.
+func (v Val) IsBig() bool { return v.Type == BigInt || v.Type == Decimal }
+
+// BigInt returns integer value as big integer.
+//! Result is must be not modified.
+func (v Val) BigInt() *big.Int {
+	switch t := v.Data.(type) {
+	case int64:
+		return big.NewInt(t)
+	case *big.Int:
+		return t
+	case decimal.Decimal:
+		return t.Int()
+	}
+	return new(big.Int)
+}
+
+// Decimal returns exact numeric value as decimal.
+func (v Val) Decimal() decimal.Decimal {
+	if d, ok := v.Data.(decimal.Decimal); ok {
+		return d
+	}
+	return decimal.Decimal{Unscaled: v.BigInt(), Scale: 0}
+}
+
+// Float64 returns numeric value as float64.
+func (v Val) Float64() float64 {
+	switch t := v.Data.(type) {
+	case int64:
+		return float64(t)
+	case float64:
+		return t
+	case *big.Int:
+		f, _ := new(big.Float).SetInt(t).Float64()
+		return f
+	case decimal.Decimal:
+		return t.Float64()
+	case bool:
+		if t {
+			return 1
+		}
+	}
+	return 0
+}
+
+// compareNum returns -1 if v is less, 1 if v is greater and 0 if values are equal.
+// Returns 2 if values are not ordered(NaN).
+func (v Val) compareNum(val Val) int {
+	if v.Type == Int && val.Type == Int {
+		left, right := v.Data.(int64), val.Data.(int64)
+		switch {
+		case left < right:
+			return -1
+		case left > right:
+			return 1
+		}
+		return 0
+	}
+	if (v.IsBig() || val.IsBig()) && v.IsNum() && val.IsNum() {
+		if left, right := v.rat(), val.rat(); left != nil && right != nil {
+			return left.Cmp(right)
+		}
+	}
+	var left, right float64
+	if v.IsNum() && val.IsNum() {
+		left, right = v.Float64(), val.Float64()
+	} else {
+		left, right = str.Conv(v.String()), str.Conv(val.String())
+	}
+	switch {
+	case left < right:
+		return -1
+	case left > right:
+		return 1
+	case left == right:
+		return 0
+	}
+	return 2
+}
+
+// rat returns exact rational value of numeric, returns nil if not exist(NaN and infinity).
+func (v Val) rat() *big.Rat {
+	switch v.Type {
+	case Float:
+		f := v.Data.(float64)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil
+		}
+		return new(big.Rat).SetFloat64(f)
+	case Decimal:
+		return v.Data.(decimal.Decimal).Rat()
+	}
+	return new(big.Rat).SetInt(v.BigInt())
+}
+
 func (v Val) Equals(val Val) bool {
-	if v.Type == val.Type {
+	if v.Type == val.Type && !v.IsBig() {
 		return v.Data == val.Data
+	} else if v.IsNum() && val.IsNum() {
+		return v.compareNum(val) == 0
 	}
 	return reflect.DeepEqual(v.Data, val.Data)
 }
@@ -163,17 +284,31 @@ func (v Val) NotEquals(val Val) bool {
 }
 
 func (v Val) Greater(val Val) bool {
-	return (v.Type == String && v.String() > val.String()) || (v.Type != String && str.Conv(v.String()) > str.Conv(val.String()))
+	if v.Type == String {
+		return v.String() > val.String()
+	}
+	return v.compareNum(val) == 1
 }
 
 func (v Val) Less(val Val) bool {
-	return (v.Type == String && v.String() < val.String()) || (v.Type != String && str.Conv(v.String()) < str.Conv(val.String()))
+	if v.Type == String {
+		return v.String() < val.String()
+	}
+	return v.compareNum(val) == -1
 }
 
 func (v Val) GreaterEquals(val Val) bool {
-	return (v.Type == String && v.String() >= val.String()) || (v.Type != String && str.Conv(v.String()) >= str.Conv(val.String()))
+	if v.Type == String {
+		return v.String() >= val.String()
+	}
+	c := v.compareNum(val)
+	return c == 1 || c == 0
 }
 
 func (v Val) LessEquals(val Val) bool {
-	return (v.Type == String && v.String() <= val.String()) || (v.Type != String && str.Conv(v.String()) <= str.Conv(val.String()))
+	if v.Type == String {
+		return v.String() <= val.String()
+	}
+	c := v.compareNum(val)
+	return c == -1 || c == 0
 }
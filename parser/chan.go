@@ -0,0 +1,95 @@
+package parser
+
+import (
+	"reflect"
+
+	"github.com/fract-lang/fract/ast"
+	"github.com/fract-lang/fract/oop"
+	"github.com/fract-lang/fract/pkg/fract"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// channel returns channel of value, panics if value is not channel.
+func channel(val oop.Val, tk obj.Token) *oop.Channel {
+	if val.Type != oop.Chan {
+		fract.IPanic(tk, obj.ValuePanic, "Value is not channel!")
+	}
+	return val.Data.(*oop.Channel)
+}
+
+// receive returns received value of channel.
+func receive(val oop.Val, tk obj.Token) oop.Val {
+	v, _ := channel(val, tk).Recv()
+	return v
+}
+
+// Channel operation of select case.
+type commCase struct {
+	ch   *oop.Channel // Nil if default case.
+	send bool
+	val  oop.Val // Value to send.
+}
+
+// selectCase blocks until one of channel operations can proceed and
+// returns index of selected case and received value.
+// Default case is selected if no other case is ready.
+func selectCase(cases []commCase, tk obj.Token) (int, oop.Val) {
+	defer func() {
+		if r := recover(); r != nil {
+			fract.Panic(tk, obj.ValuePanic, "Send on closed channel!")
+		}
+	}()
+	scases := make([]reflect.SelectCase, len(cases))
+	for i, c := range cases {
+		switch {
+		case c.ch == nil:
+			scases[i] = reflect.SelectCase{Dir: reflect.SelectDefault}
+		case c.send:
+			scases[i] = reflect.SelectCase{Dir: reflect.SelectSend, Chan: reflect.ValueOf(c.ch.C), Send: reflect.ValueOf(c.val)}
+		default:
+			scases[i] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(c.ch.C)}
+		}
+	}
+	i, v, ok := reflect.Select(scases)
+	if !ok || cases[i].send || cases[i].ch == nil {
+		return i, oop.Val{Data: "none", Type: oop.None}
+	}
+	return i, v.Interface().(oop.Val)
+}
+
+func (p *Parser) processSend(s *ast.Send) {
+	ch := channel(*p.processVal(s.Ch), s.Ch.Token())
+	val := *p.processVal(s.Val)
+	if val.Data == nil {
+		fract.IPanic(s.Val.Token(), obj.ValuePanic, "Invalid value!")
+	}
+	ch.Send(s.Tk, val)
+}
+
+func (p *Parser) processSelect(s *ast.Select) uint8 {
+	cases := make([]commCase, len(s.Cases))
+	for i, c := range s.Cases {
+		switch t := c.Comm.(type) {
+		case *ast.Send:
+			cases[i].ch = channel(*p.processVal(t.Ch), t.Ch.Token())
+			cases[i].send = true
+			cases[i].val = *p.processVal(t.Val)
+			if cases[i].val.Data == nil {
+				fract.IPanic(t.Val.Token(), obj.ValuePanic, "Invalid value!")
+			}
+		case *ast.ExprStmt:
+			recv := t.X.(*ast.Receive)
+			cases[i].ch = channel(*p.processVal(recv.Ch), recv.Ch.Token())
+		}
+	}
+	i, val := selectCase(cases, s.Tk)
+	c := s.Cases[i]
+	varLen := len(p.defs.Vars)
+	if c.Name.Val != "" {
+		p.checkDefined(&p.defs, c.Name)
+		p.defs.Vars = append(p.defs.Vars, &oop.Var{Name: c.Name.Val, Line: c.Name.Line, Val: val})
+	}
+	keywordState := p.processBlock(c.Body)
+	p.defs.Vars = p.defs.Vars[:varLen]
+	return keywordState
+}
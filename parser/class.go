@@ -1,32 +1,26 @@
 package parser
 
 import (
-	"fmt"
-
+	"github.com/fract-lang/fract/ast"
 	"github.com/fract-lang/fract/oop"
 	"github.com/fract-lang/fract/pkg/fract"
 	"github.com/fract-lang/fract/pkg/obj"
 )
 
-// buildClass from tokens.
-func (p *Parser) buildClass(name string, tokens []obj.Token) *oop.Val {
-	block := p.getBlock(tokens)
-	class := oop.Class{Name: name, File: p.Lex.File}
-	for _, tokens := range block {
-		switch tokens[0].Type {
-		case fract.Var:
-			p.fvardec(&class.Defs, tokens)
-		case fract.Func:
-			p.ffuncdec(&class.Defs, tokens)
-			if f := class.Defs.Funcs[len(class.Defs.Funcs)-1]; f.Name == class.Name {
-				if class.Constructor != nil {
-					fract.IPanic(tokens[0], obj.NamePanic, "Constructor is already defined!")
-				}
-				class.Constructor = f
-				class.Defs.Funcs = class.Defs.Funcs[:len(class.Defs.Funcs)-1]
+// buildClass from declaration.
+func (p *Parser) buildClass(s *ast.ClassDecl) *oop.Val {
+	class := oop.Class{Name: s.Name.Val, File: p.Lex.File}
+	for _, decl := range s.Vars {
+		p.fvardec(&class.Defs, decl)
+	}
+	for _, decl := range s.Funcs {
+		p.ffuncdec(&class.Defs, decl)
+		if f := class.Defs.Funcs[len(class.Defs.Funcs)-1]; f.Name == class.Name {
+			if class.Constructor != nil {
+				fract.IPanic(decl.Tk, obj.NamePanic, "Constructor is already defined!")
 			}
-		default:
-			fract.IPanic(tokens[0], obj.SyntaxPanic, "Invalid syntax!")
+			class.Constructor = f
+			class.Defs.Funcs = class.Defs.Funcs[:len(class.Defs.Funcs)-1]
 		}
 	}
 	if class.Constructor == nil { // Constructor is not given.
@@ -36,26 +30,13 @@ func (p *Parser) buildClass(name string, tokens []obj.Token) *oop.Val {
 }
 
 // Process class declaration.
-func (p *Parser) classdec(tokens []obj.Token) {
-	tokensLen := len(tokens)
-	if tokensLen < 2 {
-		fract.IPanic(tokens[0], obj.SyntaxPanic, "Invalid syntax!")
-	}
-	nameTk := tokens[1]
-	if nameTk.Type != fract.Name {
-		fract.IPanic(tokens[1], obj.SyntaxPanic, "Name is not valid!")
-	}
-	if line := p.defLineByName(tokens[1].Val); line != -1 {
-		fract.IPanic(tokens[1], obj.NamePanic, "\""+tokens[1].Val+"\" is already defined at line: "+fmt.Sprint(line))
-	}
-	classVal := *p.buildClass(nameTk.Val, tokens[2:])
+func (p *Parser) classdec(s *ast.ClassDecl) {
+	p.checkDefined(&p.defs, s.Name)
+	classVal := *p.buildClass(s)
 	classVal.Const = true
-	if p.funcTempVars != -1 {
-		p.funcTempVars++
-	}
 	p.defs.Vars = append(p.defs.Vars, &oop.Var{
-		Name: nameTk.Val,
-		Line: tokens[0].Line,
+		Name: s.Name.Val,
+		Line: s.Tk.Line,
 		Val:  classVal,
 	})
 }
@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"github.com/fract-lang/fract/ast"
+	"github.com/fract-lang/fract/oop"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// Debugger is notified by interpreting of main goroutine.
+// Interpreting is paused until methods return.
+type Debugger interface {
+	// Stmt is called before statement.
+	Stmt(s *State)
+	// Panic is called by each statement that left by panic, innermost first.
+	Panic(s *State, cp obj.Panic)
+}
+
+// State of interpreting that given to debugger.
+// State is valid until method of debugger returns.
+type State struct {
+	rt *Runtime
+	p  *Parser
+}
+
+// Frame is function in process.
+type Frame struct {
+	Func    string // Name of function, "<main>" if not function.
+	File    string
+	Line    int
+	Column  int
+	Locals  []oop.VarDef // Parameters and variables of function, nil if not function.
+	Globals []oop.VarDef // Variables of source file of function.
+}
+
+// Stmt returns statement in process.
+func (s *State) Stmt() ast.Stmt { return s.p.stmt }
+
+// Depth returns count of frames.
+func (s *State) Depth() int { return len(s.rt.frames) }
+
+// Frames returns frames in process, innermost is first.
+func (s *State) Frames() []Frame {
+	var frames []Frame
+	for i := len(s.rt.frames) - 1; i >= 0; i-- {
+		p := s.rt.frames[i]
+		if p.stmt == nil { // Imports.
+			continue
+		}
+		tk := p.stmt.Token()
+		frame := Frame{Func: "<main>", Line: tk.Line, Column: tk.Column}
+		if tk.File != nil {
+			frame.File = tk.File.Path
+		}
+		src := p
+		if p.fn != nil {
+			frame.Func = p.fn.Name
+			src = s.rt.source(p.fn.Src.(*Parser))
+			globals := make(map[*oop.Var]bool, len(src.defs.Vars))
+			for _, v := range src.defs.Vars {
+				globals[v] = true
+			}
+			frame.Locals = []oop.VarDef{}
+			for _, v := range p.defs.Vars {
+				if !globals[v] {
+					frame.Locals = append(frame.Locals, v)
+				}
+			}
+		}
+		for _, v := range src.defs.Vars {
+			// Variables of host are not have a line.
+			if v.Line != 0 {
+				frame.Globals = append(frame.Globals, v)
+			}
+		}
+		frames = append(frames, frame)
+	}
+	return frames
+}
+
+// debugStmt notifies debugger for statement.
+func (p *Parser) debugStmt(stmt ast.Stmt) {
+	switch stmt.(type) {
+	case *ast.Block, *ast.Pragma:
+		return
+	}
+	p.stmt = stmt
+	p.rt.Debugger.Stmt(&State{rt: p.rt, p: p})
+}
+
+// debugPanic notifies debugger if statement is left by panic.
+func (p *Parser) debugPanic() {
+	if r := recover(); r != nil {
+		if cp, ok := r.(obj.Panic); ok && p.stmt != nil {
+			p.rt.Debugger.Panic(&State{rt: p.rt, p: p}, cp)
+		}
+		panic(r)
+	}
+}
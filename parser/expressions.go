@@ -1,12 +1,13 @@
 package parser
 
 import (
-	"fmt"
 	"math"
 	"math/big"
 	"strings"
 
+	"github.com/fract-lang/fract/ast"
 	"github.com/fract-lang/fract/oop"
+	"github.com/fract-lang/fract/pkg/decimal"
 	"github.com/fract-lang/fract/pkg/fract"
 	"github.com/fract-lang/fract/pkg/obj"
 )
@@ -44,9 +45,11 @@ func compareValues(operator string, left, right oop.Val) bool {
 	return true
 }
 
-func compare(left, right oop.Val, operator obj.Token) bool {
+func (p *Parser) compare(left, right oop.Val, operator obj.Token) bool {
 	if operator.Val == "in" {
-		if !right.IsEnum() {
+		if right.Type == oop.ClassIns || right.Type == oop.Iter {
+			return p.rt.contains(right, left, operator)
+		} else if !right.IsEnum() {
 			fract.IPanic(operator, obj.ValuePanic, "Value is should be enumerable!")
 		}
 		switch right.Type {
@@ -86,58 +89,13 @@ func compare(left, right oop.Val, operator obj.Token) bool {
 	return compareValues(operator.Val, left, right)
 }
 
-func (p *Parser) prococessCondition(tokens []obj.Token) bool {
-	trueVal := oop.Val{Data: true, Type: oop.Bool}
-	// Process condition.
-	orParts := conditionalProcesses(tokens, "||")
-	for _, or := range orParts {
-		// Decompose and conditions.
-		andParts := conditionalProcesses(or, "&&")
-		// Is and long statement?
-		if len(andParts) > 1 {
-			for _, and := range andParts {
-				index, operator := findConditionOperator(and)
-				// Operator is not found?
-				if index == -1 {
-					operator.Val = "=="
-					return compare(*p.processValTokens(and), trueVal, operator)
-				}
-				// Operator is first or last?
-				if index == 0 {
-					fract.IPanic(and[0], obj.SyntaxPanic, "Comparison values are missing!")
-				} else if index == len(and)-1 {
-					fract.IPanic(and[len(and)-1], obj.SyntaxPanic, "Comparison values are missing!")
-				}
-				if !compare(*p.processValTokens(and[:index]), *p.processValTokens(and[index+1:]), operator) {
-					return false
-				}
-			}
-			return true
-		}
-		index, operator := findConditionOperator(or)
-		// Operator is not found?
-		if index == -1 {
-			operator.Val = "=="
-			if compare(*p.processValTokens(or), trueVal, operator) {
-				return true
-			}
-			continue
-		}
-		// Operator is first or last?
-		if index == 0 {
-			fract.IPanic(or[0], obj.SyntaxPanic, "Comparison values are missing!")
-		} else if index == len(or)-1 {
-			fract.IPanic(or[len(or)-1], obj.SyntaxPanic, "Comparison values are missing!")
-		}
-		if compare(*p.processValTokens(or[:index]), *p.processValTokens(or[index+1:]), operator) {
-			return true
-		}
-	}
-	return false
+// processCondition returns true if value of condition is true, returns false if not.
+func (p *Parser) processCondition(e ast.Expr) bool {
+	return compareValues("==", *p.processVal(e), oop.Val{Data: true, Type: oop.Bool})
 }
 
-// Returns string arithmetic compatible data.
-func arithmetic(tk obj.Token, val oop.Val) float64 {
+// Returns arithmetic compatible numeric value.
+func arithmetic(tk obj.Token, val oop.Val) oop.Val {
 	switch val.Type {
 	case oop.Func,
 		oop.Package,
@@ -150,26 +108,26 @@ func arithmetic(tk obj.Token, val oop.Val) float64 {
 		fract.IPanic(tk, obj.ArithmeticPanic, "\"object.map\" is not compatible with arithmetic processes!")
 	case oop.StructIns:
 		fract.IPanic(tk, obj.ArithmeticPanic, "\"object.structins\" is not compatible with arithmetic processes!")
+	case oop.String:
+		fract.IPanic(tk, obj.ArithmeticPanic, "\"object.string\" is not compatible with arithmetic processes!")
 	case oop.Bool:
 		if val.Data.(bool) {
-			return 1
+			return oop.Val{Data: int64(1), Type: oop.Int}
 		}
-		return 0
+		return oop.Val{Data: int64(0), Type: oop.Int}
 	}
-	return val.Data.(float64)
+	return val
 }
 
 // arithmeticProcess instance for solver.
 type arithmeticProcess struct {
-	left     []obj.Token
 	leftVal  oop.Val
-	right    []obj.Token
 	rightVal oop.Val
 	operator obj.Token
 }
 
 func (p arithmeticProcess) solve() oop.Val {
-	val := oop.Val{Data: "0", Type: oop.Int}
+	val := oop.Val{Data: int64(0), Type: oop.Int}
 	leftLen := p.leftVal.Len()
 	rightLen := p.rightVal.Len()
 	// String?
@@ -197,7 +155,7 @@ func (p arithmeticProcess) solve() oop.Val {
 			return val
 		}
 		if leftLen != rightLen && leftLen != 1 && rightLen != 1 {
-			fract.IPanic(p.right[0], obj.ArithmeticPanic, "List element count is not one or equals to first list!")
+			fract.IPanic(p.operator, obj.ArithmeticPanic, "List element count is not one or equals to first list!")
 		}
 		if leftLen == 1 || rightLen == 1 {
 			left, right := p.leftVal, p.rightVal
@@ -207,21 +165,16 @@ func (p arithmeticProcess) solve() oop.Val {
 			arith := arithmetic(p.operator, left.Data.(*oop.ListModel).Elems[0])
 			for i, elem := range right.Data.(*oop.ListModel).Elems {
 				if elem.Type == oop.List {
-					right.Data.(*oop.ListModel).Elems[i] = readyData(p, oop.Val{
+					right.Data.(*oop.ListModel).Elems[i] = oop.Val{
 						Data: arithmeticProcess{
-							left:     p.left,
 							leftVal:  right,
-							right:    p.right,
 							rightVal: elem,
 							operator: p.operator,
 						}.solve().Data,
 						Type: oop.List,
-					})
+					}
 				} else {
-					right.Data.(*oop.ListModel).Elems[i] = readyData(p, oop.Val{
-						Data: solveArithmeticProcess(p.operator, arith, arithmetic(p.operator, elem)),
-						Type: oop.Int,
-					})
+					right.Data.(*oop.ListModel).Elems[i] = solveArithmeticProcess(p.operator, arith, arithmetic(p.operator, elem))
 				}
 			}
 			val.Data = right.Data
@@ -229,7 +182,7 @@ func (p arithmeticProcess) solve() oop.Val {
 			for i, elem := range p.leftVal.Data.(*oop.ListModel).Elems {
 				right := p.rightVal.Data.(*oop.ListModel).Elems[i]
 				if elem.Type == oop.List || right.Type == oop.List {
-					proc := arithmeticProcess{left: p.left, right: p.right, operator: p.operator}
+					proc := arithmeticProcess{operator: p.operator}
 					if elem.Type == oop.List {
 						proc.leftVal = oop.Val{Data: elem.Data, Type: oop.List}
 					} else {
@@ -240,15 +193,12 @@ func (p arithmeticProcess) solve() oop.Val {
 					} else {
 						proc.rightVal = right
 					}
-					p.leftVal.Data.(*oop.ListModel).Elems[i] = readyData(p, oop.Val{
+					p.leftVal.Data.(*oop.ListModel).Elems[i] = oop.Val{
 						Data: proc.solve().Data,
 						Type: oop.List,
-					})
+					}
 				} else {
-					p.leftVal.Data.(*oop.ListModel).Elems[i] = readyData(p, oop.Val{
-						Data: solveArithmeticProcess(p.operator, arithmetic(p.operator, elem), right.Data.(float64)),
-						Type: oop.Int,
-					})
+					p.leftVal.Data.(*oop.ListModel).Elems[i] = solveArithmeticProcess(p.operator, arithmetic(p.operator, elem), arithmetic(p.operator, right))
 				}
 			}
 			val.Data = p.leftVal.Data
@@ -269,79 +219,246 @@ func (p arithmeticProcess) solve() oop.Val {
 		arith := arithmetic(p.operator, right)
 		for i, elem := range left.Data.(*oop.ListModel).Elems {
 			if elem.Type == oop.List {
-				left.Data.(*oop.ListModel).Elems[i] = readyData(p, arithmeticProcess{
-					left:     p.left,
+				left.Data.(*oop.ListModel).Elems[i] = arithmeticProcess{
 					leftVal:  right,
-					right:    p.right,
 					rightVal: elem,
 					operator: p.operator,
-				}.solve())
+				}.solve()
 			} else {
-				left.Data.(*oop.ListModel).Elems[i] = readyData(p, oop.Val{
-					Data: solveArithmeticProcess(p.operator, arithmetic(p.operator, elem), arith),
-					Type: oop.Int,
-				})
+				left.Data.(*oop.ListModel).Elems[i] = solveArithmeticProcess(p.operator, arithmetic(p.operator, elem), arith)
 			}
 		}
 		val = left
 	} else {
-		val = readyData(p, oop.Val{
-			Data: solveArithmeticProcess(p.operator, arithmetic(p.operator, p.leftVal), arithmetic(p.operator, p.rightVal)),
-			Type: oop.Int,
-		})
+		val = solveArithmeticProcess(p.operator, arithmetic(p.operator, p.leftVal), arithmetic(p.operator, p.rightVal))
 	}
 	return val
 }
 
-func solveArithmeticProcess(operator obj.Token, left, right float64) float64 {
-	var result float64
+// overflow panics integer overflow.
+func overflow(operator obj.Token) {
+	fract.Panic(operator, obj.ArithmeticPanic, "Integer overflow!")
+}
+
+// mulInt returns multiplication of integers, panics if overflow.
+func mulInt(operator obj.Token, left, right int64) int64 {
+	if left == 0 || right == 0 {
+		return 0
+	}
+	result := left * right
+	if result/right != left || (left == -1 && right == math.MinInt64) || (right == -1 && left == math.MinInt64) {
+		overflow(operator)
+	}
+	return result
+}
+
+// solveInt solves arithmetic process of integers.
+// Division and modulo are truncated toward zero.
+func solveInt(operator obj.Token, left, right int64) int64 {
 	switch operator.Val {
 	case "+": // Addition.
-		result = left + right
+		result := left + right
+		if (right > 0 && result < left) || (right < 0 && result > left) {
+			overflow(operator)
+		}
+		return result
 	case "-": // Subtraction.
-		result = left - right
+		result := left - right
+		if (right < 0 && result < left) || (right > 0 && result > left) {
+			overflow(operator)
+		}
+		return result
 	case "*": // Multiply.
-		result = left * right
-	case "/", "//": // Division.
-		if left == 0 || right == 0 {
+		return mulInt(operator, left, right)
+	case "/": // Division.
+		if right == 0 {
 			fract.Panic(operator, obj.DivideByZeroPanic, "Divide by zero!")
+		} else if left == math.MinInt64 && right == -1 {
+			overflow(operator)
 		}
-		result = left / right
+		return left / right
+	case "%": // Mod.
+		if right == 0 {
+			fract.Panic(operator, obj.DivideByZeroPanic, "Divide by zero!")
+		} else if right == -1 {
+			return 0
+		}
+		return left % right
 	case "|": // Binary or.
-		result = float64(int(left) | int(right))
+		return left | right
 	case "&": // Binary and.
-		result = float64(int(left) & int(right))
+		return left & right
 	case "^": // Bitwise exclusive or.
-		result = float64(int(left) ^ int(right))
+		return left ^ right
 	case "**": // Exponentiation.
-		result = math.Pow(left, right)
-	case "%": // Mod.
-		result = math.Mod(left, right)
+		result := int64(1)
+		for right > 0 {
+			if right&1 == 1 {
+				result = mulInt(operator, result, left)
+			}
+			if right >>= 1; right > 0 {
+				left = mulInt(operator, left, left)
+			}
+		}
+		return result
 	case "<<": // Left shift.
 		if right < 0 {
-			fract.IPanic(operator, obj.ArithmeticPanic, "Shifter is cannot should be negative!")
+			fract.Panic(operator, obj.ArithmeticPanic, "Shifter is cannot should be negative!")
+		} else if left == 0 {
+			return 0
+		} else if right >= 63 || left<<right>>right != left {
+			overflow(operator)
 		}
-		result = float64(int(left) << int(right))
+		return left << right
 	case ">>": // Right shift.
 		if right < 0 {
-			fract.IPanic(operator, obj.ArithmeticPanic, "Shifter is cannot should be negative!")
+			fract.Panic(operator, obj.ArithmeticPanic, "Shifter is cannot should be negative!")
+		} else if right >= 63 {
+			right = 63
+		}
+		return left >> right
+	}
+	fract.IPanic(operator, obj.SyntaxPanic, "Operator is invalid!")
+	return 0
+}
+
+// solveBigInt solves arithmetic process of big integers.
+// Division and modulo are truncated toward zero.
+func solveBigInt(operator obj.Token, left, right *big.Int) *big.Int {
+	result := new(big.Int)
+	switch operator.Val {
+	case "+": // Addition.
+		return result.Add(left, right)
+	case "-": // Subtraction.
+		return result.Sub(left, right)
+	case "*": // Multiply.
+		return result.Mul(left, right)
+	case "/", "%": // Division and mod.
+		if right.Sign() == 0 {
+			fract.Panic(operator, obj.DivideByZeroPanic, "Divide by zero!")
+		} else if operator.Val == "%" {
+			return result.Rem(left, right)
+		}
+		return result.Quo(left, right)
+	case "|": // Binary or.
+		return result.Or(left, right)
+	case "&": // Binary and.
+		return result.And(left, right)
+	case "^": // Bitwise exclusive or.
+		return result.Xor(left, right)
+	case "**": // Exponentiation.
+		if right.Sign() < 0 {
+			fract.Panic(operator, obj.ArithmeticPanic, "Exponent is cannot should be negative!")
+		}
+		return result.Exp(left, right, nil)
+	case "<<", ">>": // Shifts.
+		if right.Sign() < 0 {
+			fract.Panic(operator, obj.ArithmeticPanic, "Shifter is cannot should be negative!")
+		} else if !right.IsUint64() || right.Uint64() > math.MaxUint32 {
+			fract.Panic(operator, obj.ArithmeticPanic, "Shifter is too large!")
+		} else if operator.Val == "<<" {
+			return result.Lsh(left, uint(right.Uint64()))
+		}
+		return result.Rsh(left, uint(right.Uint64()))
+	}
+	fract.IPanic(operator, obj.SyntaxPanic, "Operator is invalid!")
+	return result
+}
+
+// solveDecimal solves arithmetic process of decimals.
+func solveDecimal(operator obj.Token, leftVal, rightVal oop.Val) decimal.Decimal {
+	left, right := leftVal.Decimal(), rightVal.Decimal()
+	switch operator.Val {
+	case "+": // Addition.
+		return left.Add(right)
+	case "-": // Subtraction.
+		return left.Sub(right)
+	case "*": // Multiply.
+		return left.Mul(right)
+	case "/", "%": // Division and mod.
+		if right.Sign() == 0 {
+			fract.Panic(operator, obj.DivideByZeroPanic, "Divide by zero!")
+		} else if operator.Val == "%" {
+			return left.Rem(right)
 		}
-		result = float64(int(left) >> int(right))
+		return left.Quo(right)
+	case "**": // Exponentiation.
+		if rightVal.Type == oop.Decimal {
+			fract.Panic(operator, obj.ArithmeticPanic, "Exponent of decimal is must be integer!")
+		}
+		exp := rightVal.BigInt()
+		if exp.Sign() < 0 {
+			fract.Panic(operator, obj.ArithmeticPanic, "Exponent is cannot should be negative!")
+		}
+		result := decimal.New(big.NewInt(1))
+		for i := exp.BitLen() - 1; i >= 0; i-- {
+			result = result.Mul(result)
+			if exp.Bit(i) == 1 {
+				result = result.Mul(left)
+			}
+		}
+		return result
+	case "|", "&", "^", "<<", ">>":
+		fract.Panic(operator, obj.ArithmeticPanic, "Bitwise operators is not defined for decimal values!")
 	default:
 		fract.IPanic(operator, obj.SyntaxPanic, "Operator is invalid!")
 	}
-	return result
+	return left
 }
 
-// Check data and set ready.
-func readyData(process arithmeticProcess, val oop.Val) oop.Val {
-	if process.leftVal.Type == oop.String || process.rightVal.Type == oop.String {
-		val.Type = oop.String
-	} else if process.operator.Val == "/" || process.leftVal.Type == oop.Float || process.rightVal.Type == oop.Float {
-		val.Type = oop.Float
-		return val
+func solveArithmeticProcess(operator obj.Token, leftVal, rightVal oop.Val) oop.Val {
+	if leftVal.IsBig() || rightVal.IsBig() {
+		if leftVal.Type == oop.Float || rightVal.Type == oop.Float {
+			fract.Panic(operator, obj.ArithmeticPanic, "Float values is not compatible with bigint and decimal values!")
+		} else if leftVal.Type == oop.Decimal || rightVal.Type == oop.Decimal {
+			return oop.Val{Data: solveDecimal(operator, leftVal, rightVal), Type: oop.Decimal}
+		}
+		return oop.Val{Data: solveBigInt(operator, leftVal.BigInt(), rightVal.BigInt()), Type: oop.BigInt}
 	}
-	return val
+	switch operator.Val {
+	case "|", "&", "^", "<<", ">>": // Bitwise operators works with integers.
+		left, right := leftVal.Data, rightVal.Data
+		if leftVal.Type == oop.Float {
+			left = int64(leftVal.Data.(float64))
+		}
+		if rightVal.Type == oop.Float {
+			right = int64(rightVal.Data.(float64))
+		}
+		return oop.Val{Data: solveInt(operator, left.(int64), right.(int64)), Type: oop.Int}
+	case "**":
+		if leftVal.Type == oop.Int && rightVal.Type == oop.Int && rightVal.Data.(int64) >= 0 {
+			return oop.Val{Data: solveInt(operator, leftVal.Data.(int64), rightVal.Data.(int64)), Type: oop.Int}
+		}
+	default:
+		if leftVal.Type == oop.Int && rightVal.Type == oop.Int {
+			return oop.Val{Data: solveInt(operator, leftVal.Data.(int64), rightVal.Data.(int64)), Type: oop.Int}
+		}
+	}
+	var result float64
+	left, right := leftVal.Float64(), rightVal.Float64()
+	switch operator.Val {
+	case "+": // Addition.
+		result = left + right
+	case "-": // Subtraction.
+		result = left - right
+	case "*": // Multiply.
+		result = left * right
+	case "/": // Division.
+		if right == 0 {
+			fract.Panic(operator, obj.DivideByZeroPanic, "Divide by zero!")
+		}
+		result = left / right
+	case "**": // Exponentiation.
+		result = math.Pow(left, right)
+	case "%": // Mod.
+		if right == 0 {
+			fract.Panic(operator, obj.DivideByZeroPanic, "Divide by zero!")
+		}
+		result = math.Mod(left, right)
+	default:
+		fract.IPanic(operator, obj.SyntaxPanic, "Operator is invalid!")
+	}
+	return oop.Val{Data: result, Type: oop.Float}
 }
 
 // Select elements of enumerable object.
@@ -361,7 +478,7 @@ func (p *Parser) selectEnumerable(valType string, v oop.Val, tk obj.Token, s int
 	case oop.Map:
 		m := v.Data.(oop.MapModel).Map
 		switch t := s.(type) {
-		case oop.ListModel:
+		case *oop.ListModel:
 			resultMap := oop.NewMapModel()
 			for _, key := range t.Elems {
 				val, ok := m[key]
@@ -388,16 +505,6 @@ func (p *Parser) selectEnumerable(valType string, v oop.Val, tk obj.Token, s int
 	return &result
 }
 
-type valuePartInfo struct {
-	tokens  []obj.Token
-	valType string // Force to mutability or immutability.
-	/*
-		mut   -> Force to mutability.
-		var   -> Force to immutability.
-		empty -> Type of value.
-	*/
-}
-
 func (p *Parser) processNameValue(valType string, tk obj.Token) *oop.Val {
 	var result *oop.Val
 	defIndex, defType := p.defByName(tk.Val)
@@ -418,558 +525,223 @@ func (p *Parser) processNameValue(valType string, tk obj.Token) *oop.Val {
 	return result
 }
 
-func (p *Parser) processValuePart(part valuePartInfo) *oop.Val {
-	var result *oop.Val
-	// Single value.
-	if tk := part.tokens[0]; len(part.tokens) == 1 {
-		if tk.Val[0] == '\'' || tk.Val[0] == '"' {
-			result = &oop.Val{Data: tk.Val[1 : len(tk.Val)-1], Type: oop.String}
-			goto end
-		} else if tk.Val == "true" || tk.Val == "false" {
-			result = &oop.Val{Data: tk.Val == "true", Type: oop.Bool}
-			goto end
-		} else if tk.Val == "none" {
-			result = &oop.Val{Data: tk.Val, Type: oop.None}
-			goto end
-		} else if tk.Type == fract.Value {
-			if strings.Contains(tk.Val, ".") || strings.ContainsAny(tk.Val, "eE") {
-				tk.Type = oop.Float
-			} else {
-				tk.Type = oop.Int
-			}
-			if tk.Val != "NaN" {
-				prs, _ := new(big.Float).SetString(tk.Val)
-				val, _ := prs.Float64()
-				result = &oop.Val{Data: val, Type: tk.Type}
-			} else {
-				result = &oop.Val{Data: math.NaN(), Type: tk.Type}
-			}
-			goto end
-		} else {
-			if tk.Type != fract.Name {
-				fract.IPanic(tk, obj.ValuePanic, "Invalid value!")
-			}
+// selectorValue returns value of sub field of object.
+func (p *Parser) selectorValue(val oop.Val, name obj.Token, valType string) *oop.Val {
+	switch val.Type {
+	case oop.Package:
+		impInf := val.Data.(*importInfo)
+		checkPublic(nil, name)
+		return impInf.src.processNameValue(valType, name)
+	case oop.StructIns:
+		ins := val.Data.(oop.StructInstance)
+		checkPublic(ins.File, name)
+		i := ins.Fields.VarIndexByName(name.Val)
+		if i == -1 {
+			fract.IPanic(name, obj.NamePanic, "Name is not defined: "+name.Val)
 		}
-	}
-	switch j, tk := len(part.tokens)-1, part.tokens[len(part.tokens)-1]; tk.Type {
-	case fract.Name:
-		if j > 0 {
-			j--
-			if j == 0 || part.tokens[j].Type != fract.Dot {
-				fract.IPanic(part.tokens[j], obj.SyntaxPanic, "Invalid syntax!")
-			}
-			nameTk := part.tokens[j+1]
-			valTk := part.tokens[j]
-			part.tokens = part.tokens[:j]
-			valType := part.valType
-			part.valType = "mut"
-			val := p.processValuePart(part)
-			part.valType = valType
-			switch val.Type {
-			case oop.Package:
-				impInf := val.Data.(*importInfo)
-				checkPublic(nil, nameTk)
-				result = impInf.src.processNameValue(part.valType, nameTk)
-				goto end
-			case oop.StructIns:
-				ins := val.Data.(oop.StructInstance)
-				checkPublic(ins.File, tk)
-				i := ins.Fields.VarIndexByName(nameTk.Val)
-				if i == -1 {
-					fract.IPanic(nameTk, obj.NamePanic, "Name is not defined: "+nameTk.Val)
-				}
-				result = &ins.Fields.Vars[i].Val
-				goto end
-			case oop.Map:
-				m := val.Data.(oop.MapModel)
-				i := m.Defs.FuncIndexByName(nameTk.Val)
-				if i == -1 {
-					fract.IPanic(nameTk, obj.NamePanic, "Name is not defined: "+nameTk.Val)
-				}
-				result = &oop.Val{Data: m.Defs.Funcs[i], Type: oop.Func}
-				goto end
-			case oop.ClassIns:
-				ins := val.Data.(oop.ClassInstance)
-				checkPublic(ins.File, tk)
-				defIndex, defType := ins.Defs.DefByName(nameTk.Val)
-				if defIndex == -1 {
-					fract.IPanic(nameTk, obj.NamePanic, "Name is not defined: "+nameTk.Val)
-				}
-				switch defType {
-				case 'f': // Function.
-					result = &oop.Val{Data: ins.Defs.Funcs[defIndex], Type: oop.Func}
-				case 'v': // Value.
-					if p.defs.VarIndexByName("this") != -1 {
-						result = &ins.Defs.Vars[defIndex].Val
-					} else {
-						result = ins.Defs.Vars[defIndex].Val.Get(part.valType)
-					}
-				}
-				goto end
-			case oop.List:
-				list := val.Data.(*oop.ListModel)
-				i := list.Defs.FuncIndexByName(nameTk.Val)
-				if i == -1 {
-					fract.IPanic(nameTk, obj.NamePanic, "Name is not defined: "+nameTk.Val)
-				}
-				result = &oop.Val{Data: list.Defs.Funcs[i], Type: oop.Func}
-				goto end
-			case oop.String:
-				str := oop.NewStringModel(val.Data.(string))
-				i := str.Defs.FuncIndexByName(nameTk.Val)
-				if i == -1 {
-					fract.IPanic(nameTk, obj.NamePanic, "Name is not defined: "+nameTk.Val)
-				}
-				result = &oop.Val{Data: str.Defs.Funcs[i], Type: oop.Func}
-				goto end
-			default:
-				fract.IPanic(valTk, obj.ValuePanic, "Object is not support sub fields!")
-			}
+		return &ins.Fields.Vars[i].Val
+	case oop.Map:
+		m := val.Data.(oop.MapModel)
+		i := m.Defs.FuncIndexByName(name.Val)
+		if i == -1 {
+			fract.IPanic(name, obj.NamePanic, "Name is not defined: "+name.Val)
+		}
+		return &oop.Val{Data: m.Defs.Funcs[i], Type: oop.Func}
+	case oop.ClassIns:
+		ins := val.Data.(oop.ClassInstance)
+		checkPublic(ins.File, name)
+		defIndex, defType := ins.Defs.DefByName(name.Val)
+		if defIndex == -1 {
+			fract.IPanic(name, obj.NamePanic, "Name is not defined: "+name.Val)
+		}
+		switch defType {
+		case 'f': // Function.
+			return &oop.Val{Data: ins.Defs.Funcs[defIndex], Type: oop.Func}
+		default: // Value.
+			if p.defs.VarIndexByName("this") != -1 {
+				return &ins.Defs.Vars[defIndex].Val
+			}
+			return ins.Defs.Vars[defIndex].Val.Get(valType)
 		}
-		result = p.processNameValue(part.valType, tk)
-		goto end
-	case fract.Brace:
-		braceCount := 0
-		switch tk.Val {
-		case ")":
-			var valTokens []obj.Token
-			for ; j >= 0; j-- {
-				tk := part.tokens[j]
-				if tk.Type != fract.Brace {
-					continue
-				}
-				switch tk.Val {
-				case ")":
-					braceCount++
-				case "(":
-					braceCount--
-				}
-				if braceCount > 0 {
-					continue
-				}
-				valTokens = part.tokens[:j]
-				break
-			}
-			if len(valTokens) == 0 && braceCount == 0 {
-				tk, part.tokens = part.tokens[0], part.tokens[1:len(part.tokens)-1]
-				if len(part.tokens) == 0 {
-					fract.IPanic(tk, obj.SyntaxPanic, "Invalid syntax!")
-				}
-				result = p.processValue(part.tokens, part.valType)
-				goto end
-			}
-			val := p.processValuePart(valuePartInfo{tokens: valTokens, valType: part.valType})
-			switch val.Type {
-			case oop.Func: // Function call.
-				result = p.funcCallModel(val.Data.(*oop.Fn), part.tokens[len(valTokens):]).Call()
-			case oop.StructDef:
-				s := val.Data.(oop.Struct)
-				result = &oop.Val{
-					Data: s.CallConstructor(p.funcCallModel(s.Constructor, part.tokens[len(valTokens):]).args),
-					Type: oop.StructIns,
-				}
-			case oop.ClassDef:
-				class := val.Data.(oop.Class)
-				result = &oop.Val{
-					Data: class.CallConstructor(p.funcCallModel(class.Constructor, part.tokens[len(valTokens):])),
-					Type: oop.ClassIns,
-				}
-			default:
-				fract.IPanic(part.tokens[len(valTokens)], obj.ValuePanic, "Invalid syntax!")
-			}
-			goto end
-		case "]":
-			var valTokens []obj.Token
-			for ; j >= 0; j-- {
-				tk := part.tokens[j]
-				if tk.Type != fract.Brace {
-					continue
-				}
-				switch tk.Val {
-				case "]":
-					braceCount++
-				case "[":
-					braceCount--
-				}
-				if braceCount > 0 {
-					continue
-				}
-				valTokens = part.tokens[:j]
-				break
-			}
-			if len(valTokens) == 0 && braceCount == 0 {
-				result = p.processEnumerableValue(part.tokens)
-				goto end
-			}
-			val := p.processValuePart(valuePartInfo{valType: part.valType, tokens: valTokens})
-			if !val.IsEnum() {
-				fract.IPanic(valTokens[0], obj.ValuePanic, "Index accessor is cannot used with not enumerable values!")
-			}
-			result = p.selectEnumerable(part.valType, *val, tk, enumerableSelections(*val, *p.processValTokens(part.tokens[len(valTokens)+1 : len(part.tokens)-1]), tk))
-			goto end
-		case "}":
-			var valTokens []obj.Token
-			for ; j >= 0; j-- {
-				tk := part.tokens[j]
-				if tk.Type != fract.Brace {
-					continue
-				}
-				switch tk.Val {
-				case "}":
-					braceCount++
-				case "{":
-					braceCount--
-				}
-				if braceCount > 0 {
-					continue
-				}
-				valTokens = part.tokens[:j]
-				break
-			}
-			valTokensLen := len(valTokens)
-			if valTokensLen == 0 && braceCount == 0 {
-				result = p.processEnumerableValue(part.tokens)
-				goto end
-			} else if valTokensLen > 1 && (valTokens[1].Type != fract.Brace || valTokens[1].Val != "(") {
-				fract.IPanic(valTokens[1], obj.SyntaxPanic, "Invalid syntax!")
-			} else if valTokensLen > 1 && (valTokens[valTokensLen-1].Type != fract.Brace || valTokens[valTokensLen-1].Val != ")") {
-				fract.IPanic(valTokens[valTokensLen-1], obj.SyntaxPanic, "Invalid syntax!")
-			}
-			switch valTokens[0].Type {
-			case fract.Func:
-				fn := &oop.Fn{
-					Name:   "anonymous",
-					Src:    p,
-					Tokens: p.getBlock(part.tokens[len(valTokens):]),
-				}
-				if fn.Tokens == nil {
-					fn.Tokens = [][]obj.Token{}
-				}
-				if valTokensLen > 1 {
-					valTokens = valTokens[1:]
-					valTokens = decomposeBrace(&valTokens)
-					p.setParams(fn, &valTokens)
-				}
-				result = &oop.Val{Data: fn, Type: oop.Func}
-			case fract.Struct:
-				result = p.buildStruct("anonymous", part.tokens[1:])
-			default:
-				fract.IPanic(valTokens[0], obj.SyntaxPanic, "Invalid syntax!")
-			}
-			valTokens = nil
-			goto end
+	case oop.List:
+		list := val.Data.(*oop.ListModel)
+		i := list.Defs.FuncIndexByName(name.Val)
+		if i == -1 {
+			fract.IPanic(name, obj.NamePanic, "Name is not defined: "+name.Val)
+		}
+		return &oop.Val{Data: list.Defs.Funcs[i], Type: oop.Func}
+	case oop.String:
+		str := oop.NewStringModel(val.Data.(string))
+		i := str.Defs.FuncIndexByName(name.Val)
+		if i == -1 {
+			fract.IPanic(name, obj.NamePanic, "Name is not defined: "+name.Val)
 		}
+		return &oop.Val{Data: str.Defs.Funcs[i], Type: oop.Func}
 	}
-	fract.IPanic(part.tokens[0], obj.ValuePanic, "Invalid value!")
-end:
-	result.Mut = part.valType == "mut"
-	return result
+	fract.IPanic(name, obj.ValuePanic, "Object is not support sub fields!")
+	return nil
 }
 
-func (p *Parser) processListValue(tokens []obj.Token) *oop.Val {
-	var braceCount int
-	comma := 1
-	list := oop.NewListModel()
-	for j := 1; j < len(tokens)-1; j++ {
-		switch typ := tokens[j]; typ.Type {
-		case fract.Brace:
-			switch typ.Val {
-			case "{", "[", "(":
-				braceCount++
-			default:
-				braceCount--
-			}
-		case fract.Comma:
-			if braceCount != 0 {
-				break
-			}
-			if comma-j == 0 {
-				fract.IPanic(typ, obj.SyntaxPanic, "Value is not given!")
-			}
-			list.PushBack(*p.processValTokens(tokens[comma:j]))
-			comma = j + 1
-		}
-	}
-	if len := len(tokens); comma < len-1 {
-		list.PushBack(*p.processValTokens(tokens[comma : len-1]))
-	}
-	return &oop.Val{Data: list, Type: oop.List}
+func (p *Parser) processSelector(s *ast.Selector, valType string) *oop.Val {
+	return p.selectorValue(*p.processValue(s.X, "mut"), s.Name, valType)
 }
 
-func (p *Parser) processMapValue(tokens []obj.Token) *oop.Val {
-	var braceCount int
-	m := oop.NewMapModel()
-	comma := 1
-	for j := 1; j < len(tokens)-1; j++ {
-		switch typ := tokens[j]; typ.Type {
-		case fract.Brace:
-			switch typ.Val {
-			case "{", "[", "(":
-				braceCount++
-			default:
-				braceCount--
-			}
-		case fract.Comma:
-			if braceCount != 0 {
-				break
-			}
-			if comma-j == 0 {
-				fract.IPanic(typ, obj.SyntaxPanic, "Value is not given!")
-			}
-			modelTokens := tokens[comma:j]
-			var (
-				i              int
-				modelTokensLen int = len(modelTokens)
-				tk             obj.Token
-			)
-			for i, tk = range modelTokens {
-				switch tk.Type {
-				case fract.Brace:
-					switch tk.Val {
-					case "{", "[", "(":
-						braceCount++
-					default:
-						braceCount--
-					}
-				case fract.Colon:
-					if braceCount != 0 {
-						break
-					}
-					if i+1 >= modelTokensLen {
-						fract.IPanic(tk, obj.SyntaxPanic, "Value is not given!")
-					}
-					key := *p.processValTokens(modelTokens[:i])
-					_, ok := m.Map[key]
-					if ok {
-						fract.IPanic(tk, obj.ValuePanic, "Key is already defined!")
-					}
-					m.Map[key] = *p.processValTokens(modelTokens[i+1:])
-					comma = j + 1
-					modelTokens = nil
-				}
-			}
-			if modelTokens != nil {
-				fract.IPanic(modelTokens[modelTokensLen-1], obj.SyntaxPanic, "Value identifier is not found!")
-			}
-		}
-	}
-	if comma < len(tokens)-1 {
-		lastTokens := tokens[comma : len(tokens)-1]
-		i := -1
-		lenLastTokens := len(lastTokens)
-		for j, tk := range lastTokens {
-			switch tk.Type {
-			case fract.Brace:
-				switch tk.Val {
-				case "{", "[", "(":
-					braceCount++
-				default:
-					braceCount--
-				}
-			case fract.Colon:
-				if braceCount != 0 {
-					break
-				}
-				i = j
-			}
-			if i != -1 {
-				break
-			}
-		}
-		if i+1 >= lenLastTokens {
-			fract.IPanic(lastTokens[i], obj.SyntaxPanic, "Value is not given!")
-		}
-		key := *p.processValTokens(lastTokens[:i])
-		_, ok := m.Map[key]
-		if ok {
-			fract.IPanic(lastTokens[i], obj.ValuePanic, "Key is already defined!")
-		}
-		m.Map[key] = *p.processValTokens(lastTokens[i+1:])
-		lastTokens = nil
+// callee returns function of callable value.
+func callee(val oop.Val, tk obj.Token) *oop.Fn {
+	switch val.Type {
+	case oop.Func:
+		return val.Data.(*oop.Fn)
+	case oop.StructDef:
+		return val.Data.(oop.Struct).Constructor
+	case oop.ClassDef:
+		return val.Data.(oop.Class).Constructor
 	}
-	return &oop.Val{Data: m, Type: oop.Map}
+	fract.IPanic(tk, obj.ValuePanic, "Invalid syntax!")
+	return nil
 }
 
-func (p *Parser) processListComprehension(tokens []obj.Token) *oop.Val {
-	var (
-		selectTokens []obj.Token
-		loopTokens   []obj.Token
-		filterTokens []obj.Token
-		braceCount   int
-	)
-	for i, tk := range tokens {
-		if tk.Type == fract.Brace {
-			switch tk.Val {
-			case "{", "[", "(":
-				braceCount++
-			default:
-				braceCount--
-			}
-		}
-		if braceCount > 1 {
-			continue
-		}
-		if tk.Type == fract.Loop {
-			selectTokens = tokens[1:i]
-		} else if tk.Type == fract.Comma {
-			loopTokens = tokens[len(selectTokens)+1 : i]
-			filterTokens = tokens[i+1 : len(tokens)-1]
-			if len(filterTokens) == 0 {
-				filterTokens = nil
-			}
-			break
-		}
-	}
-	if loopTokens == nil {
-		loopTokens = tokens[len(selectTokens)+1 : len(tokens)-1]
-	}
-	if len(loopTokens) < 2 {
-		fract.IPanic(loopTokens[0], obj.SyntaxPanic, "Variable name is not given!")
+// callValue calls callable value with call model and returns result.
+func callValue(val oop.Val, model *funcCall) *oop.Val {
+	switch val.Type {
+	case oop.StructDef:
+		s := val.Data.(oop.Struct)
+		return &oop.Val{Data: s.CallConstructor(model.args), Type: oop.StructIns}
+	case oop.ClassDef:
+		// Arguments of constructor are set to copy of it, constructor is shared by goroutines.
+		ctor := *model.fn
+		model.fn = &ctor
+		class := val.Data.(oop.Class)
+		return &oop.Val{Data: class.CallConstructor(model), Type: oop.ClassIns}
 	}
-	nameTk := loopTokens[1]
-	// Name is not name?
-	if nameTk.Type != fract.Name {
-		fract.IPanic(nameTk, obj.SyntaxPanic, "This is not a valid name!")
+	return model.Call()
+}
+
+func (p *Parser) processCall(c *ast.Call, valType string) *oop.Val {
+	val := *p.processValue(c.Fn, valType)
+	return callValue(val, p.funcCallModel(callee(val, c.Tk), c))
+}
+
+func (p *Parser) processLogical(l *ast.Logical) bool {
+	left := p.processCondition(l.Left)
+	if l.Op.Val == "||" {
+		return left || p.processCondition(l.Right)
 	}
-	if line := p.defLineByName(nameTk.Val); line != -1 {
-		fract.IPanic(nameTk, obj.NamePanic, "\""+nameTk.Val+"\" is already defined at line: "+fmt.Sprint(line))
+	return left && p.processCondition(l.Right)
+}
+
+func (p *Parser) processListValue(l *ast.List) *oop.Val {
+	list := oop.NewListModel()
+	for _, elem := range l.Elems {
+		list.PushBack(*p.processVal(elem))
 	}
-	if lenLoopTokens := len(loopTokens); lenLoopTokens < 3 {
-		tk := tokens[0]
-		fract.IPanicC(tk.File, tk.Line, loopTokens[1].Column+len(loopTokens[1].Val), obj.SyntaxPanic, "Value is not given!")
-	} else if tk := loopTokens[2]; tk.Type != fract.In {
-		fract.IPanic(loopTokens[2], obj.SyntaxPanic, "Invalid syntax!")
-	} else if lenLoopTokens < 4 {
-		fract.IPanic(loopTokens[2], obj.SyntaxPanic, "Value is not given!")
+	return &oop.Val{Data: list, Type: oop.List}
+}
+
+func (p *Parser) processMapValue(m *ast.Map) *oop.Val {
+	model := oop.NewMapModel()
+	for i, k := range m.Keys {
+		key := *p.processVal(k)
+		if _, ok := model.Map[key]; ok {
+			fract.IPanic(k.Token(), obj.ValuePanic, "Key is already defined!")
+		}
+		model.Map[key] = *p.processVal(m.Vals[i])
 	}
-	loopTokens = loopTokens[3:]
-	varVal := *p.processValTokens(loopTokens)
-	if !varVal.IsEnum() {
-		fract.IPanic(loopTokens[0], obj.ValuePanic, "Foreach loop must defined enumerable value!")
+	return &oop.Val{Data: model, Type: oop.Map}
+}
+
+func (p *Parser) processListComprehension(c *ast.Comprehension) *oop.Val {
+	nameTk := c.Name
+	p.checkDefined(&p.defs, nameTk)
+	varVal := *p.processVal(c.Iter)
+	if !isIterable(varVal) {
+		fract.IPanic(c.Iter.Token(), obj.ValuePanic, "Foreach loop must defined enumerable value!")
 	}
 	if nameTk.Val == "_" {
 		nameTk.Val = ""
-	} else if !isValidName(nameTk.Val) {
-		fract.IPanic(nameTk, obj.NamePanic, "Invalid name!")
 	}
+	varLen := len(p.defs.Vars)
 	p.defs.Vars = append(p.defs.Vars, &oop.Var{Name: nameTk.Val})
-	elem := p.defs.Vars[len(p.defs.Vars)-1]
+	p.depth++
 	// Interpret block.
 	list := oop.NewListModel()
-	l := loop{val: varVal}
-	l.run(func() {
-		elem.Val = l.b
-		if filterTokens == nil || p.prococessCondition(filterTokens) {
-			list.PushBack(*p.processValTokens(selectTokens))
+	it := p.rt.iterate(varVal, c.Iter.Token())
+	defer it.close()
+	for it.next() {
+		// Variable is new for each element, closures are capture own element.
+		p.defs.Vars[varLen] = &oop.Var{Name: nameTk.Val, Val: it.b}
+		if c.Filter == nil || p.processCondition(c.Filter) {
+			list.PushBack(*p.processVal(c.Select))
 		}
-	})
+	}
 	// Remove variables.
-	elem = nil
-	p.defs.Vars = p.defs.Vars[:len(p.defs.Vars)-1]
+	p.depth--
+	p.defs.Vars = p.defs.Vars[:varLen]
 	return &oop.Val{Data: list, Type: oop.List}
 }
 
-func (p *Parser) processEnumerableValue(tokens []obj.Token) *oop.Val {
-	var (
-		ListComprehension bool
-		braceCount        int
-	)
-	for _, t := range tokens {
-		if t.Type == fract.Brace {
-			switch t.Val {
-			case "{", "[", "(":
-				braceCount++
-			default:
-				braceCount--
-			}
-		}
-		if braceCount > 1 {
-			continue
-		}
-		if t.Type == fract.Comma {
-			break
-		} else if !ListComprehension && t.Type == fract.Loop {
-			if tokens[0].Val != "[" {
-				fract.IPanic(tokens[0], obj.SyntaxPanic, "Invalid syntax!")
-			}
-			ListComprehension = true
-			break
-		}
-	}
-	if ListComprehension {
-		return p.processListComprehension(tokens)
-	} else if tokens[0].Val == "{" {
-		return p.processMapValue(tokens)
-	}
-	return p.processListValue(tokens)
-}
-
-func (p *Parser) processValue(tks []obj.Token, valType string) *oop.Val {
-	// Is conditional expression?
-	if j, _ := findConditionOperator(tks); j != -1 {
-		return &oop.Val{Data: p.prococessCondition(tks), Type: oop.Bool}
-	}
-	processes := arithmeticProcesses(tks)
-	part := valuePartInfo{valType: valType}
-	if len(processes) == 1 {
-		part.tokens = processes[0]
-		return p.processValuePart(part)
-	}
-	var result oop.Val
-	var process arithmeticProcess
-	j := nextOperator(processes)
-	for j != -1 {
-		if j == 0 {
-			if len(processes) == 1 {
-				break
-			}
-			process.leftVal = result
-			process.operator = processes[j][0]
-			process.right = processes[j+1]
-			part.tokens = process.right
-			process.rightVal = *p.processValuePart(part)
-			if process.rightVal.Type == fract.NA {
-				fract.IPanic(process.left[0], obj.ValuePanic, "Value is not given!")
-			}
-			result = process.solve()
-			processes = processes[2:]
-			j = nextOperator(processes)
-			continue
-		}
-		process.left = processes[j-1]
-		part.tokens = process.left
-		process.leftVal = *p.processValuePart(part)
-		if process.leftVal.Type == fract.NA {
-			fract.IPanic(process.left[0], obj.ValuePanic, "Value is not given!")
-		}
-		process.operator = processes[j][0]
-		process.right = processes[j+1]
-		part.tokens = process.right
-		process.rightVal = *p.processValuePart(part)
-		if process.rightVal.Type == fract.NA {
-			fract.IPanic(process.right[0], obj.ValuePanic, "Value is not given!")
-		}
-		val := process.solve()
-		if result.Data != nil {
-			process.operator.Val = "+"
-			process.right = processes[j+1]
-			process.leftVal = result
-			process.rightVal = val
-			result = process.solve()
-		} else {
-			result = val
-		}
-		// Remove computed processes.
-		processes = append(processes[:j-1], processes[j+2:]...)
-		// Find next operator.
-		j = nextOperator(processes)
+// processValue returns value of expression.
+// valType is forces to mutability or immutability.
+//
+//	mut   -> Force to mutability.
+//	var   -> Force to immutability.
+//	empty -> Type of value.
+func (p *Parser) processValue(e ast.Expr, valType string) *oop.Val {
+	var result *oop.Val
+	switch t := e.(type) {
+	case *ast.Value:
+		result = &oop.Val{Data: t.Data, Type: t.Type}
+	case *ast.Interpolation:
+		result = &oop.Val{Data: p.interpolate(t), Type: oop.String}
+	case *ast.Name:
+		result = p.processNameValue(valType, t.Tk)
+	case *ast.Binary:
+		val := arithmeticProcess{
+			leftVal:  *p.processValue(t.Left, valType),
+			rightVal: *p.processValue(t.Right, valType),
+			operator: t.Op,
+		}.solve()
+		result = &val
+	case *ast.Compare:
+		result = &oop.Val{Data: p.compare(*p.processVal(t.Left), *p.processVal(t.Right), t.Op), Type: oop.Bool}
+	case *ast.Logical:
+		result = &oop.Val{Data: p.processLogical(t), Type: oop.Bool}
+	case *ast.Selector:
+		result = p.processSelector(t, valType)
+	case *ast.Index:
+		val := p.processValue(t.X, valType)
+		if !val.IsEnum() {
+			fract.IPanic(t.X.Token(), obj.ValuePanic, "Index accessor is cannot used with not enumerable values!")
+		}
+		result = p.selectEnumerable(valType, *val, t.Tk, enumerableSelections(*val, *p.processVal(t.Index), t.Tk))
+	case *ast.Call:
+		result = p.processCall(t, valType)
+	case *ast.List:
+		result = p.processListValue(t)
+	case *ast.Map:
+		result = p.processMapValue(t)
+	case *ast.Comprehension:
+		result = p.processListComprehension(t)
+	case *ast.Func:
+		fn := &oop.Fn{
+			Name:      "anonymous",
+			Src:       p,
+			Block:     t.Body,
+			Generator: t.Generator,
+		}
+		p.setParams(fn, t.Params)
+		p.closure(fn)
+		result = &oop.Val{Data: fn, Type: oop.Func}
+	case *ast.Struct:
+		result = p.buildStruct("anonymous", t.Fields)
+	case *ast.Receive:
+		val := receive(*p.processVal(t.Ch), t.Ch.Token())
+		result = &val
+	default:
+		fract.IPanic(e.Token(), obj.ValuePanic, "Invalid value!")
 	}
-	processes = nil
-	process.left = nil
-	process.right = nil
-	return &result
+	val := *result
+	val.Mut = valType == "mut"
+	return &val
 }
 
-func (p *Parser) processValTokens(tks []obj.Token) *oop.Val {
-	return p.processValue(tks, "")
-}
+func (p *Parser) processVal(e ast.Expr) *oop.Val { return p.processValue(e, "") }
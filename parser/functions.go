@@ -1,9 +1,9 @@
 package parser
 
 import (
-	"fmt"
 	"strings"
 
+	"github.com/fract-lang/fract/ast"
 	"github.com/fract-lang/fract/oop"
 	"github.com/fract-lang/fract/pkg/fract"
 	"github.com/fract-lang/fract/pkg/obj"
@@ -14,6 +14,8 @@ type funcCall struct {
 	fn    *oop.Fn
 	errTk obj.Token
 	args  []oop.VarDef
+	rt    *Runtime   // Runtime of caller.
+	gen   *generator // Generator of call, nil if not processed by generator.
 }
 
 func (c *funcCall) Func() *oop.Fn { return c.fn }
@@ -21,438 +23,315 @@ func (c *funcCall) Func() *oop.Fn { return c.fn }
 func (c *funcCall) Call() *oop.Val {
 	var returnVal oop.Val
 	// Is built-in function?
-	if c.fn.Tokens == nil {
-		returnVal = c.fn.Src.(func(obj.Token, []oop.VarDef) oop.Val)(c.errTk, c.args)
+	builtin, ok := c.fn.Src.(func(obj.Token, []oop.VarDef) oop.Val)
+	// Built-in functions of runtime are called by runtime of caller.
+	if fn, isRt := c.fn.Src.(func(*Runtime, obj.Token, []oop.VarDef) oop.Val); isRt {
+		builtin = func(tk obj.Token, args []oop.VarDef) oop.Val { return fn(c.rt, tk, args) }
+		ok = true
+	}
+	if ok {
+		// Frame is not popped if panicked, panic handlers are use it for stack trace.
+		callLen := c.rt.call(c.fn.Name, c.errTk)
+		returnVal = builtin(c.errTk, c.args)
+		c.rt.calls = c.rt.calls[:callLen]
 		c.args = nil
 		c.fn = nil
 		return &returnVal
 	}
-	// Process block.
-	deferLen := len(defers)
-	src := c.fn.Src.(*Parser)
-	p := Parser{
-		defs:         oop.DefMap{Vars: append(c.args, c.fn.Args...), Funcs: src.defs.Funcs},
-		packages:     src.packages,
-		funcTempVars: src.funcTempVars,
-		loopCount:    0,
-		funcCount:    1,
-		Tokens:       c.fn.Tokens[:len(c.fn.Tokens):len(c.fn.Tokens)],
+	// Generator function returns iterator and it is processes the call.
+	if c.fn.Generator && c.gen == nil {
+		returnVal = oop.Val{Data: newGenerator(c), Type: oop.Iter}
+		c.args = nil
+		c.fn = nil
+		return &returnVal
 	}
-	if p.funcTempVars == -1 {
-		p.funcTempVars = 0
+	// Process block.
+	src := c.rt.source(c.fn.Src.(*Parser))
+	deferLen := len(c.rt.defers)
+	vars := append(c.args, c.fn.Args...)
+	funcs := src.defs.Funcs[:len(src.defs.Funcs):len(src.defs.Funcs)]
+	var scopeFuncs []*oop.Fn
+	if c.fn.Scope != nil { // Closure.
+		vars = append(vars, c.fn.Scope.Defs.Vars...)
+		scopeFuncs = c.fn.Scope.Funcs()
+		funcs = append(scopeFuncs, funcs...)
 	}
-	if p.funcTempVars == 0 {
-		p.defs.Vars = append(p.defs.Vars, src.defs.Vars...)
-	} else {
-		p.defs.Vars = append(p.defs.Vars, src.defs.Vars[:len(src.defs.Vars)-p.funcTempVars]...)
+	p := Parser{
+		defs: oop.DefMap{
+			Vars:  append(vars, src.defs.Vars...),
+			Funcs: funcs,
+		},
+		srcVars:  [2]int{len(vars), len(vars) + len(src.defs.Vars)},
+		srcFuncs: [2]int{len(scopeFuncs), len(scopeFuncs) + len(src.defs.Funcs)},
+		packages: src.packages[:len(src.packages):len(src.packages)],
+		prog:     src.prog,
+		rt:       c.rt,
+		fn:       c.fn,
+		gen:      c.gen,
+		Lex:      src.Lex,
 	}
-	p.funcTempVars = len(c.args)
+	frameLen := p.rt.enter(&p)
+	callLen := p.rt.call(c.fn.Name, c.errTk)
 	// Interpret block.
 	block := obj.Block{
 		Try: func() {
-			for p.index = 0; p.index < len(p.Tokens); p.index++ {
-				if p.processExpression(p.Tokens[p.index]) == fract.FUNCReturn {
-					src.returnVal = p.returnVal
-					if src.returnVal == nil {
+			keywordState := fract.NA
+			if c.fn.Code != nil { // Compiled function.
+				keywordState = p.exec(&c.fn.Code.Code)
+			} else {
+				for _, stmt := range c.fn.Block.Stmts {
+					if keywordState = p.processStmt(stmt); keywordState == fract.FUNCReturn {
 						break
 					}
-					returnVal = *src.returnVal
-					src.returnVal = nil
-					break
 				}
 			}
+			if keywordState == fract.FUNCReturn && p.returnVal != nil {
+				returnVal = *p.returnVal
+			}
+		},
+		Catch: func(cp obj.Panic) {
+			p.rt.stackTrace(&cp)
+			// Defers are run before panic leaves function like Go,
+			// so deferred Done of wait groups is not missed by goroutines.
+			p.rt.runDefers(deferLen)
+			p.rt.calls = p.rt.calls[:callLen]
+			p.rt.leave(frameLen)
+			panic(cp)
 		},
 	}
 	block.Do()
-	if block.Panic.Msg != "" {
-		defers = defers[:deferLen]
-		panic(block.Panic.Msg)
-	}
-	for i := len(defers) - 1; i >= deferLen; i-- {
-		defers[i].Call()
-	}
-	defers = defers[:deferLen]
+	p.rt.runDefers(deferLen)
+	p.rt.calls = p.rt.calls[:callLen]
+	p.rt.leave(frameLen)
 	c.args = nil
 	c.fn = nil
 	return &returnVal
 }
 
-// isParamSet Argument type is param set?
-func isParamSet(tokens []obj.Token) bool {
-	return len(tokens) >= 2 && tokens[0].Type == fract.Name && tokens[1].Val == "="
+// argMode returns value mode of argument by parameter type.
+func argMode(paramType string) string {
+	switch paramType {
+	case "", "const":
+		return ""
+	case "mut", "const mut":
+		return "mut"
+	}
+	return "var"
 }
 
 // procFuncArg is process and returns function argument value
-// by specified tokens and parameter type.
-func (p *Parser) procFuncArgVal(tokens []obj.Token, paramType string) oop.Val {
-	if paramType != "" && paramType != "const" {
-		if paramType == "mut" || paramType == "const mut" {
-			return *p.processValue(tokens, "mut")
-		}
-		return *p.processValue(tokens, "var")
-	}
-	return *p.processValTokens(tokens)
+// by specified expression and parameter type.
+func (p *Parser) procFuncArgVal(e ast.Expr, paramType string) oop.Val {
+	return *p.processValue(e, argMode(paramType))
 }
 
-// paramsArgValues decompose and returns params values.
-func (p *Parser) paramsArgValues(tokens []obj.Token, index, lastComma *int, paramType string) oop.Val {
-	values := oop.NewListModel()
-	resultVal := oop.Val{Type: oop.List}
-	braceCount := 0
-	for ; *index < len(tokens); *index++ {
-		switch tk := tokens[*index]; tk.Type {
-		case fract.Brace:
-			switch tk.Val {
-			case "{", "[", "(":
-				braceCount++
-			default:
-				braceCount--
-			}
-		case fract.Comma:
-			if braceCount != 0 {
-				break
-			}
-			valTokens := tokens[*lastComma:*index]
-			if isParamSet(valTokens) {
-				*index -= 4
-				resultVal.Data = values
-				return resultVal
-			}
-			var params bool
-			if l := len(valTokens); valTokens[l-1].Type == fract.Params {
-				tk = valTokens[l-1]
-				params = true
-				valTokens = valTokens[:l-1]
-			}
-			val := p.procFuncArgVal(valTokens, paramType)
-			if params {
-				if val.Type != oop.List {
-					fract.IPanic(tk, obj.ValuePanic, "Notation is can used for only lists!")
-				}
-				values.PushBack(val.Data.(*oop.ListModel).Elems...)
-			} else {
-				values.PushBack(val)
-			}
-			valTokens = nil
-			*lastComma = *index + 1
-		}
-	}
-	if *lastComma < len(tokens) {
-		valTokens := tokens[*lastComma:]
-		if isParamSet(valTokens) {
-			*index -= 4
-			return resultVal
-		}
-		var params bool
-		var tk obj.Token
-		if l := len(valTokens); valTokens[l-1].Type == fract.Params {
-			tk = valTokens[l-1]
-			params = true
-			valTokens = valTokens[:l-1]
-		}
-		val := p.procFuncArgVal(valTokens, paramType)
-		if params {
-			if val.Type != oop.List {
-				fract.IPanic(tk, obj.ValuePanic, "Notation is can used for only lists!")
-			}
-			values.PushBack(val.Data.(*oop.ListModel).Elems...)
-		} else {
-			values.PushBack(val)
-		}
-		valTokens = nil
-	}
-	resultVal.Data = values
-	return resultVal
+// Arguments of function call.
+type funcArgs struct {
+	fn      *oop.Fn
+	args    []oop.VarDef
+	count   int            // Count of positional arguments.
+	keyword bool           // Keyword argument is given.
+	cur     *oop.Var       // Variable of current argument.
+	typ     string         // Type of current parameter.
+	params  *oop.ListModel // Values of params parameter, nil if current parameter is not params.
 }
 
-type funcArgInfo struct {
-	fn        *oop.Fn
-	names     *[]string
-	tokens    []obj.Token
-	tk        obj.Token
-	index     *int
-	count     *int
-	lastComma *int
+func newFuncArgs(fn *oop.Fn) *funcArgs {
+	return &funcArgs{fn: fn, args: make([]oop.VarDef, len(fn.Params))}
 }
 
-// Process function argument.
-func (p *Parser) processFuncArg(inf funcArgInfo) *oop.Var {
-	var paramSet bool
-	length := *inf.index - *inf.lastComma
-	if length < 1 {
-		fract.IPanic(inf.tk, obj.SyntaxPanic, "Value is not given!")
-	} else if *inf.count >= len(inf.fn.Params) {
-		fract.IPanic(inf.tk, obj.SyntaxPanic, "Argument overflow!")
+// next sets parameter of next argument and returns type of parameter.
+func (a *funcArgs) next(name, tk obj.Token, spread bool) string {
+	// Positional arguments after params parameter are values of params.
+	if name.Val == "" && a.params != nil {
+		return a.typ
 	}
-	param := inf.fn.Params[*inf.count]
-	resultVar := &oop.Var{Name: param.Name}
-	valTokens := inf.tokens[*inf.lastComma:*inf.index]
-	inf.tk = valTokens[0]
-	// Check param set.
-	if length >= 2 && isParamSet(valTokens) {
-		length -= 2
-		if length < 1 {
-			fract.IPanic(inf.tk, obj.SyntaxPanic, "Value is not given!")
-		}
-		for _, param := range inf.fn.Params {
-			if param.Name == inf.tk.Val {
-				for _, name := range *inf.names {
-					if name == inf.tk.Val {
-						fract.IPanic(inf.tk, obj.SyntaxPanic, "Keyword argument repeated!")
-					}
-				}
-				*inf.count++
-				paramSet = true
-				*inf.names = append(*inf.names, inf.tk.Val)
-				resultVar.Name = inf.tk.Val
-				//Parameter is params typed?
-				if param.Params {
-					*inf.lastComma += 2
-					resultVar.Val = p.paramsArgValues(inf.tokens, inf.index, inf.lastComma, param.Type)
-				} else {
-					resultVar.Val = p.procFuncArgVal(valTokens[2:], param.Type)
-				}
-				valTokens = nil
-				resultVar.Val.Const = param.Type == "const" || param.Type == "const var" || param.Type == "const mut"
-				return resultVar
+	a.params = nil
+	index := a.count
+	if name.Val != "" { // Keyword argument.
+		index = -1
+		for i, param := range a.fn.Params {
+			if param.Name == name.Val {
+				index = i
+				break
 			}
 		}
-		fract.IPanic(inf.tk, obj.NamePanic, "Parameter is not defined in this name: "+inf.tk.Val)
-	}
-	if paramSet {
-		fract.IPanic(inf.tk, obj.SyntaxPanic, "After the parameter has been given a special value, all parameters must be shown privately!")
+		if index == -1 {
+			fract.IPanic(name, obj.NamePanic, "Parameter is not defined in this name: "+name.Val)
+		} else if a.args[index] != nil {
+			fract.IPanic(name, obj.SyntaxPanic, "Keyword argument repeated!")
+		}
+		a.keyword = true
+	} else if a.keyword {
+		fract.IPanic(tk, obj.SyntaxPanic, "After the parameter has been given a special value, all parameters must be shown privately!")
+	} else if a.count >= len(a.fn.Params) {
+		fract.IPanic(tk, obj.SyntaxPanic, "Argument overflow!")
+	} else {
+		a.count++
 	}
-	*inf.count++
-	*inf.names = append(*inf.names, resultVar.Name)
+	param := a.fn.Params[index]
+	a.typ = param.Type
+	a.cur = &oop.Var{Name: param.Name}
 	// Parameter is params typed?
 	if param.Params {
-		resultVar.Val = p.paramsArgValues(inf.tokens, inf.index, inf.lastComma, param.Type)
-	} else {
-		resultVar.Val = p.procFuncArgVal(valTokens, param.Type)
+		a.params = oop.NewListModel()
+		a.cur.Val = oop.Val{Data: a.params, Type: oop.List}
+	} else if spread {
+		fract.IPanic(tk, obj.SyntaxPanic, "Invalid syntax!")
 	}
-	resultVar.Val.Const = param.Type == "const" || param.Type == "const var" || param.Type == "const mut"
-	valTokens = nil
-	return resultVar
+	a.cur.Val.Const = param.Type == "const" || param.Type == "const var" || param.Type == "const mut"
+	a.args[index] = a.cur
+	return param.Type
 }
 
-// Process function call model and initialize model instance.
-func (p *Parser) funcCallModel(fn *oop.Fn, tokens []obj.Token) *funcCall {
-	var (
-		names    []string
-		args     []oop.VarDef
-		argCount = 0
-		tk       = tokens[0]
-	)
-	// Decompose arguments.
-	tokens = decomposeBrace(&tokens)
-	var (
-		inf = funcArgInfo{
-			fn:        fn,
-			names:     &names,
-			tk:        tk,
-			tokens:    tokens,
-			count:     &argCount,
-			index:     new(int),
-			lastComma: new(int),
-		}
-		braceCount = 0
-	)
-	for *inf.index = 0; *inf.index < len(tokens); *inf.index++ {
-		switch inf.tk = tokens[*inf.index]; inf.tk.Type {
-		case fract.Brace:
-			switch inf.tk.Val {
-			case "{", "[", "(":
-				braceCount++
-			default:
-				braceCount--
-			}
-		case fract.Comma:
-			if braceCount != 0 {
-				break
-			}
-			args = append(args, p.processFuncArg(inf))
-			*inf.lastComma = *inf.index + 1
-		}
+// push value of current argument.
+func (a *funcArgs) push(val oop.Val, spread bool, tk obj.Token) {
+	if a.params == nil {
+		val.Const = a.cur.Val.Const
+		a.cur.Val = val
+		return
 	}
-	if *inf.lastComma < len(tokens) {
-		inf.tk = tokens[*inf.lastComma]
-		tkslen := len(tokens)
-		inf.index = &tkslen
-		args = append(args, p.processFuncArg(inf))
+	if !spread {
+		a.params.PushBack(val)
+		return
 	}
-	tokens = nil
-	inf.count = nil
-	inf.index = nil
-	inf.lastComma = nil
-	inf.names = nil
+	if val.Type != oop.List {
+		fract.IPanic(tk, obj.ValuePanic, "Notation is can used for only lists!")
+	}
+	a.params.PushBack(val.Data.(*oop.ListModel).Elems...)
+}
+
+// done checks required parameters and returns arguments with default values.
+func (a *funcArgs) done(tk obj.Token) []oop.VarDef {
 	// All parameters is not defined?
-	if argCount < len(fn.Params)-fn.DefaultParamCount {
-		var sb strings.Builder
-		sb.WriteString("All required positional arguments is not given:")
-		for _, p := range fn.Params {
-			if p.DefaultVal.Data != nil {
-				break
-			}
-			msg := " '" + p.Name + "',"
-			for _, name := range names {
-				if p.Name == name {
-					msg = ""
-					break
-				}
-			}
-			sb.WriteString(msg)
+	var sb strings.Builder
+	for i, param := range a.fn.Params {
+		if a.args[i] == nil && param.DefaultVal.Data == nil {
+			sb.WriteString(" '" + param.Name + "',")
 		}
-		fract.IPanic(tk, obj.PlainPanic, sb.String()[:sb.Len()-1])
+	}
+	if sb.Len() > 0 {
+		fract.IPanic(tk, obj.PlainPanic, "All required positional arguments is not given:"+sb.String()[:sb.Len()-1])
 	}
 	// Check default values.
-	for ; argCount < len(fn.Params); argCount++ {
-		param := fn.Params[argCount]
-		if param.DefaultVal.Data != nil {
-			args = append(args, &oop.Var{Name: param.Name, Val: param.DefaultVal})
+	for i, param := range a.fn.Params {
+		if a.args[i] == nil {
+			a.args[i] = &oop.Var{Name: param.Name, Val: param.DefaultVal}
 		}
 	}
-	return &funcCall{fn: fn, errTk: tk, args: args}
+	return a.args
 }
 
-// Set arguments to parameters of function.
-func (p *Parser) setParams(fn *oop.Fn, tokens *[]obj.Token) {
-	paramName, params, defaultDef, valType := true, false, false, ""
-	braceCount := 0
-	var param oop.Param
-	for i := 0; i < len(*tokens); i++ {
-		tk := (*tokens)[i]
-		if tk.Type == fract.Brace {
-			switch tk.Val {
-			case "{", "[", "(":
-				braceCount++
-			default:
-				braceCount--
-			}
-		}
-		if braceCount > 0 {
-			continue
-		}
-		if paramName {
-			switch tk.Type {
-			case fract.Params:
-				if params {
-					fract.IPanic(tk, obj.SyntaxPanic, "Invalid syntax!")
-				}
-				params = true
-				continue
-			case fract.Name:
-				if !isValidName(tk.Val) {
-					fract.IPanic(tk, obj.NamePanic, "Invalid name!")
-				} else if tk.Val == fn.Name {
-					fract.IPanic(tk, obj.NamePanic, "Parameter name is not same with function name!")
-				}
-			case fract.Var:
-				if valType != "" || params {
-					if !params {
-						if valType == "const" && tk.Val != "const" {
-							valType += " " + tk.Val
-							continue
-						}
-					}
-					fract.IPanic(tk, obj.SyntaxPanic, "Invalid syntax!")
-				}
-				valType = tk.Val
-				continue
-			default:
-				fract.IPanic(tk, obj.SyntaxPanic, "Parameter name is not found!")
-			}
-			param = oop.Param{Name: tk.Val, Params: params, Type: valType}
-			fn.Params = append(fn.Params, param)
-			paramName = false
-			valType = ""
-			continue
-		} else {
-			paramName = true
-			// Default value definition?
-			if tk.Val == "=" {
-				braceCount := 0
-				i++
-				start := i
-				for ; i < len(*tokens); i++ {
-					tk = (*tokens)[i]
-					if tk.Type == fract.Brace {
-						switch tk.Val {
-						case "{", "[", "(":
-							braceCount++
-						default:
-							braceCount--
-						}
-					} else if tk.Type == fract.Comma {
-						break
-					}
-				}
-				if i-start < 1 {
-					fract.IPanic((*tokens)[start-1], obj.SyntaxPanic, "Value is not given!")
-				}
-				param.DefaultVal = *p.processValTokens((*tokens)[start:i])
-				if param.Params && param.DefaultVal.Type != oop.List {
-					fract.IPanic(tk, obj.ValuePanic, "Params parameter is can only take list values!")
-				}
-				fn.Params[len(fn.Params)-1] = param
-				fn.DefaultParamCount++
-				defaultDef = true
-				continue
-			}
-			if param.DefaultVal.Data == nil && defaultDef {
-				fract.IPanic(tk, obj.SyntaxPanic, "All parameters after a given parameter with a default value must take a default value!")
-			} else if tk.Type != fract.Comma {
-				fract.IPanic(tk, obj.SyntaxPanic, "Comma is not found!")
+// Process function call model and initialize model instance.
+func (p *Parser) funcCallModel(fn *oop.Fn, call *ast.Call) *funcCall {
+	args := newFuncArgs(fn)
+	for _, arg := range call.Args {
+		tk := arg.Val.Token()
+		args.push(p.procFuncArgVal(arg.Val, args.next(arg.Name, tk, arg.Spread)), arg.Spread, tk)
+	}
+	return &funcCall{fn: fn, errTk: call.Tk, args: args.done(call.Tk), rt: p.rt}
+}
+
+// Set parameters of function.
+func (p *Parser) setParams(fn *oop.Fn, params []ast.Param) {
+	for _, param := range params {
+		fnParam := oop.Param{Name: param.Name.Val, Params: param.Params, Type: param.Type}
+		if param.Default != nil {
+			fnParam.DefaultVal = *p.processVal(param.Default)
+			if param.Params && fnParam.DefaultVal.Type != oop.List {
+				fract.IPanic(param.Default.Token(), obj.ValuePanic, "Params parameter is can only take list values!")
 			}
+			fn.DefaultParamCount++
 		}
-	}
-	if param.DefaultVal.Data == nil && defaultDef {
-		fract.IPanic((*tokens)[len(*tokens)-1], obj.SyntaxPanic, "All parameters after a given parameter with a default value must take a default value!")
+		fn.Params = append(fn.Params, fnParam)
 	}
 }
 
 // Process function declaration to defmap.
-func (p *Parser) ffuncdec(defs *oop.DefMap, tokens []obj.Token) {
-	tokensLen := len(tokens)
-	nameTk := tokens[1]
-	// Name is not name?
-	if nameTk.Type != fract.Name || !isValidName(nameTk.Val) {
-		fract.IPanic(nameTk, obj.SyntaxPanic, "Invalid name!")
-	}
-	// Name is already defined?
-	var ln int
-	if &p.defs == defs { // Variable added to defmap of parser.
-		ln = p.defLineByName(nameTk.Val)
-	} else { // Variable added to another defmap.
-		ln = defs.DefIndexByName(nameTk.Val)
+func (p *Parser) ffuncdec(defs *oop.DefMap, s *ast.FuncDecl) {
+	p.checkDefined(defs, s.Name)
+	fn := &oop.Fn{
+		Name:      s.Name.Val,
+		Line:      s.Name.Line,
+		Src:       p,
+		Block:     s.Body,
+		Generator: s.Generator,
 	}
-	if ln != -1 {
-		fract.IPanic(nameTk, obj.NamePanic, "\""+nameTk.Val+"\" is already defined at line: "+fmt.Sprint(ln))
+	p.setParams(fn, s.Params)
+	defs.Funcs = append(defs.Funcs, fn)
+}
+
+// Process function declaration to defmap of parser.
+func (p *Parser) funcdec(s *ast.FuncDecl) {
+	p.ffuncdec(&p.defs, s)
+	// Function is captured by itself for recursive calls.
+	p.closure(p.defs.Funcs[len(p.defs.Funcs)-1])
+}
+
+// closure captures defines of local scope to function if function is declared in local scope.
+// Functions of top-level of source are not captured, defines of source are visible to them.
+func (p *Parser) closure(fn *oop.Fn) {
+	if p.fn != nil || p.depth > 0 {
+		p.capture(fn)
 	}
+}
 
-	if tokensLen < 3 {
-		fract.IPanicC(nameTk.File, nameTk.Line, nameTk.Column+len(nameTk.Val), obj.SyntaxPanic, "Invalid syntax!")
+// capture local defines of parser to function.
+// Variables are captured by reference, assignments are visible to both of function and scope.
+// Defines of source are not captured, they are visible by source of function.
+// Functions that declared to scope after capture are visible to function while scope is alive.
+func (p *Parser) capture(fn *oop.Fn) {
+	fn.Scope = &oop.Scope{
+		Defs: oop.DefMap{
+			Vars:  append(append([]oop.VarDef(nil), p.defs.Vars[:p.srcVars[0]]...), p.defs.Vars[p.srcVars[1]:]...),
+			Funcs: append(append([]*oop.Fn(nil), p.defs.Funcs[:p.srcFuncs[0]]...), p.defs.Funcs[p.srcFuncs[1]:]...),
+		},
+		Outer: &p.defs,
+		FnLen: len(p.defs.Funcs),
 	}
-	fn := &oop.Fn{
-		Name: nameTk.Val,
-		Line: p.index,
-		Src:  p,
+	// Defines of source are visible by source of function in process.
+	if p.fn != nil {
+		fn.Src = p.fn.Src
 	}
-	// Decompose function arguments.
-	if tokens[2].Val == "(" {
-		tokens = tokens[2:]
-		r := decomposeBrace(&tokens)
-		p.setParams(fn, &r)
-		r = nil
-	} else {
-		tokens = tokens[2:]
+}
+
+// Func returns function by name, returns nil if not defined.
+func (p *Parser) Func(name string) *oop.Fn {
+	if i := p.defs.FuncIndexByName(name); i != -1 {
+		return p.defs.Funcs[i]
 	}
-	fn.Tokens = p.getBlock(tokens)
-	if fn.Tokens == nil {
-		fn.Tokens = [][]obj.Token{}
+	return nil
+}
+
+// Var returns variable by name, returns nil if not defined.
+func (p *Parser) Var(name string) oop.VarDef {
+	if i := p.defs.VarIndexByName(name); i != -1 {
+		return p.defs.Vars[i]
 	}
-	fn.Line = nameTk.Line
-	defs.Funcs = append(defs.Funcs, fn)
+	return nil
 }
 
-// Process function declaration to defmap of parser.
-func (p *Parser) funcdec(tks []obj.Token) { p.ffuncdec(&p.defs, tks) }
+// CallFunc calls function by positional arguments and returns result.
+// Returns error if function is panicked.
+func (p *Parser) CallFunc(fn *oop.Fn, args []oop.Val) (val oop.Val, err error) {
+	deferLen := len(p.rt.defers)
+	callLen := len(p.rt.calls)
+	frameLen := len(p.rt.frames)
+	defer func() {
+		if r := recover(); r != nil {
+			err = p.rt.error(r)
+			p.rt.defers = p.rt.defers[:deferLen]
+			p.rt.calls = p.rt.calls[:callLen]
+			p.rt.leave(frameLen)
+		}
+	}()
+	// Calls from host are not have a position in code.
+	tk := obj.Token{
+		File:   &obj.File{Path: "<host>", Lines: []string{fn.Name + "(...)"}},
+		Val:    fn.Name,
+		Type:   fract.Name,
+		Line:   1,
+		Column: 1,
+	}
+	return p.rt.callFunc(fn, tk, args...), nil
+}
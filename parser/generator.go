@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"github.com/fract-lang/fract/ast"
+	"github.com/fract-lang/fract/oop"
+	"github.com/fract-lang/fract/pkg/fract"
+)
+
+// Generator is iterator of generator function call.
+// Function is processed by own goroutine when first value is requested
+// and suspended at each yield until next value is requested.
+// Consumer and function are never run at same time.
+type generator struct {
+	call    *funcCall
+	resume  chan bool    // True for next value, false for close.
+	yields  chan oop.Val // Closed when function is ended.
+	err     interface{}  // Panic of function, nil if not panicked.
+	started bool
+	done    bool
+}
+
+func newGenerator(c *funcCall) *generator {
+	g := &generator{resume: make(chan bool), yields: make(chan oop.Val)}
+	// Function has own runtime like goroutines, defers and calls of
+	// function are kept while it is suspended.
+	g.call = &funcCall{fn: c.fn, errTk: c.errTk, args: c.args, rt: c.rt.fork(), gen: g}
+	return g
+}
+
+func (g *generator) run() {
+	defer func() {
+		if r := recover(); r != nil {
+			g.err = r
+		}
+		close(g.yields)
+	}()
+	if <-g.resume {
+		g.call.Call()
+	}
+}
+
+// Next resumes function until next yield.
+// Panics of function are panicked again by consumer.
+func (g *generator) Next() (oop.Val, bool) {
+	if g.done {
+		return oop.Val{Data: "none", Type: oop.None}, false
+	}
+	if !g.started {
+		g.started = true
+		go g.run()
+	}
+	g.resume <- true
+	if val, ok := <-g.yields; ok {
+		return val, true
+	}
+	g.finish()
+	return oop.Val{Data: "none", Type: oop.None}, false
+}
+
+// Close ends suspended function like a return from yield,
+// so defers and finally blocks of function are run.
+func (g *generator) Close() {
+	if g.done {
+		return
+	} else if !g.started {
+		g.done = true
+		return
+	}
+	g.resume <- false
+	for range g.yields {
+		// Yields of finally blocks are ignored.
+		g.resume <- false
+	}
+	g.finish()
+}
+
+// finish marks generator as done and panics again panic of function.
+func (g *generator) finish() {
+	g.done = true
+	if r := g.err; r != nil {
+		g.err = nil
+		panic(r)
+	}
+}
+
+// yield sends value to consumer and waits until next value is requested.
+// Returns false if generator is closed.
+func (g *generator) yield(val oop.Val) bool {
+	g.yields <- val
+	return <-g.resume
+}
+
+// processYield sends value to consumer of generator.
+// Returns return state if generator is closed, function ends like returned.
+func (p *Parser) processYield(s *ast.Yield) uint8 {
+	if !p.gen.yield(*p.processVal(s.Val)) {
+		return fract.FUNCReturn
+	}
+	return fract.NA
+}
@@ -4,36 +4,32 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
-	"path"
+	"path/filepath"
 	"strings"
 
+	"github.com/fract-lang/fract/ast"
 	"github.com/fract-lang/fract/pkg/fract"
 	"github.com/fract-lang/fract/pkg/obj"
 )
 
 // Import content into destination interpeter.
 func (p *Parser) Import() {
+	defer p.rt.leave(p.rt.enter(p))
 	// Interpret all lines.
-	for p.index = 1; p.index < len(p.Tokens); p.index++ {
-		switch tokens := p.Tokens[p.index]; tokens[0].Type {
-		case fract.Var:
-			p.vardec(tokens)
-		case fract.Func:
-			p.funcdec(tokens)
-		case fract.Struct:
-			p.structdec(tokens)
-		case fract.Class:
-			p.classdec(tokens)
-		case fract.Import: // Import.
-			src := new(Parser)
-			src.AddBuiltInFuncs()
-			src.processImport(tokens)
-			p.defs.Vars = append(p.defs.Vars, src.defs.Vars...)
-			p.defs.Funcs = append(p.defs.Funcs, src.defs.Funcs...)
-			p.packages = append(p.packages, src.packages...)
-		case fract.Macro: // Macro.
-			p.processPragma(tokens)
-			if p.loopCount != -1 { // Breaked import.
+	for _, stmt := range p.tree.Stmts {
+		switch s := stmt.(type) {
+		case *ast.VarDecl:
+			p.vardec(s)
+		case *ast.FuncDecl:
+			p.funcdec(s)
+		case *ast.StructDecl:
+			p.structdec(s)
+		case *ast.ClassDecl:
+			p.classdec(s)
+		case *ast.Import: // Import.
+			p.processImport(s)
+		case *ast.Pragma: // Pragma.
+			if p.processPragma(s) { // Breaked import.
 				return
 			}
 		}
@@ -47,7 +43,7 @@ type importInfo struct {
 	line int     // Defined line.
 }
 
-func importDirectory(dir string) (*importInfo, error) {
+func (p *Parser) importDirectory(dir string) (*importInfo, error) {
 	info, err := os.Stat(dir)
 	// Exists directory?
 	if dir != "" && (err != nil || !info.IsDir()) {
@@ -57,26 +53,28 @@ func importDirectory(dir string) (*importInfo, error) {
 	if err != nil {
 		return nil, fmt.Errorf("there is a problem on import: " + err.Error())
 	}
-	src := &Parser{}
+	src := &Parser{rt: p.rt}
 	src.AddBuiltInFuncs()
 	for _, i := range infos {
 		// Skip directories.
 		if i.IsDir() || !strings.HasSuffix(i.Name(), fract.Extension) {
 			continue
 		}
-		impSrc := New(dir + string(os.PathSeparator) + i.Name())
-		impSrc.loopCount = -1 //! Tag as import source.
+		impSrc := New(p.rt, filepath.Join(dir, i.Name()))
+		impSrc.Lex.File.Builtin = dir == p.rt.StdLib
+		impSrc.importing = true
 		impSrc.ready()
 		impSrc.AddBuiltInFuncs()
 		builtinFuncLen := len(impSrc.defs.Funcs)
+		builtinVarLen := len(impSrc.defs.Vars)
 		impSrc.Import()
 		impSrc.importPackage() // Import other package files.
-		if dir != fract.StdLib {
+		if dir != p.rt.StdLib {
 			impSrc.importStdlibLocal()
 		}
-		impSrc.loopCount = 0
+		impSrc.importing = false
 		src.defs.Funcs = append(src.defs.Funcs, impSrc.defs.Funcs[builtinFuncLen:]...)
-		src.defs.Vars = append(src.defs.Vars, impSrc.defs.Vars...)
+		src.defs.Vars = append(src.defs.Vars, impSrc.defs.Vars[builtinVarLen:]...)
 		src.packages = append(src.packages, impSrc.packages...)
 		src.packageName = impSrc.packageName
 		break
@@ -85,10 +83,10 @@ func importDirectory(dir string) (*importInfo, error) {
 }
 
 func (p *Parser) importStdlibLocal() {
-	if p.Lex.File.Path == fract.StdLib {
+	if p.Lex.File.Path == p.rt.StdLib {
 		return
 	}
-	imp, err := importDirectory(fract.StdLib)
+	imp, err := p.importDirectory(p.rt.StdLib)
 	if err != nil {
 		fract.Error(p.Lex.File, 0, 0, err.Error())
 	}
@@ -97,46 +95,50 @@ func (p *Parser) importStdlibLocal() {
 	imp = nil
 }
 
-func (p *Parser) processImport(tokens []obj.Token) {
-	if len(tokens) == 1 {
-		fract.IPanic(tokens[0], obj.SyntaxPanic, "Import path is not given!")
-	}
-	if tokens[1].Type != fract.Name && (tokens[1].Type != fract.Value || tokens[1].Val[0] != '"' && tokens[1].Val[0] != '.') {
-		fract.IPanic(tokens[1], obj.ValuePanic, "Import path should be string or standard path!")
-	}
-	j := 1
-	if len(tokens) > 2 {
-		if tokens[1].Type == fract.Name {
-			j = 2
-		} else {
-			fract.IPanic(tokens[1], obj.NamePanic, "Alias is should be a invalid name!")
-		}
-	}
-	if j == 1 && len(tokens) != 2 {
-		fract.IPanic(tokens[2], obj.SyntaxPanic, "Invalid syntax!")
-	} else if j == 2 && len(tokens) != 3 {
-		fract.IPanic(tokens[3], obj.SyntaxPanic, "Invalid syntax!")
-	}
+// importPath returns imported package of path.
+// std reports path is name of standard library package.
+// tk is token for errors.
+func (p *Parser) importPath(tk obj.Token, pathVal string, std bool) *importInfo {
 	var impPath string
-	if tokens[j].Type == fract.Name {
-		switch tokens[j].Val {
-		default:
-			impPath = strings.ReplaceAll(fract.StdLib+"/."+tokens[j].Val, ".", string(os.PathSeparator))
+	if std {
+		if defs, ok := p.rt.packages[pathVal]; ok { // Package of host.
+			name := pathVal[strings.LastIndexByte(pathVal, '.')+1:]
+			src := &Parser{rt: p.rt, packageName: name, Lex: p.Lex}
+			src.defs.Funcs = defs.Funcs
+			src.defs.Vars = p.rt.nativeVars(defs.Vars)
+			return &importInfo{name: name, src: src}
 		}
+		impPath = filepath.Join(p.rt.StdLib, strings.ReplaceAll(pathVal, ".", string(os.PathSeparator)))
 	} else {
-		impPath = tokens[0].File.Path[:strings.LastIndex(tokens[0].File.Path, string(os.PathSeparator))+1] + p.processValTokens([]obj.Token{tokens[j]}).String()
+		impPath = tk.File.Path[:strings.LastIndex(tk.File.Path, string(os.PathSeparator))+1] + pathVal
 	}
-	imp, err := importDirectory(path.Join(fract.ExecutablePath, impPath))
+	imp, err := p.importDirectory(impPath)
 	if err != nil {
-		tk := tokens[1]
 		fract.Error(tk.File, tk.Line, tk.Column, err.Error())
 	}
-	imp.line = tokens[0].Line
-	if j == 2 { // Alias.
-		imp.name = tokens[1].Val
+	return imp
+}
+
+// addPackage appends imported package to parser.
+func (p *Parser) addPackage(imp *importInfo, alias string, tk obj.Token) {
+	imp.line = tk.Line
+	if alias != "" { // Alias.
+		imp.name = alias
 	}
 	if ln := p.defLineByName(imp.name); ln != -1 {
-		fract.IPanic(tokens[0], obj.NamePanic, "\""+imp.name+"\" is already defined at line: "+fmt.Sprint(ln))
+		fract.IPanic(tk, obj.NamePanic, "\""+imp.name+"\" is already defined at line: "+fmt.Sprint(ln))
 	}
 	p.packages = append(p.packages, imp)
 }
+
+func (p *Parser) processImport(s *ast.Import) {
+	tk := s.Path
+	if s.Alias.Val != "" {
+		tk = s.Alias
+	}
+	pathVal := s.Path.Val
+	if s.Path.Type != fract.Name {
+		pathVal = pathVal[1 : len(pathVal)-1]
+	}
+	p.addPackage(p.importPath(tk, pathVal, s.Path.Type == fract.Name), s.Alias.Val, s.Tk)
+}
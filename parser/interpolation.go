@@ -0,0 +1,68 @@
+package parser
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/fract-lang/fract/ast"
+	"github.com/fract-lang/fract/oop"
+	"github.com/fract-lang/fract/pkg/fract"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// interpolate returns string of interpolated string literal.
+func (p *Parser) interpolate(s *ast.Interpolation) string {
+	var sb strings.Builder
+	for i, e := range s.Exprs {
+		sb.WriteString(s.Texts[i])
+		sb.WriteString(format(*p.processVal(e), s.Formats[i], e.Token()))
+	}
+	sb.WriteString(s.Texts[len(s.Texts)-1])
+	return sb.String()
+}
+
+// format returns string of value by format specifier of interpolation.
+//
+// Specifier is flags, width, precision and verb like fmt package of Go.
+// Flag "-" is left alignment, "+" is sign and "0" is zero padding.
+// Values are formatted like printed if verb is not given, precision of
+// numbers is count of decimals and precision of others is max length.
+//
+//	s             -> String of value.
+//	d, x, X, o, b -> Integer in decimal, hexadecimal, octal or binary.
+//	f, e, E, g, G -> Numeric in floating-point notations.
+func format(val oop.Val, spec string, tk obj.Token) string {
+	if spec == "" {
+		return val.String()
+	}
+	verb := spec[len(spec)-1]
+	if verb >= '0' && verb <= '9' || verb == '-' || verb == '+' {
+		verb = 's'
+		if val.IsNum() && strings.Contains(spec, ".") {
+			verb = 'f'
+		}
+	} else {
+		spec = spec[:len(spec)-1]
+	}
+	spec = "%" + spec + string(verb)
+	switch verb {
+	case 's':
+		return fmt.Sprintf(spec, val.String())
+	case 'd', 'x', 'X', 'o', 'b':
+		if val.Type != oop.Int && val.Type != oop.BigInt {
+			fract.Panic(tk, obj.ValuePanic, "Value is must be integer for \""+string(verb)+"\" format!")
+		}
+		return fmt.Sprintf(spec, val.Data)
+	}
+	switch val.Type {
+	case oop.Int, oop.Float:
+		return fmt.Sprintf(spec, val.Float64())
+	case oop.BigInt:
+		return fmt.Sprintf(spec, new(big.Float).SetInt(val.BigInt()))
+	case oop.Decimal:
+		return fmt.Sprintf(spec, new(big.Float).SetPrec(256).SetRat(val.Decimal().Rat()))
+	}
+	fract.Panic(tk, obj.ValuePanic, "Value is must be numeric for \""+string(verb)+"\" format!")
+	return ""
+}
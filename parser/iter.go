@@ -0,0 +1,170 @@
+package parser
+
+import (
+	"github.com/fract-lang/fract/functions"
+	"github.com/fract-lang/fract/oop"
+	"github.com/fract-lang/fract/pkg/fract"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// Iterator protocol of classes.
+//
+// Instances of classes that have Next and Value methods are iterators.
+// Next moves to next value and returns false if values are finished,
+// Value returns current value. Close method is optional, it is called
+// if iteration is ended before values are finished.
+//
+// Instances of classes that have Iter method are enumerables, Iter returns
+// new iterator for each iteration; a generator or an iterator instance.
+// Len and Contains methods are optional, lengths and membership tests of
+// instances are processed by iteration if they are not defined.
+
+// method returns method of class instance by name, returns nil if not defined.
+func method(ins oop.ClassInstance, name string) *oop.Fn {
+	if i := ins.Defs.FuncIndexByName(name); i != -1 {
+		return ins.Defs.Funcs[i]
+	}
+	return nil
+}
+
+// isIterator returns true if class instance is iterator, returns false if not.
+func isIterator(ins oop.ClassInstance) bool {
+	return method(ins, "Next") != nil && method(ins, "Value") != nil
+}
+
+// isIterable returns true if value is iterable by foreach loops.
+func isIterable(val oop.Val) bool {
+	switch val.Type {
+	case oop.Chan, oop.Iter:
+		return true
+	case oop.ClassIns:
+		ins := val.Data.(oop.ClassInstance)
+		return method(ins, "Iter") != nil || isIterator(ins)
+	}
+	return val.IsEnum()
+}
+
+// callFunc calls function by positional arguments and returns result.
+func (rt *Runtime) callFunc(fn *oop.Fn, tk obj.Token, args ...oop.Val) oop.Val {
+	fnArgs := newFuncArgs(fn)
+	for _, arg := range args {
+		fnArgs.next(obj.Token{}, tk, false)
+		fnArgs.push(arg, false, tk)
+	}
+	c := &funcCall{fn: fn, errTk: tk, args: fnArgs.done(tk), rt: rt}
+	return *c.Call()
+}
+
+// classIterator is iterator of iterator instance.
+type classIterator struct {
+	rt    *Runtime
+	tk    obj.Token // Token of method calls.
+	next  *oop.Fn
+	value *oop.Fn
+	close *oop.Fn // Nil if not defined.
+	done  bool
+}
+
+func (it *classIterator) Next() (oop.Val, bool) {
+	if it.done {
+		return oop.Val{Data: "none", Type: oop.None}, false
+	}
+	next := it.rt.callFunc(it.next, it.tk)
+	if next.Type != oop.Bool {
+		fract.Panic(it.tk, obj.ValuePanic, "Next method is must return boolean!")
+	} else if next.Data == false {
+		it.done = true
+		return oop.Val{Data: "none", Type: oop.None}, false
+	}
+	return it.rt.callFunc(it.value, it.tk), true
+}
+
+func (it *classIterator) Close() {
+	if it.done {
+		return
+	}
+	it.done = true
+	if it.close != nil {
+		it.rt.callFunc(it.close, it.tk)
+	}
+}
+
+// iterOf returns iterator value of class instance.
+func (rt *Runtime) iterOf(ins oop.ClassInstance, tk obj.Token) oop.Val {
+	if fn := method(ins, "Iter"); fn != nil {
+		val := rt.callFunc(fn, tk)
+		if val.Type == oop.Iter {
+			return val
+		} else if val.Type != oop.ClassIns || !isIterator(val.Data.(oop.ClassInstance)) {
+			fract.Panic(tk, obj.ValuePanic, "Iter method is must return iterator!")
+		}
+		ins = val.Data.(oop.ClassInstance)
+	}
+	return oop.Val{
+		Data: &classIterator{
+			rt:    rt,
+			tk:    tk,
+			next:  method(ins, "Next"),
+			value: method(ins, "Value"),
+			close: method(ins, "Close"),
+		},
+		Type: oop.Iter,
+	}
+}
+
+// iterate returns iterator of iterable value.
+func (rt *Runtime) iterate(val oop.Val, tk obj.Token) *iterator {
+	if val.Type == oop.ClassIns {
+		val = rt.iterOf(val.Data.(oop.ClassInstance), tk)
+	}
+	return newIterator(val)
+}
+
+// contains returns true if class instance or iterator contains value, returns false if not.
+// Iterators are consumed until value is found.
+func (rt *Runtime) contains(val, elem oop.Val, tk obj.Token) bool {
+	if val.Type == oop.ClassIns {
+		if fn := method(val.Data.(oop.ClassInstance), "Contains"); fn != nil {
+			result := rt.callFunc(fn, tk, elem)
+			if result.Type != oop.Bool {
+				fract.Panic(tk, obj.ValuePanic, "Contains method is must return boolean!")
+			}
+			return result.Data.(bool)
+		} else if !isIterable(val) {
+			fract.IPanic(tk, obj.ValuePanic, "Value is should be enumerable!")
+		}
+	}
+	it := rt.iterate(val, tk)
+	defer it.close()
+	for it.next() {
+		if it.b.Equals(elem) {
+			return true
+		}
+	}
+	return false
+}
+
+// len is built-in len function by runtime of caller.
+// Class instances are use Len method if defined.
+// Lengths of iterators are counts of values, iterators are consumed.
+func (rt *Runtime) len(tk obj.Token, args []oop.VarDef) oop.Val {
+	val := args[0].Val
+	if val.Type == oop.ClassIns {
+		if fn := method(val.Data.(oop.ClassInstance), "Len"); fn != nil {
+			result := rt.callFunc(fn, tk)
+			if result.Type != oop.Int {
+				fract.Panic(tk, obj.ValuePanic, "Len method is must return integer!")
+			}
+			return result
+		}
+	}
+	if val.Type != oop.Iter && (val.Type != oop.ClassIns || !isIterable(val)) {
+		return functions.Len(tk, args)
+	}
+	it := rt.iterate(val, tk)
+	var n int64
+	for it.next() {
+		n++
+	}
+	return oop.Val{Data: n, Type: oop.Int}
+}
@@ -1,53 +1,105 @@
 package parser
 
 import (
-	"fmt"
+	"unicode/utf8"
 
+	"github.com/fract-lang/fract/ast"
 	"github.com/fract-lang/fract/oop"
 	"github.com/fract-lang/fract/pkg/fract"
 	"github.com/fract-lang/fract/pkg/obj"
 )
 
-// Loop.
-type loop struct {
-	a         oop.Val
-	b         oop.Val
-	val       oop.Val
-	breakLoop bool
+// Iterator of enumerable value.
+type iterator struct {
+	val   oop.Val
+	elems oop.ListType // Elements of list.
+	keys  []oop.Val    // Keys of map.
+	pos   int
+	a     oop.Val // Index or key.
+	b     oop.Val // Element.
 }
 
-func (l *loop) run(b func()) {
-	switch l.val.Type {
+func newIterator(val oop.Val) *iterator {
+	it := &iterator{val: val}
+	switch val.Type {
 	case oop.List:
-		l.a.Type = oop.Int
-		for i, e := range l.val.Data.(*oop.ListModel).Elems {
-			l.a.Data = float64(i)
-			l.b = e
-			b()
-			if l.breakLoop {
-				break
-			}
+		it.elems = val.Data.(*oop.ListModel).Elems
+	case oop.Map:
+		for k := range val.Data.(oop.MapModel).Map {
+			it.keys = append(it.keys, k)
+		}
+	}
+	return it
+}
+
+// next moves to next element, returns false if elements are finished.
+func (it *iterator) next() bool {
+	switch it.val.Type {
+	case oop.List:
+		if it.pos >= len(it.elems) {
+			return false
 		}
+		it.a = oop.Val{Data: int64(it.pos), Type: oop.Int}
+		it.b = it.elems[it.pos]
+		it.pos++
 	case oop.String:
-		l.a.Type = oop.Int
-		l.b.Type = oop.String
-		for i, e := range l.val.Data.(string) {
-			l.a.Data = float64(i)
-			l.b.Data = string(e)
-			b()
-			if l.breakLoop {
-				break
-			}
+		str := it.val.Data.(string)
+		if it.pos >= len(str) {
+			return false
 		}
+		r, size := utf8.DecodeRuneInString(str[it.pos:])
+		it.a = oop.Val{Data: int64(it.pos), Type: oop.Int}
+		it.b = oop.Val{Data: string(r), Type: oop.String}
+		it.pos += size
+	case oop.Chan:
+		v, ok := it.val.Data.(*oop.Channel).Recv()
+		if !ok {
+			return false
+		}
+		// Loops of channels are bind received values to first name.
+		it.a = v
+		it.b = v
+	case oop.Iter:
+		v, ok := it.val.Data.(oop.Iterator).Next()
+		if !ok {
+			return false
+		}
+		// Loops of iterators are bind values to first name like channels.
+		it.a = v
+		it.b = v
 	case oop.Map:
-		for k, v := range l.val.Data.(oop.MapModel).Map {
-			l.a = k
-			l.b = v
-			b()
-			if l.breakLoop {
-				break
+		m := it.val.Data.(oop.MapModel).Map
+		for it.pos < len(it.keys) {
+			k := it.keys[it.pos]
+			it.pos++
+			// Skip removed keys.
+			if v, ok := m[k]; ok {
+				it.a = k
+				it.b = v
+				return true
 			}
 		}
+		return false
+	default:
+		return false
+	}
+	return true
+}
+
+// close releases iterator of lazy values if elements are not finished.
+func (it *iterator) close() {
+	if it.val.Type == oop.Iter {
+		it.val.Data.(oop.Iterator).Close()
+	}
+}
+
+// checkElemName panics if loops of value are not takes element name.
+func checkElemName(val oop.Val, tk obj.Token) {
+	switch val.Type {
+	case oop.Chan:
+		fract.IPanic(tk, obj.ValuePanic, "Channel loops are takes only value name!")
+	case oop.Iter, oop.ClassIns:
+		fract.IPanic(tk, obj.ValuePanic, "Iterator loops are takes only value name!")
 	}
 }
 
@@ -59,159 +111,60 @@ func processKeywordState(kws uint8) uint8 {
 	return kws
 }
 
-func (p *Parser) processLoop(tokens []obj.Token) uint8 {
-	blockIndex := findBlock(tokens)
-	blockTokens, tokens := p.getBlock(tokens[blockIndex:]), tokens[1:blockIndex]
-	funcLen := len(p.defs.Funcs)
-	impLen := len(p.packages)
-	breakLoop := false
-	keywordState := fract.NA
-	parserTokens := p.Tokens
-	parserIndex := p.index
+func (p *Parser) processLoop(s *ast.Loop) uint8 {
 	//*************
 	//    WHILE
 	//*************
-	if len(tokens) == 0 || len(tokens) >= 1 {
-		if len(tokens) == 0 || len(tokens) == 1 || len(tokens) >= 1 && tokens[1].Type != fract.In && tokens[1].Type != fract.Comma {
-			varLen := len(p.defs.Vars)
-			// Infinity loop.
-			if len(tokens) == 0 {
-			infinity:
-				p.Tokens = blockTokens
-				for p.index = 0; p.index < len(p.Tokens); p.index++ {
-					keywordState = p.processExpression(p.Tokens[p.index])
-					if keywordState == fract.LOOPBreak || keywordState == fract.FUNCReturn { // Break loop or return.
-						p.Tokens = parserTokens
-						p.index = parserIndex
-						return processKeywordState(keywordState)
-					} else if keywordState == fract.LOOPContinue { // Continue loop.
-						break
-					}
-				}
-				// Remove temporary variables.
-				p.defs.Vars = p.defs.Vars[:varLen]
-				// Remove temporary functions.
-				p.defs.Funcs = p.defs.Funcs[:funcLen]
-				// Remove temporary imports.
-				p.packages = p.packages[:impLen]
-				goto infinity
-			}
-		while:
-			// Interpret/skip block.
-			condition := p.prococessCondition(tokens)
-			p.Tokens = blockTokens
-			for p.index = 0; p.index < len(p.Tokens); p.index++ {
-				// Condition is true?
-				if condition {
-					keywordState = p.processExpression(p.Tokens[p.index])
-					if keywordState == fract.LOOPBreak || keywordState == fract.FUNCReturn { // Break loop or return.
-						breakLoop = true
-						break
-					} else if keywordState == fract.LOOPContinue { // Continue loop.
-						break
-					}
-				} else {
-					breakLoop = true
-					break
-				}
-			}
-			// Remove temporary variables.
-			p.defs.Vars = p.defs.Vars[:varLen]
-			// Remove temporary functions.
-			p.defs.Funcs = p.defs.Funcs[:funcLen]
-			// Remove temporary imports.
-			p.packages = p.packages[:impLen]
-			condition = p.prococessCondition(tokens)
-			if breakLoop || !condition {
-				p.Tokens = parserTokens
-				p.index = parserIndex
+	if s.Iter == nil {
+		for s.Cond == nil || p.processCondition(s.Cond) {
+			switch keywordState := p.processBlock(s.Body); keywordState {
+			case fract.LOOPBreak, fract.FUNCReturn: // Break loop or return.
 				return processKeywordState(keywordState)
 			}
-			goto while
 		}
+		return fract.NA
 	}
 	//*************
 	//   FOREACH
 	//*************
-	nameTK := tokens[0]
-	// Name is not name?
-	if nameTK.Type != fract.Name {
-		fract.IPanic(nameTK, obj.SyntaxPanic, "This is not a valid name!")
-	}
-	if nameTK.Val != "_" {
-		if !isValidName(nameTK.Val) {
-			fract.IPanic(nameTK, obj.NamePanic, "Invalid name!")
-		}
-		if ln := p.defLineByName(nameTK.Val); ln != -1 {
-			fract.IPanic(nameTK, obj.NamePanic, "\""+nameTK.Val+"\" is already defined at line: "+fmt.Sprint(ln))
-		}
+	nameTk := s.Key
+	if nameTk.Val != "_" {
+		p.checkDefined(&p.defs, nameTk)
 	} else {
-		nameTK.Val = ""
+		nameTk.Val = ""
 	}
 	// Element name?
 	elemName := ""
-	if tokens[1].Type == fract.Comma {
-		if len(tokens) < 3 || tokens[2].Type != fract.Name {
-			fract.IPanic(tokens[1], obj.SyntaxPanic, "Element name is not defined!")
-		}
-		if tokens[2].Val != "_" {
-			elemName = tokens[2].Val
-			if !isValidName(elemName) {
-				fract.IPanic(tokens[2], obj.NamePanic, "Invalid name!")
-			}
-			if ln := p.defLineByName(tokens[2].Val); ln != -1 {
-				fract.IPanic(tokens[2], obj.NamePanic, "\""+elemName+"\" is already defined at line: "+fmt.Sprint(ln))
-			}
-		}
-		if len(tokens)-3 == 0 {
-			tokens[2].Column += len(tokens[2].Val)
-			fract.IPanic(tokens[2], obj.SyntaxPanic, "Value is not given!")
-		}
-		tokens = tokens[2:]
+	if s.Elem.Val != "" && s.Elem.Val != "_" {
+		elemName = s.Elem.Val
+		p.checkDefined(&p.defs, s.Elem)
 	}
-	if len(tokens) < 3 {
-		fract.IPanic(tokens[1], obj.SyntaxPanic, "Value is not given!")
-	} else if t := tokens[1]; t.Type != fract.In {
-		fract.IPanic(tokens[1], obj.SyntaxPanic, "Invalid syntax!")
-	}
-	tokens = tokens[2:]
-	val := *p.processValTokens(tokens)
+	val := *p.processVal(s.Iter)
 	// Type is not list?
-	if !val.IsEnum() {
-		fract.IPanic(tokens[0], obj.ValuePanic, "Foreach loop must defined enumerable value!")
+	if !isIterable(val) {
+		fract.IPanic(s.Iter.Token(), obj.ValuePanic, "Foreach loop must defined enumerable value!")
+	} else if s.Elem.Val != "" {
+		checkElemName(val, s.Elem)
 	}
-	index := &oop.Var{Name: nameTK.Val, Val: oop.Val{Data: "0", Type: oop.Int}}
-	element := &oop.Var{Name: elemName}
-	p.defs.Vars = append(p.defs.Vars, index, element)
-	vars := p.defs.Vars
+	varLen := len(p.defs.Vars)
+	p.defs.Vars = append(p.defs.Vars,
+		&oop.Var{Name: nameTk.Val, Val: oop.Val{Data: int64(0), Type: oop.Int}},
+		&oop.Var{Name: elemName})
+	keywordState := fract.NA
 	// Interpret block.
-	l := loop{val: val}
-	l.run(func() {
-		index.Val = l.a
-		element.Val = l.b
-		p.Tokens = blockTokens
-		for p.index = 0; p.index < len(p.Tokens); p.index++ {
-			keywordState = p.processExpression(p.Tokens[p.index])
-			if keywordState == fract.LOOPBreak || keywordState == fract.FUNCReturn { // Break loop or return.
-				breakLoop = true
-				break
-			} else if keywordState == fract.LOOPContinue { // Continue loop.
-				break
-			}
+	it := p.rt.iterate(val, s.Iter.Token())
+	// Iterator is closed also when loop is left by break, return or panic.
+	defer it.close()
+	for it.next() {
+		// Variables are new for each iteration, closures are capture own iteration.
+		p.defs.Vars[varLen] = &oop.Var{Name: nameTk.Val, Val: it.a}
+		p.defs.Vars[varLen+1] = &oop.Var{Name: elemName, Val: it.b}
+		keywordState = p.processBlock(s.Body)
+		if keywordState == fract.LOOPBreak || keywordState == fract.FUNCReturn {
+			break
 		}
-		// Remove temporary variables.
-		p.defs.Vars = vars
-		// Remove temporary functions.
-		p.defs.Funcs = p.defs.Funcs[:funcLen]
-		// Remove temporary imports.
-		p.packages = p.packages[:impLen]
-		l.breakLoop = breakLoop
-	})
-	p.Tokens = parserTokens
-	p.index = parserIndex
+	}
 	// Remove loop variables.
-	index = nil
-	element = nil
-	p.defs.Vars = vars[:len(vars)-2]
+	p.defs.Vars = p.defs.Vars[:varLen]
 	return processKeywordState(keywordState)
 }
@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"github.com/fract-lang/fract/ast"
+	"github.com/fract-lang/fract/oop"
+	"github.com/fract-lang/fract/pkg/fract"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// bindPattern appends variable of bound name to vars.
+// Defined names are not bound, use pin pattern like ^x for compare with them.
+func (p *Parser) bindPattern(tk obj.Token, val oop.Val, vars *[]oop.VarDef) {
+	if tk.Val == "_" {
+		return
+	}
+	if !isValidName(tk.Val) {
+		fract.IPanic(tk, obj.NamePanic, "Invalid name!")
+	}
+	p.checkDefined(&p.defs, tk)
+	for _, v := range *vars {
+		if v.Name == tk.Val {
+			fract.IPanic(tk, obj.NamePanic, "Name duplicate!")
+		}
+	}
+	*vars = append(*vars, &oop.Var{Name: tk.Val, Line: tk.Line, Val: val})
+}
+
+// matchPattern returns true if value is matched by pattern, returns false if not.
+// Names bound by pattern appended to vars.
+func (p *Parser) matchPattern(val oop.Val, pattern ast.Expr, vars *[]oop.VarDef) bool {
+	switch t := pattern.(type) {
+	case *ast.Name: // Wildcard or name binding.
+		p.bindPattern(t.Tk, val, vars)
+		return true
+	case *ast.Pin: // Value of expression.
+		return compareValues("==", val, *p.processVal(t.Expr))
+	case *ast.List: // List pattern.
+		if val.Type != oop.List {
+			return false
+		}
+		elems := val.Data.(*oop.ListModel).Elems
+		for i, elem := range t.Elems {
+			// Rest of list.
+			if rest, ok := elem.(*ast.Rest); ok {
+				if i > len(elems) {
+					return false
+				}
+				list := oop.NewListModel(elems[i:]...)
+				return p.matchPattern(oop.Val{Data: list, Type: oop.List}, &ast.Name{Tk: rest.Name}, vars)
+			}
+			if i >= len(elems) || !p.matchPattern(elems[i], elem, vars) {
+				return false
+			}
+		}
+		return len(t.Elems) == len(elems)
+	case *ast.Call: // Struct instance pattern.
+		def := *p.processVal(t.Fn)
+		if def.Type != oop.StructDef {
+			break
+		}
+		s := def.Data.(oop.Struct)
+		if val.Type != oop.StructIns {
+			return false
+		}
+		ins := val.Data.(oop.StructInstance)
+		if !s.IsInstance(ins) {
+			return false
+		}
+		if len(t.Args) != len(s.Constructor.Params) {
+			fract.IPanic(t.Tk, obj.SyntaxPanic, "Pattern field count is not same with struct field count!")
+		}
+		for i, arg := range t.Args {
+			field := ins.Fields.Vars[ins.Fields.VarIndexByName(s.Constructor.Params[i].Name)]
+			if !p.matchPattern(field.Val, arg.Val, vars) {
+				return false
+			}
+		}
+		return true
+	}
+	// Literal pattern.
+	return compareValues("==", val, *p.processVal(pattern))
+}
+
+func (p *Parser) processMatch(s *ast.Match) uint8 {
+	val := *p.processVal(s.Val)
+	for _, c := range s.Cases {
+		for _, pattern := range c.Patterns {
+			var vars []oop.VarDef
+			if !p.matchPattern(val, pattern, &vars) {
+				continue
+			}
+			varLen := len(p.defs.Vars)
+			p.defs.Vars = append(p.defs.Vars, vars...)
+			keywordState := p.processBlock(c.Body)
+			p.defs.Vars = p.defs.Vars[:varLen]
+			return keywordState
+		}
+	}
+	fract.Panic(s.Tk, obj.MatchPanic, "No case matched for value: "+val.String())
+	return fract.NA
+}
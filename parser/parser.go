@@ -1,7 +1,7 @@
 package parser
 
 import (
-	"fmt"
+	"errors"
 	"io/ioutil"
 	"os"
 	"path"
@@ -9,67 +9,152 @@ import (
 	"strings"
 	"unicode"
 
+	"github.com/fract-lang/fract/ast"
+	"github.com/fract-lang/fract/bytecode"
 	"github.com/fract-lang/fract/functions"
 	"github.com/fract-lang/fract/lex"
 	"github.com/fract-lang/fract/oop"
+	"github.com/fract-lang/fract/pkg/diag"
 	"github.com/fract-lang/fract/pkg/fract"
 	"github.com/fract-lang/fract/pkg/obj"
 )
 
-var (
-	defers []*funcCall
-)
-
 // Parser of Fract.
 type Parser struct {
-	defs         oop.DefMap
-	packages     []*importInfo
-	funcTempVars int // Count of function temporary variables.
-	loopCount    int
-	funcCount    int
-	index        int
-	packageName  string   // Package name.
-	returnVal    *oop.Val // Last returned value.
+	defs        oop.DefMap
+	packages    []*importInfo
+	importing   bool              // Tag as import source.
+	packageName string            // Package name.
+	returnVal   *oop.Val          // Last returned value.
+	tree        *ast.Block        // Syntax tree of code file.
+	prog        *bytecode.Program // Compiled program, nil if not compiled.
+	rt          *Runtime
+	session     bool       // Interpret codes in same session.
+	stdlib      bool       // Standard library is imported to session.
+	baseFuncs   int        // Count of functions before codes of session.
+	baseVars    int        // Count of variables before codes of session.
+	fn          *oop.Fn    // Function in process, nil if not function.
+	gen         *generator // Generator of function in process, nil if not generator.
+	depth       int        // Depth of blocks in process.
+	srcVars     [2]int     // Range of variables of source in defines, not captured by closures.
+	srcFuncs    [2]int     // Range of functions of source in defines, not captured by closures.
+	stmt        ast.Stmt   // Statement in process, set only if debugging.
 
 	Lex    *lex.Lex
 	Tokens [][]obj.Token // All Tokens of code file.
 }
 
+// readLines returns lines of code.
+func readLines(code string) []string {
+	lines := strings.Split(code, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRightFunc(line, unicode.IsSpace)
+	}
+	return lines
+}
+
 // New returns instance of parser related to file.
-func New(fp string) *Parser {
+func New(rt *Runtime, fp string) *Parser {
 	file, _ := os.Open(fp)
 	bytes, _ := os.ReadFile(fp)
-	fileObj := &obj.File{Path: fp, File: file}
-	fileObj.Lines = strings.Split(string(bytes), "\n")
-	for i, line := range fileObj.Lines {
-		fileObj.Lines[i] = strings.TrimRightFunc(line, unicode.IsSpace)
+	fileObj := &obj.File{Path: fp, File: file, Lines: readLines(string(bytes))}
+	return &Parser{
+		Lex: &lex.Lex{File: fileObj, Line: 1},
+		rt:  rt,
 	}
+}
+
+// NewSource returns instance of parser related to file with code.
+// Code is used instead of content of file.
+func NewSource(rt *Runtime, fp, code string) *Parser {
 	return &Parser{
-		funcTempVars: -1,
-		Lex:          &lex.Lex{File: fileObj, Line: 1},
+		Lex: &lex.Lex{File: &obj.File{Path: fp, Lines: readLines(code)}, Line: 1},
+		rt:  rt,
 	}
 }
 
 // NewStdin returns new instance of parser from standard input.
-func NewStdin() *Parser {
+func NewStdin(rt *Runtime) *Parser {
 	return &Parser{
-		funcTempVars: -1,
 		Lex: &lex.Lex{
 			File: &obj.File{Path: "<stdin>"},
 			Line: 1,
 		},
+		rt:      rt,
+		session: true,
 	}
 }
 
+// NewString returns new instance of parser for evaluate codes in same session.
+func NewString(rt *Runtime) *Parser {
+	return &Parser{
+		Lex: &lex.Lex{
+			File:     &obj.File{Path: "<string>"},
+			Line:     1,
+			Finished: true,
+		},
+		rt:      rt,
+		session: true,
+	}
+}
+
+// NewBytecode returns new instance of parser from compiled program.
+func NewBytecode(rt *Runtime, prog *bytecode.Program) *Parser {
+	return &Parser{
+		Lex: &lex.Lex{
+			File:     &obj.File{Path: prog.Path, Lines: prog.Lines},
+			Line:     1,
+			Finished: true,
+		},
+		packageName: prog.Package,
+		prog:        prog,
+		rt:          rt,
+	}
+}
+
+// Compile code file to bytecode program.
+func (p *Parser) Compile() *bytecode.Program {
+	p.ready()
+	return bytecode.Compile(p.Lex.File, p.packageName, p.tree)
+}
+
 // ready interpreter to process.
 func (p *Parser) ready() {
-	/// Tokenize all lines.
+	p.tokenize()
+	p.detectPackage()
+	p.tree = ast.Build(p.Tokens[1:])
+}
+
+// Check returns diagnostics of code file without interpret.
+// Tokenizing and building of syntax tree are continued after errors.
+func (p *Parser) Check() diag.List {
+	var diags diag.List
+	p.Lex.Diags = &diags
+	p.tokenize()
+	p.Lex.Diags = nil
+	if diags.Run(p.detectPackage) || (len(p.Tokens) > 0 && p.Tokens[0][0].Type == fract.Package) {
+		p.tree = ast.BuildDiags(p.Tokens[1:], &diags)
+	} else {
+		p.tree = ast.BuildDiags(p.Tokens, &diags)
+	}
+	diags.Sort()
+	return diags
+}
+
+// Tree returns syntax tree of code, nil if code is not parsed.
+func (p *Parser) Tree() *ast.Block { return p.tree }
+
+// tokenize all lines.
+func (p *Parser) tokenize() {
 	for !p.Lex.Finished {
 		if ctks := p.Lex.Next(); ctks != nil {
 			p.Tokens = append(p.Tokens, ctks)
 		}
 	}
-	// Detect package.
+}
+
+// detectPackage sets package name by package clause at first line.
+func (p *Parser) detectPackage() {
 	if len(p.Tokens) == 0 {
 		fract.Error(p.Lex.File, 1, 1, "Package is not defined!")
 	}
@@ -107,7 +192,7 @@ func (p *Parser) importPackage() {
 		if info.IsDir() || !strings.HasSuffix(info.Name(), fract.Extension) || info.Name() == mainName {
 			continue
 		}
-		src := New(path.Join(dir, info.Name()))
+		src := New(p.rt, path.Join(dir, info.Name()))
 		src.ready()
 		if src.packageName != p.packageName {
 			tk := src.Tokens[0][0]
@@ -115,23 +200,145 @@ func (p *Parser) importPackage() {
 		}
 		src.AddBuiltInFuncs()
 		builtinFuncLen := len(src.defs.Funcs)
-		src.loopCount = -1 //! Tag as import source.
+		builtinVarLen := len(src.defs.Vars)
+		src.importing = true
 		src.Import()
 		p.defs.Funcs = append(p.defs.Funcs, src.defs.Funcs[builtinFuncLen:]...)
-		p.defs.Vars = append(p.defs.Vars, src.defs.Vars...)
+		p.defs.Vars = append(p.defs.Vars, src.defs.Vars[builtinVarLen:]...)
 		p.packages = append(p.packages, src.packages...)
 	}
 }
 
-func (p *Parser) Interpret() {
-	if p.Lex.File.Path == "<stdin>" {
+// Eval interprets code in session of parser and returns error if panicked.
+// Definitions of previous codes are kept.
+func (p *Parser) Eval(code string) (err error) {
+	defer p.catch(&err)
+	p.Lex = &lex.Lex{File: &obj.File{Path: p.Lex.File.Path, Lines: readLines(code)}, Line: 1}
+	p.Tokens = nil
+	for !p.Lex.Finished {
+		if tks := p.Lex.Next(); tks != nil {
+			p.Tokens = append(p.Tokens, tks)
+		}
+	}
+	p.Interpret()
+	return nil
+}
+
+// Value evaluates expression in session of parser and returns value.
+// Returns error if code is not a single expression or panicked.
+func (p *Parser) Value(code string) (val oop.Val, err error) {
+	defer p.catch(&err)
+	p.Lex = &lex.Lex{File: &obj.File{Path: p.Lex.File.Path, Lines: readLines(code)}, Line: 1}
+	p.Tokens = nil
+	p.tokenize()
+	defer p.rt.leave(p.rt.enter(p))
+	p.readySession()
+	stmts := ast.Build(p.Tokens).Stmts
+	if len(stmts) != 1 {
+		return val, errors.New("expression is not given")
+	}
+	s, ok := stmts[0].(*ast.ExprStmt)
+	if !ok {
+		return val, errors.New("code is not an expression")
+	}
+	return *p.processVal(s.X), nil
+}
+
+// Load interprets code file in session of parser.
+// Package clause of file is skipped and definitions of file are kept.
+func (p *Parser) Load(path string) (err error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	defer p.catch(&err)
+	// Lexer of session is restored for next codes.
+	lx := p.Lex
+	defer func() { p.Lex = lx }()
+	p.Lex = &lex.Lex{File: &obj.File{Path: path, Lines: readLines(string(bytes))}, Line: 1}
+	p.Tokens = nil
+	p.tokenize()
+	if len(p.Tokens) > 0 && p.Tokens[0][0].Type == fract.Package {
+		p.Tokens = p.Tokens[1:]
+	}
+	p.Interpret()
+	return nil
+}
+
+// readySession imports standard library to session if not imported.
+func (p *Parser) readySession() {
+	if !p.stdlib {
 		p.importStdlibLocal()
+		p.stdlib = true
+		p.baseFuncs, p.baseVars = len(p.defs.Funcs), len(p.defs.Vars)
+	}
+}
+
+// Defs returns all defines of parser.
+func (p *Parser) Defs() oop.DefMap { return p.defs }
+
+// SessionDefs returns defines of codes of session.
+// Built-in defines and defines of standard library are not included.
+func (p *Parser) SessionDefs() oop.DefMap {
+	if !p.stdlib {
+		return oop.DefMap{}
+	}
+	return oop.DefMap{Funcs: p.defs.Funcs[p.baseFuncs:], Vars: p.defs.Vars[p.baseVars:]}
+}
+
+// Packages returns names of imported packages.
+func (p *Parser) Packages() []string {
+	names := make([]string, len(p.packages))
+	for i, info := range p.packages {
+		names[i] = info.name
+	}
+	return names
+}
+
+// Package returns defines of imported package by name.
+// Returns false if package is not imported.
+func (p *Parser) Package(name string) (oop.DefMap, bool) {
+	for _, info := range p.packages {
+		if info.name == name {
+			return info.src.defs, true
+		}
+	}
+	return oop.DefMap{}, false
+}
+
+// Run interprets code and returns error if panicked.
+func (p *Parser) Run() (err error) {
+	defer p.catch(&err)
+	p.Interpret()
+	return nil
+}
+
+// catch recovers panic to error and clears defers.
+func (p *Parser) catch(err *error) {
+	if r := recover(); r != nil {
+		*err = p.rt.error(r)
+		p.rt.defers = nil
+		p.rt.calls = nil
+		p.depth = 0
+	}
+}
+
+func (p *Parser) Interpret() {
+	defer p.rt.leave(p.rt.enter(p))
+	if p.session {
+		p.readySession()
 		// Interpret all lines.
-		for p.index = 0; p.index < len(p.Tokens); p.index++ {
-			p.processExpression(p.Tokens[p.index])
+		for _, stmt := range ast.Build(p.Tokens).Stmts {
+			p.processStmt(stmt)
 		}
 		goto end
 	}
+	if p.prog != nil { // Compiled program.
+		p.importStdlibLocal()
+		p.importPackage()
+		p.exec(&p.prog.Main)
+		goto end
+	}
 	// Lexer is finished.
 	if p.Lex.Finished {
 		return
@@ -140,27 +347,21 @@ func (p *Parser) Interpret() {
 	p.importStdlibLocal()
 	p.importPackage()
 	// Interpret all lines.
-	for p.index = 1; p.index < len(p.Tokens); p.index++ {
-		p.processExpression(p.Tokens[p.index])
+	for _, stmt := range p.tree.Stmts {
+		p.processStmt(stmt)
 	}
 end:
-	for i := len(defers) - 1; i >= 0; i-- {
-		defers[i].Call()
-	}
+	p.rt.runDefers(0)
 }
 
-func (p *Parser) processPragma(tks []obj.Token) {
-	if tks[1].Type != fract.Name {
-		fract.IPanic(tks[0], obj.SyntaxPanic, "Invalid pragma!")
-	}
-	switch tks[1].Val {
+// processPragma and returns true if import is breaked.
+func (p *Parser) processPragma(s *ast.Pragma) bool {
+	switch s.Name.Val {
 	case "enofi":
-		if p.loopCount == -1 {
-			p.loopCount = 0
-		}
-	default:
-		fract.IPanic(tks[1], obj.SyntaxPanic, "Invalid pragma!")
+		return p.importing
+	case "nolint": // Suppressions of linter.
 	}
+	return false
 }
 
 // isValidName returns true if name is valid, returns false if not.
@@ -186,9 +387,9 @@ func enumerableSelections(enum, selectVal oop.Val, tk obj.Token) interface{} {
 			if d.Type != oop.Int {
 				fract.IPanic(tk, obj.ValuePanic, "Only integer values can used in index access!")
 			}
-			pos := processIndex(enumLen, int(d.Data.(float64)))
+			pos := processIndex(enumLen, int(d.Data.(int64)))
 			if pos == -1 {
-				fract.IPanic(tk, obj.OutOfRangePanic, "Index is out of range!")
+				fract.Panic(tk, obj.OutOfRangePanic, "Index is out of range!")
 			}
 			i = append(i, pos)
 		}
@@ -197,95 +398,13 @@ func enumerableSelections(enum, selectVal oop.Val, tk obj.Token) interface{} {
 	if selectVal.Type != oop.Int {
 		fract.IPanic(tk, obj.ValuePanic, "Only integer values can used in index access!")
 	}
-	pos := processIndex(enumLen, int(selectVal.Data.(float64)))
+	pos := processIndex(enumLen, int(selectVal.Data.(int64)))
 	if pos == -1 {
-		fract.IPanic(tk, obj.OutOfRangePanic, "Index is out of range!")
+		fract.Panic(tk, obj.OutOfRangePanic, "Index is out of range!")
 	}
 	return []int{pos}
 }
 
-// findBlock returns start index of block if found, returns -1 if not.
-func findBlock(tokens []obj.Token) int {
-	braceCount := 0
-	for i, tk := range tokens {
-		switch tk.Val {
-		case "[", "(":
-			braceCount++
-		case "]", ")":
-			braceCount--
-		case "{":
-			if braceCount == 0 {
-				return i
-			}
-		}
-	}
-	fract.IPanic(tokens[0], obj.SyntaxPanic, "Block is not given!")
-	return -1
-}
-
-func (p *Parser) getBlock(tokens []obj.Token) [][]obj.Token {
-	if len(tokens) == 0 {
-		p.index++
-		tokens = p.Tokens[p.index]
-	}
-	if tokens[0].Type != fract.Brace || tokens[0].Val != "{" {
-		fract.IPanic(tokens[0], obj.SyntaxPanic, "Invalid syntax!")
-	}
-	braceCount := 0 // Actually is 1 cause first token is open bracket({).
-	for i, tk := range tokens {
-		if tk.Type == fract.Brace {
-			switch tk.Val {
-			case "{":
-				braceCount++
-			case "}":
-				braceCount--
-			}
-		}
-		if braceCount == 0 {
-			if i < len(tokens)-1 {
-				p.Tokens = append(p.Tokens[:p.index+1], append([][]obj.Token{tokens[i+1:]}, p.Tokens[p.index+1:]...)...)
-			}
-			tokens = tokens[1 : i+1]
-			break
-		}
-	}
-	var blockTokens [][]obj.Token
-	if len(tokens) == 1 {
-		return blockTokens
-	}
-	line := tokens[0].Line
-	lastIndex := 0
-	for j, tk := range tokens {
-		if tk.Type == fract.Brace {
-			switch tk.Val {
-			case "{", "[", "(":
-				braceCount++
-			default:
-				braceCount--
-				line = tk.Line
-			}
-		}
-		if braceCount > 0 {
-			continue
-		}
-		if tk.Type == fract.StatementTerminator {
-			blockTokens = append(blockTokens, tokens[lastIndex:j])
-			lastIndex = j + 1
-			line = tk.Line
-			continue
-		}
-		if line < tk.Line {
-			blockTokens = append(blockTokens, tokens[lastIndex:j])
-			lastIndex = j
-			line = tk.Line
-		}
-	}
-	if len(tokens) != lastIndex {
-		blockTokens = append(blockTokens, tokens[lastIndex:len(tokens)-1])
-	}
-	return blockTokens
-}
-
 // TYPES
 // 'f' -> Function.
 // 'v' -> Variable.
@@ -335,92 +454,6 @@ func (p *Parser) packageIndexByName(name string) int {
 	return -1
 }
 
-func arithmeticProcesses(tokens []obj.Token) [][]obj.Token {
-	var (
-		processes  [][]obj.Token
-		part       []obj.Token
-		operator   bool
-		braceCount int
-	)
-	for i := 0; i < len(tokens); i++ {
-		switch tk := tokens[i]; tk.Type {
-		case fract.Operator:
-			if !operator {
-				fract.IPanic(tk, obj.SyntaxPanic, "Operator overflow!")
-			}
-			operator = false
-			if braceCount > 0 {
-				part = append(part, tk)
-			} else {
-				processes = append(processes, part)
-				processes = append(processes, []obj.Token{tk})
-				part = []obj.Token{}
-			}
-		default:
-			if tk.Type == fract.Brace {
-				switch tk.Val {
-				case "(", "[", "{":
-					braceCount++
-				default:
-					braceCount--
-				}
-			}
-			if braceCount == 0 && tk.Type == fract.Comma {
-				fract.IPanic(tk, obj.SyntaxPanic, "Invalid syntax!")
-			}
-			if i > 0 {
-				if lt := tokens[i-1]; (lt.Type == fract.Name || lt.Type == fract.Value) && (tk.Type == fract.Name || tk.Type == fract.Value) {
-					fract.IPanic(tk, obj.SyntaxPanic, "Invalid syntax!")
-				}
-			}
-			part = append(part, tk)
-			operator = tk.Type != fract.Comma && (tk.Type != fract.Brace || tk.Type == fract.Brace && tk.Val != "[" && tk.Val != "(" && tk.Val != "{") && i < len(tokens)-1
-		}
-	}
-	if len(part) != 0 {
-		processes = append(processes, part)
-	}
-	return processes
-}
-
-// decomposeBrace returns range tokens and index of first parentheses
-// and remove range tokens from original tokens.
-func decomposeBrace(tokens *[]obj.Token) []obj.Token {
-	first := -1
-	for i, tk := range *tokens {
-		if tk.Type == fract.Brace && tk.Val == "(" {
-			first = i
-			break
-		}
-	}
-	// Skip find close parentheses and result ready steps
-	// if open parentheses is not found.
-	if first == -1 {
-		return nil
-	}
-	// Find close parentheses.
-	braceCount, length := 1, 0
-	for i := first + 1; i < len(*tokens); i++ {
-		tk := (*tokens)[i]
-		if tk.Type == fract.Brace {
-			switch tk.Val {
-			case "(":
-				braceCount++
-			case ")":
-				braceCount--
-			}
-			if braceCount == 0 {
-				break
-			}
-		}
-		length++
-	}
-	rangeTokens := append([]obj.Token{}, (*tokens)[first+1:first+1+length]...)
-	// Remove range from original tokens.
-	*tokens = append((*tokens)[:first], (*tokens)[first+2+length:]...)
-	return rangeTokens
-}
-
 // processIndex is process index by length.
 func processIndex(length, index int) int {
 	if index >= 0 {
@@ -436,124 +469,15 @@ func processIndex(length, index int) int {
 	return index
 }
 
-// nextOperator find index of priority operator and returns index of operator
-// if found, returns -1 if not.
-func nextOperator(tokens [][]obj.Token) int {
-	high, mid, low := -1, -1, -1
-	for i, part := range tokens {
-		switch part[0].Val {
-		case "<<", ">>":
-			return i
-		case "**":
-			return i
-		case "%":
-			return i
-		case "*", "/", "\\":
-			if high == -1 {
-				high = i
-			}
-		case "+", "-":
-			if low == -1 {
-				low = i
-			}
-		case "&", "|":
-			if mid == -1 {
-				mid = i
-			}
-		}
-	}
-	if high != -1 {
-		return high
-	} else if mid != -1 {
-		return mid
-	} else if low != -1 {
-		return low
-	}
-	return -1
-}
-
-// findConditionOperator return next condition operator.
-func findConditionOperator(tokens []obj.Token) (int, obj.Token) {
-	braceCount := 0
-	for i, tk := range tokens {
-		if tk.Type == fract.Brace {
-			switch tk.Val {
-			case "{", "[", "(":
-				braceCount++
-			default:
-				braceCount--
-			}
-		}
-		if braceCount > 0 {
-			continue
-		}
-		switch tk.Type {
-		case fract.Operator:
-			switch tk.Val {
-			case "&&", "||", "==", "!=", ">", "<", "<=", ">=":
-				return i, tk
-			}
-		case fract.In:
-			return i, tk
-		}
-	}
-	var tk obj.Token
-	return -1, tk
-}
-
-// Find next or condition operator index and return if find, return -1 if not.
-func nextConditionOperator(tokens []obj.Token, pos int, operator string) int {
-	braceCount := 0
-	for ; pos < len(tokens); pos++ {
-		tk := tokens[pos]
-		if tk.Type == fract.Brace {
-			switch tk.Val {
-			case "{", "[", "(":
-				braceCount++
-			default:
-				braceCount--
-			}
-		}
-		if braceCount > 0 {
-			continue
-		}
-		if tk.Type == fract.Operator && tk.Val == operator {
-			return pos
-		}
-	}
-	return -1
-}
-
-// conditionalProcesses returns conditional expressions by operators.
-func conditionalProcesses(tokens []obj.Token, operator string) [][]obj.Token {
-	var expressions [][]obj.Token
-	last := 0
-	index := nextConditionOperator(tokens, last, operator)
-	for index != -1 {
-		if index-last == 0 {
-			fract.IPanic(tokens[last], obj.SyntaxPanic, "Condition expression is cannot given!")
-		}
-		expressions = append(expressions, tokens[last:index])
-		last = index + 1
-		index = nextConditionOperator(tokens, last, operator) // Find next.
-		if index == len(tokens)-1 {
-			fract.IPanic(tokens[len(tokens)-1], obj.SyntaxPanic, "Operator overflow!")
-		}
-	}
-	if last != len(tokens) {
-		expressions = append(expressions, tokens[last:])
-	}
-	return expressions
-}
-
 //! Built-in functions should have a lowercase names.
 
+// AddBuiltInFuncs adds built-in functions and defines of host.
 func (p *Parser) AddBuiltInFuncs() {
 	p.defs.Funcs = append(p.defs.Funcs,
 		&oop.Fn{
 			Name:              "print",
 			DefaultParamCount: 2,
-			Src:               functions.Print,
+			Src:               p.rt.Print,
 			Params: []oop.Param{{
 				Name:       "value",
 				Params:     true,
@@ -561,7 +485,7 @@ func (p *Parser) AddBuiltInFuncs() {
 			}},
 		}, &oop.Fn{
 			Name:              "println",
-			Src:               functions.Println,
+			Src:               p.rt.Println,
 			DefaultParamCount: 2,
 			Params: []oop.Param{{
 				Name:       "value",
@@ -570,7 +494,7 @@ func (p *Parser) AddBuiltInFuncs() {
 			}},
 		}, &oop.Fn{
 			Name:              "input",
-			Src:               functions.Input,
+			Src:               p.rt.Input,
 			DefaultParamCount: 1,
 			Params: []oop.Param{{
 				Name:       "message",
@@ -582,11 +506,11 @@ func (p *Parser) AddBuiltInFuncs() {
 			Src:               functions.Exit,
 			Params: []oop.Param{{
 				Name:       "code",
-				DefaultVal: oop.Val{Data: 0., Type: oop.Int},
+				DefaultVal: oop.Val{Data: int64(0), Type: oop.Int},
 			}},
 		}, &oop.Fn{
 			Name:              "len",
-			Src:               functions.Len,
+			Src:               (*Runtime).len,
 			DefaultParamCount: 0,
 			Params:            []oop.Param{{Name: "object"}},
 		}, &oop.Fn{
@@ -598,7 +522,7 @@ func (p *Parser) AddBuiltInFuncs() {
 				{Name: "to"},
 				{
 					Name:       "step",
-					DefaultVal: oop.Val{Data: 1., Type: oop.Int},
+					DefaultVal: oop.Val{Data: int64(1), Type: oop.Int},
 				},
 			},
 		}, &oop.Fn{
@@ -638,103 +562,88 @@ func (p *Parser) AddBuiltInFuncs() {
 			Src:               functions.Float,
 			DefaultParamCount: 0,
 			Params:            []oop.Param{{Name: "object"}},
+		}, &oop.Fn{
+			Name:              "bigint",
+			Src:               functions.BigInt,
+			DefaultParamCount: 0,
+			Params:            []oop.Param{{Name: "object"}},
+		}, &oop.Fn{
+			Name:              "decimal",
+			Src:               functions.Decimal,
+			DefaultParamCount: 0,
+			Params:            []oop.Param{{Name: "object"}},
 		}, &oop.Fn{
 			Name:              "panic",
 			Src:               functions.Panic,
-			DefaultParamCount: 0,
-			Params:            []oop.Param{{Name: "msg"}},
+			DefaultParamCount: 1,
+			Params: []oop.Param{
+				{Name: "msg"},
+				{
+					Name:       "type",
+					DefaultVal: oop.Val{Data: obj.PlainPanic, Type: oop.String},
+				},
+			},
 		}, &oop.Fn{
 			Name:              "type",
 			Src:               functions.Type,
 			DefaultParamCount: 0,
 			Params:            []oop.Param{{Name: "obj"}},
+		}, &oop.Fn{
+			Name:              "chan",
+			Src:               functions.Chan,
+			DefaultParamCount: 1,
+			Params: []oop.Param{{
+				Name:       "size",
+				DefaultVal: oop.Val{Data: int64(0), Type: oop.Int},
+			}},
+		}, &oop.Fn{
+			Name:              "close",
+			Src:               functions.Close,
+			DefaultParamCount: 0,
+			Params:            []oop.Param{{Name: "ch"}},
 		},
 	)
+	p.defs.Funcs = append(p.defs.Funcs, p.rt.natives.Funcs...)
+	p.defs.Vars = append(p.defs.Vars, p.rt.nativeVars(p.rt.natives.Vars)...)
 }
 
-func (p *Parser) processIf(tokens []obj.Token) uint8 {
-	blockIndex := findBlock(tokens)
-	ptokens := p.Tokens
-	pindex := p.index
-	p.Tokens = [][]obj.Token{}
-	p.index = -1
-	blockTokens, conditionTokens := p.getBlock(tokens[blockIndex:]), tokens[1:blockIndex]
-	// Condition is empty?
-	if len(conditionTokens) == 0 {
-		first := tokens[0]
-		fract.IPanicC(first.File, first.Line, first.Column+len(first.Val), obj.SyntaxPanic, "Condition is empty!")
-	}
-	condition := p.prococessCondition(conditionTokens)
+// processBlock process statements of block and returns keyword state.
+// Defines of block are removed after process.
+func (p *Parser) processBlock(b *ast.Block) uint8 {
 	varLen := len(p.defs.Vars)
 	fnLen := len(p.defs.Funcs)
 	impLen := len(p.packages)
 	keywordState := fract.NA
-	if !condition {
-		goto rep
-	}
-	for _, tokens := range blockTokens {
-		if keywordState = p.processExpression(tokens); keywordState != fract.NA {
-			break
-		}
-	}
-rep:
-	p.index++
-	if p.index >= len(p.Tokens) {
-		p.index--
-		goto end
-	}
-	tokens = p.Tokens[p.index]
-	if tokens[0].Type != fract.Else {
-		p.index--
-		goto end
-	}
-	if len(tokens) > 1 && tokens[1].Type == fract.If { // Else if.
-		blockIndex = findBlock(tokens)
-		p.Tokens = [][]obj.Token{}
-		p.index = -1
-		blockTokens, conditionTokens = p.getBlock(tokens[blockIndex:]), tokens[2:blockIndex]
-		// Condition is empty?
-		if len(conditionTokens) == 0 {
-			first := tokens[1]
-			fract.IPanicC(first.File, first.Line, first.Column+len(first.Val), obj.SyntaxPanic, "Condition is empty!")
-		}
-		if condition { // Executed before if block.
-			goto rep
-		}
-		condition = p.prococessCondition(conditionTokens)
-		if !condition {
-			goto rep
-		}
-		for _, tokens := range blockTokens {
-			if keywordState = p.processExpression(tokens); keywordState != fract.NA {
-				break
-			}
-		}
-		goto rep
-	}
-	blockTokens = p.getBlock(tokens[1:])
-	p.Tokens = p.Tokens[1:]
-	if condition {
-		goto end
-	}
-	for _, tokens := range blockTokens {
-		if keywordState = p.processExpression(tokens); keywordState != fract.NA {
+	p.depth++
+	for _, stmt := range b.Stmts {
+		if keywordState = p.processStmt(stmt); keywordState != fract.NA {
 			break
 		}
 	}
-end:
-	p.Tokens = append(ptokens, p.Tokens...)
-	p.index = pindex
+	p.depth--
 	p.defs.Vars = p.defs.Vars[:varLen]
 	p.defs.Funcs = p.defs.Funcs[:fnLen]
 	p.packages = p.packages[:impLen]
 	return keywordState
 }
 
+func (p *Parser) processIf(s *ast.If) uint8 {
+	if p.processCondition(s.Cond) {
+		return p.processBlock(s.Body)
+	}
+	switch t := s.Else.(type) {
+	case *ast.If:
+		return p.processIf(t)
+	case *ast.Block:
+		return p.processBlock(t)
+	}
+	return fract.NA
+}
+
 // checkPublic name access.
 func checkPublic(f *obj.File, name obj.Token) {
 	if f != nil {
-		if f == name.File {
+		if f == name.File || f.Builtin {
 			return
 		}
 	}
@@ -743,85 +652,87 @@ func checkPublic(f *obj.File, name obj.Token) {
 	}
 }
 
-func (p *Parser) processTryCatch(tokens []obj.Token) uint8 {
-	fract.TryCount++
+func (p *Parser) processTryCatch(s *ast.TryCatch) (kws uint8) {
 	var (
 		varLen   = len(p.defs.Vars)
 		fnLen    = len(p.defs.Funcs)
 		impLen   = len(p.packages)
-		deferLen = len(defers)
-		kws      = fract.NA
+		deferLen = len(p.rt.defers)
+		callLen  = len(p.rt.calls)
+		depth    = p.depth
 	)
+	if s.Finally != nil {
+		defer func() {
+			r := recover()
+			if r != nil {
+				cp, ok := r.(obj.Panic)
+				if !ok || cp.Fatal {
+					panic(r)
+				}
+				// Panic of catch block or not catched panic.
+				p.rt.stackTrace(&cp)
+				p.rt.calls = p.rt.calls[:callLen]
+				p.rt.runDefers(deferLen)
+				p.defs.Vars = p.defs.Vars[:varLen]
+				p.defs.Funcs = p.defs.Funcs[:fnLen]
+				p.packages = p.packages[:impLen]
+				p.depth = depth
+				r = cp
+			}
+			// Break, continue and return of finally block are discards panic.
+			if fkws := p.processBlock(s.Finally); fkws != fract.NA {
+				kws = fkws
+			} else if r != nil {
+				panic(r)
+			}
+		}()
+	}
 	b := &obj.Block{
 		Try: func() {
-			for _, tks := range p.getBlock(tokens[1:]) {
-				if kws = p.processExpression(tks); kws != fract.NA {
+			p.depth++
+			for _, stmt := range s.Try.Stmts {
+				if kws = p.processStmt(stmt); kws != fract.NA {
 					break
 				}
 			}
-			if p.Tokens[p.index+1][0].Type == fract.Catch {
-				p.index++
-			}
-			fract.TryCount--
+			p.depth--
 			p.defs.Vars = p.defs.Vars[:varLen]
 			p.defs.Funcs = p.defs.Funcs[:fnLen]
 			p.packages = p.packages[:impLen]
-			for index := len(defers) - 1; index >= deferLen; index-- {
-				defers[index].Call()
-			}
-			defers = defers[:deferLen]
+			p.rt.runDefers(deferLen)
 		},
 		Catch: func(cp obj.Panic) {
-			for index := len(defers) - 1; index >= deferLen; index-- {
-				defers[index].Call()
+			if cp.Fatal { // Interpreter panics are not catchable.
+				p.rt.defers = p.rt.defers[:deferLen]
+				panic(cp)
 			}
-			p.loopCount = 0
-			fract.TryCount--
+			p.rt.stackTrace(&cp)
+			p.rt.calls = p.rt.calls[:callLen]
+			p.rt.runDefers(deferLen)
 			p.defs.Vars = p.defs.Vars[:varLen]
 			p.defs.Funcs = p.defs.Funcs[:fnLen]
 			p.packages = p.packages[:impLen]
-			defers = defers[:deferLen]
-			p.index++
-			tokens = p.Tokens[p.index]
-			if tokens[0].Type != fract.Catch {
-				p.index--
+			p.depth = depth
+			if len(s.Catches) == 0 {
 				return
 			}
-			l := len(tokens)
-			if l < 2 {
-				fract.IPanic(tokens[0], obj.SyntaxPanic, "Invalid syntax!")
+			c := catchOf(s.Catches, cp)
+			if c == nil { // Not catched panics are raised again.
+				panic(cp)
 			}
-			nameTk := tokens[1]
-			if nameTk.Type == fract.Name {
-				if ln := p.defLineByName(nameTk.Val); ln != -1 {
-					fract.IPanic(nameTk, obj.NamePanic, "\""+nameTk.Val+"\" is already defined at line: "+fmt.Sprint(ln))
-				}
-				p.defs.Vars = append(p.defs.Vars, &oop.Var{
-					Name: nameTk.Val,
-					Line: nameTk.Line,
-					Val:  oop.Val{Data: cp.Msg, Type: oop.String},
-				})
-			}
-			var block [][]obj.Token
-			if nameTk.Type == fract.Name {
-				if l < 3 {
-					fract.IPanic(tokens[1], obj.SyntaxPanic, "Invalid syntax!")
-				}
-				block = p.getBlock(tokens[2:])
-			} else {
-				block = p.getBlock(tokens[1:])
+			p.depth++
+			if c.Name.Val != "" {
+				p.catchVar(c.Name, cp)
 			}
-			for _, tks := range block {
-				if kws = p.processExpression(tks); kws != fract.NA {
+			for _, stmt := range c.Body.Stmts {
+				if kws = p.processStmt(stmt); kws != fract.NA {
 					break
 				}
 			}
+			p.depth--
 			p.defs.Vars = p.defs.Vars[:varLen]
 			p.defs.Funcs = p.defs.Funcs[:fnLen]
-			for i := len(defers) - 1; i >= deferLen; i-- {
-				defers[i].Call()
-			}
-			defers = defers[:deferLen]
+			p.rt.runDefers(deferLen)
 		},
 	}
 	b.Do()
@@ -829,157 +740,160 @@ func (p *Parser) processTryCatch(tokens []obj.Token) uint8 {
 	return kws
 }
 
-// TODO: Add match-case.
+// catchOf returns first catch that catches panic.
+// Returns nil if not exist.
+func catchOf(catches []*ast.Catch, cp obj.Panic) *ast.Catch {
+	for _, c := range catches {
+		if c.Type.Val == "" || c.Type.Val == cp.Type {
+			return c
+		}
+	}
+	return nil
+}
+
+// errorVal returns instance of error struct of standard library for panic.
+func (p *Parser) errorVal(cp obj.Panic) oop.Val {
+	trace := make([]string, len(cp.Trace))
+	for i, f := range cp.Trace {
+		trace[i] = f.String()
+	}
+	msg := cp.Text
+	if msg == "" {
+		msg = cp.Msg
+	}
+	fields := map[string]oop.Val{
+		"message": {Data: msg, Type: oop.String},
+		"type":    {Data: cp.Type, Type: oop.String},
+		"file":    {Data: cp.File, Type: oop.String},
+		"line":    {Data: int64(cp.Line), Type: oop.Int},
+		"column":  {Data: int64(cp.Column), Type: oop.Int},
+		"trace":   {Data: strings.Join(trace, "\n"), Type: oop.String},
+	}
+	s := oop.Struct{Name: "error", Lex: p.Lex, Constructor: &oop.Fn{Params: []oop.Param{{Name: "message"}}}}
+	if i, t := p.defs.DefByName("error"); t == 'v' && p.defs.Vars[i].Val.Type == oop.StructDef {
+		s = p.defs.Vars[i].Val.Data.(oop.Struct)
+	}
+	args := make([]oop.VarDef, len(s.Constructor.Params))
+	for i, param := range s.Constructor.Params {
+		val, ok := fields[param.Name]
+		if !ok {
+			val = oop.Val{Data: "none", Type: oop.None}
+		}
+		args[i] = &oop.Var{Name: param.Name, Val: val}
+	}
+	ins := s.CallConstructor(args)
+	// Fields that are not declared by struct are added to instance.
+	for _, name := range []string{"message", "type", "file", "line", "column", "trace"} {
+		if ins.Fields.VarIndexByName(name) == -1 {
+			ins.Fields.Vars = append(ins.Fields.Vars, &oop.Var{Name: name, Val: fields[name]})
+		}
+	}
+	ins.Panic = &cp
+	return oop.Val{Data: ins, Type: oop.StructIns}
+}
+
+// catchVar defines variable of catch block.
+func (p *Parser) catchVar(nameTk obj.Token, cp obj.Panic) {
+	p.checkDefined(&p.defs, nameTk)
+	p.defs.Vars = append(p.defs.Vars, &oop.Var{
+		Name: nameTk.Val,
+		Line: nameTk.Line,
+		Val:  p.errorVal(cp),
+	})
+}
+
+// setReturn sets returned value by values of return statement.
+func (p *Parser) setReturn(vals []oop.Val) {
+	switch len(vals) {
+	case 0:
+	case 1:
+		p.returnVal = &vals[0]
+	default:
+		list := oop.NewListModel(vals...)
+		p.returnVal = &oop.Val{Data: list, Type: oop.List, Tag: "function_multiple_returns"}
+	}
+}
+
+func (p *Parser) processReturn(s *ast.Return) uint8 {
+	vals := make([]oop.Val, len(s.Vals))
+	for i, v := range s.Vals {
+		vals[i] = *p.processVal(v)
+	}
+	p.setReturn(vals)
+	return fract.FUNCReturn
+}
+
 //! A change added here(especially added a code block) must also be compatible with "imports.go" and
 
-// processExpression and returns keyword state.
-func (p *Parser) processExpression(tks []obj.Token) uint8 {
-	switch firstTk := tks[0]; firstTk.Type {
-	case fract.Value, fract.Brace, fract.Name:
-		if firstTk.Type == fract.Name {
-			braceCount := 0
-			for index, tk := range tks {
-				if tk.Type == fract.Brace {
-					switch tk.Val {
-					case " {", "[", "(":
-						braceCount++
-					default:
-						braceCount--
-					}
-				}
-				if braceCount > 0 {
-					continue
-				}
-				if tk.Type == fract.Operator {
-					switch tk.Val {
-					case "=", "+=", "-=", "*=", "/=", "%=", "^=", "<<=", ">>=", "|=", "&=":
-						p.varset(tks)
-						return fract.NA
-					case ":=":
-						p.varsdec(tks, index)
-						return fract.NA
-					}
-				}
-			}
-		}
+// processStmt and returns keyword state.
+func (p *Parser) processStmt(stmt ast.Stmt) uint8 {
+	// Defines of top-level of source are before statement, closures of statement are not capture them.
+	if p.fn == nil && p.depth == 0 {
+		p.srcVars[1], p.srcFuncs[1] = len(p.defs.Vars), len(p.defs.Funcs)
+	}
+	if p.rt.Debugger != nil {
+		defer p.debugPanic()
+		p.debugStmt(stmt)
+	}
+	switch s := stmt.(type) {
+	case *ast.ExprStmt:
 		// Print value if live interpreting.
-		if val := p.processValTokens(tks); fract.InteractiveShell {
-			if val.Print() {
-				println()
-			}
-		}
-	case fract.Var:
-		p.vardec(tks)
-	case fract.If:
-		return p.processIf(tks)
-	case fract.Loop:
-		p.loopCount++
-		state := p.processLoop(tks)
-		p.loopCount--
-		return state
-	case fract.Break:
-		if p.loopCount < 1 {
-			fract.IPanic(firstTk, obj.SyntaxPanic, "Break keyword only used in loops!")
-		}
+		if val := p.processVal(s.X); p.rt.Interactive {
+			if val.Data != nil {
+				p.rt.Output(p.rt.Stdout, val.String()+"\n")
+			}
+		}
+	case *ast.Assign:
+		p.varset(s)
+	case *ast.ShortVarDecl:
+		p.varsdec(s)
+	case *ast.VarDecl:
+		p.vardec(s)
+	case *ast.If:
+		return p.processIf(s)
+	case *ast.Match:
+		return p.processMatch(s)
+	case *ast.Select:
+		return p.processSelect(s)
+	case *ast.Send:
+		p.processSend(s)
+	case *ast.Loop:
+		return p.processLoop(s)
+	case *ast.Break:
 		return fract.LOOPBreak
-	case fract.Continue:
-		if p.loopCount < 1 {
-			fract.IPanic(firstTk, obj.SyntaxPanic, "Continue keyword only used in loops!")
-		}
+	case *ast.Continue:
 		return fract.LOOPContinue
-	case fract.Return:
-		if p.funcCount < 1 {
-			fract.IPanic(firstTk, obj.SyntaxPanic, "Return keyword only used in functions!")
-		}
-		if len(tks) > 1 {
-			tks = tks[1:]
-			list := oop.NewListModel()
-			var lastIndex int
-			var braceCount int
-			for index, tk := range tks {
-				switch tk.Type {
-				case fract.Brace:
-					switch tk.Val {
-					case "{", "[", "(":
-						braceCount++
-					default:
-						braceCount--
-					}
-				case fract.Comma:
-					if braceCount > 0 {
-						break
-					}
-					list.PushBack(*p.processValTokens(tks[lastIndex:index]))
-					lastIndex = index + 1
-				}
-			}
-			if lastIndex == 0 {
-				list = nil
-				p.returnVal = p.processValTokens(tks)
-			} else {
-				if lastIndex < len(tks) {
-					list.PushBack(*p.processValTokens(tks[lastIndex:]))
-				}
-				p.returnVal = nil
-				p.returnVal = new(oop.Val)
-				p.returnVal.Data = list
-				p.returnVal.Type = oop.List
-				p.returnVal.Tag = "function_multiple_returns"
-			}
-		}
-		return fract.FUNCReturn
-	case fract.Func:
-		p.funcdec(tks)
-	case fract.Try:
-		return p.processTryCatch(tks)
-	case fract.Import:
-		p.processImport(tks)
-	case fract.Macro:
-		p.processPragma(tks)
-	case fract.Struct:
-		p.structdec(tks)
-	case fract.Class:
-		p.classdec(tks)
-	case fract.Defer, fract.Go:
-		if l := len(tks); l < 2 {
-			fract.IPanic(tks[0], obj.SyntaxPanic, "Function is not given!")
-		} else if t := tks[l-1]; t.Type != fract.Brace && t.Val != ")" {
-			fract.IPanicC(tks[0].File, tks[0].Line, tks[0].Column+len(tks[0].Val), obj.SyntaxPanic, "Invalid syntax!")
-		}
-		var valTokens []obj.Token
-		braceCount := 0
-		for i := len(tks) - 1; i >= 0; i-- {
-			tk := tks[i]
-			if tk.Type != fract.Brace {
-				continue
-			}
-			switch tk.Val {
-			case ")":
-				braceCount++
-			case "(":
-				braceCount--
-			}
-			if braceCount > 0 {
-				continue
-			}
-			valTokens = tks[1:i]
-			break
-		}
-		if len(valTokens) == 0 && braceCount == 0 {
-			fract.IPanic(tks[1], obj.SyntaxPanic, "Invalid syntax!")
-		}
-		// Function call.
-		val := p.processValuePart(valuePartInfo{tokens: valTokens})
-		if val.Type != oop.Func {
-			fract.IPanic(tks[len(valTokens)], obj.ValuePanic, "Value is not function!")
-		}
-		if firstTk.Type == fract.Defer {
-			defers = append(defers, p.funcCallModel(val.Data.(*oop.Fn), tks[len(valTokens):]))
-		} else {
-			go p.funcCallModel(val.Data.(*oop.Fn), tks[len(valTokens):]).Call()
-		}
-	default:
-		fract.IPanic(firstTk, obj.SyntaxPanic, "Invalid syntax!")
+	case *ast.Return:
+		return p.processReturn(s)
+	case *ast.Yield:
+		return p.processYield(s)
+	case *ast.FuncDecl:
+		p.funcdec(s)
+	case *ast.TryCatch:
+		return p.processTryCatch(s)
+	case *ast.Import:
+		p.processImport(s)
+	case *ast.Pragma:
+		p.processPragma(s)
+	case *ast.StructDecl:
+		p.structdec(s)
+	case *ast.ClassDecl:
+		p.classdec(s)
+	case *ast.Defer:
+		p.rt.defers = append(p.rt.defers, p.processCallStmt(s.Call))
+	case *ast.Go:
+		p.rt.goCall(p.processCallStmt(s.Call))
+	case *ast.Block:
+		return p.processBlock(s)
 	}
 	return fract.NA
 }
+
+// processCallStmt returns call model of defer or go statement.
+func (p *Parser) processCallStmt(call *ast.Call) *funcCall {
+	val := p.processVal(call.Fn)
+	if val.Type != oop.Func {
+		fract.IPanic(call.Tk, obj.ValuePanic, "Value is not function!")
+	}
+	return p.funcCallModel(val.Data.(*oop.Fn), call)
+}
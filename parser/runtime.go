@@ -0,0 +1,269 @@
+package parser
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/fract-lang/fract/functions"
+	"github.com/fract-lang/fract/lex"
+	"github.com/fract-lang/fract/oop"
+	"github.com/fract-lang/fract/pkg/fract"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// Runtime is shared state of parsers of an interpreter.
+// Goroutines are processed with own runtimes forked from runtime of caller.
+type Runtime struct {
+	functions.Env
+	Stderr      io.Writer
+	StdLib      string   // Path of standard library.
+	Interactive bool     // Print values of expression statements.
+	Debugger    Debugger // Notified by main goroutine, nil if not debugging.
+
+	defers    []*funcCall
+	calls     []obj.Frame            // Function calls in process, latest is innermost.
+	frames    []*Parser              // Parsers in process by goroutine of runtime.
+	snapshots map[*Parser]*Parser    // Copies of parsers in process by other goroutines.
+	natives   oop.DefMap             // Definitions of host.
+	packages  map[string]*oop.DefMap // Packages of host.
+}
+
+// NewRuntime returns runtime with standard input and outputs.
+func NewRuntime(stdlib string) *Runtime {
+	rt := &Runtime{
+		Env:    functions.NewEnv(os.Stdout, bufio.NewReader(os.Stdin)),
+		Stderr: os.Stderr,
+		StdLib: stdlib,
+	}
+	rt.DefinePackage("sync", functions.Sync())
+	rt.DefinePackage("atomic", functions.Atomic())
+	return rt
+}
+
+// isHostName reports name is valid name for defines of host.
+func isHostName(name string) bool {
+	if name == "" || !isValidName(name) {
+		return false
+	}
+	for i, r := range name {
+		if r != '_' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	// Keywords are not names.
+	l := lex.Lex{File: &obj.File{Lines: []string{name}}, Line: 1}
+	tks := l.Next()
+	return len(tks) == 1 && tks[0].Type == fract.Name
+}
+
+// addDefs appends defines to destination.
+// Returns error without change destination if any name is invalid or defined.
+func addDefs(dest *oop.DefMap, defs oop.DefMap, reserved *oop.DefMap) error {
+	res := oop.DefMap{
+		Funcs: dest.Funcs[:len(dest.Funcs):len(dest.Funcs)],
+		Vars:  dest.Vars[:len(dest.Vars):len(dest.Vars)],
+	}
+	check := func(name string) error {
+		if !isHostName(name) {
+			return fmt.Errorf("invalid name: %q", name)
+		} else if res.DefIndexByName(name) != -1 || (reserved != nil && reserved.DefIndexByName(name) != -1) {
+			return fmt.Errorf("%q is already defined", name)
+		}
+		return nil
+	}
+	for _, f := range defs.Funcs {
+		if err := check(f.Name); err != nil {
+			return err
+		}
+		res.Funcs = append(res.Funcs, f)
+	}
+	for _, v := range defs.Vars {
+		if err := check(v.Name); err != nil {
+			return err
+		}
+		res.Vars = append(res.Vars, v)
+	}
+	*dest = res
+	return nil
+}
+
+// Define adds functions and variables of host to built-in defines.
+// Defines are visible to parsers that created after define.
+func (rt *Runtime) Define(defs oop.DefMap) error {
+	builtins := &Parser{rt: &Runtime{}}
+	builtins.AddBuiltInFuncs()
+	return addDefs(&rt.natives, defs, &builtins.defs)
+}
+
+// DefinePackage adds package of host that importable by name.
+// Names of defines are must be public for access from other packages.
+func (rt *Runtime) DefinePackage(name string, defs oop.DefMap) error {
+	for _, part := range strings.Split(name, ".") {
+		if !isHostName(part) {
+			return fmt.Errorf("invalid package name: %q", name)
+		}
+	}
+	if _, ok := rt.packages[name]; ok {
+		return fmt.Errorf("package %q is already defined", name)
+	}
+	pkg := &oop.DefMap{}
+	if err := addDefs(pkg, defs, nil); err != nil {
+		return err
+	}
+	if rt.packages == nil {
+		rt.packages = map[string]*oop.DefMap{}
+	}
+	rt.packages[name] = pkg
+	return nil
+}
+
+// BuiltIns returns built-in functions and variables with defines of host.
+func (rt *Runtime) BuiltIns() oop.DefMap {
+	p := &Parser{rt: rt}
+	p.AddBuiltInFuncs()
+	return p.defs
+}
+
+// nativeVars returns copies of variables of host.
+func (rt *Runtime) nativeVars(vars []oop.VarDef) []oop.VarDef {
+	cpy := make([]oop.VarDef, len(vars))
+	for i, v := range vars {
+		cpy[i] = &oop.Var{Name: v.Name, Val: v.Val}
+	}
+	return cpy
+}
+
+// runDefers runs defers after length and removes them.
+// Defers are removed before call, so they are not run again
+// if a deferred call is panicked.
+func (rt *Runtime) runDefers(length int) {
+	for len(rt.defers) > length {
+		c := rt.defers[len(rt.defers)-1]
+		rt.defers = rt.defers[:len(rt.defers)-1]
+		c.Call()
+	}
+}
+
+// fork returns runtime for new goroutine.
+// Parsers in process are copied because they are modified by caller
+// while goroutine is running.
+func (rt *Runtime) fork() *Runtime {
+	grt := *rt
+	grt.defers = nil
+	grt.Debugger = nil // Goroutines are not debugged.
+	grt.calls = append([]obj.Frame(nil), rt.calls...)
+	grt.frames = nil
+	grt.snapshots = make(map[*Parser]*Parser, len(rt.snapshots)+len(rt.frames))
+	for p, cpy := range rt.snapshots {
+		grt.snapshots[p] = cpy
+	}
+	for _, p := range rt.frames {
+		grt.snapshots[p] = p.snapshot()
+	}
+	return &grt
+}
+
+// snapshot returns copy of parser that not affected by later defines.
+func (p *Parser) snapshot() *Parser {
+	cpy := *p
+	cpy.defs = oop.DefMap{
+		Vars:  append([]oop.VarDef(nil), p.defs.Vars...),
+		Funcs: append([]*oop.Fn(nil), p.defs.Funcs...),
+	}
+	cpy.packages = append([]*importInfo(nil), p.packages...)
+	return &cpy
+}
+
+// enter begins process of parser and returns count of previous frames.
+func (rt *Runtime) enter(p *Parser) int {
+	rt.frames = append(rt.frames, p)
+	return len(rt.frames) - 1
+}
+
+// leave ends process of parsers after count of frames.
+func (rt *Runtime) leave(n int) { rt.frames = rt.frames[:n] }
+
+// source returns parser of function source for goroutine of runtime.
+func (rt *Runtime) source(p *Parser) *Parser {
+	if cpy, ok := rt.snapshots[p]; ok {
+		return cpy
+	}
+	return p
+}
+
+// call pushes frame of function call and returns count of previous calls.
+func (rt *Runtime) call(name string, tk obj.Token) int {
+	frame := obj.Frame{Func: name, Line: tk.Line, Column: tk.Column}
+	if tk.File != nil {
+		frame.File = tk.File.Path
+	}
+	rt.calls = append(rt.calls, frame)
+	return len(rt.calls) - 1
+}
+
+// stackTrace sets stack trace of panic by function calls in process.
+// Stack trace is not changed if already set.
+func (rt *Runtime) stackTrace(cp *obj.Panic) {
+	if cp.Trace != nil {
+		return
+	}
+	// Frames are positions in functions, calls are positions of callers.
+	pos := obj.Frame{File: cp.File, Line: cp.Line, Column: cp.Column}
+	cp.Trace = make([]obj.Frame, 0, len(rt.calls)+1)
+	for i := len(rt.calls) - 1; i >= 0; i-- {
+		c := rt.calls[i]
+		pos.Func = c.Func
+		cp.Trace = append(cp.Trace, pos)
+		pos = obj.Frame{File: c.File, Line: c.Line, Column: c.Column}
+	}
+	pos.Func = "<main>"
+	cp.Trace = append(cp.Trace, pos)
+}
+
+// error returns error of recovered panic with stack trace.
+func (rt *Runtime) error(r interface{}) error {
+	if cp, ok := r.(obj.Panic); ok {
+		rt.stackTrace(&cp)
+		r = cp
+	}
+	return Error(r)
+}
+
+// goCall calls function concurrently.
+// Panics of call are written to stderr after defers of call are run.
+func (rt *Runtime) goCall(c *funcCall) {
+	c.rt = rt.fork()
+	// Enclosing scope of closure is changed by caller, functions of it are copied.
+	if c.fn.Scope != nil {
+		fn := *c.fn
+		fn.Scope = &oop.Scope{
+			Defs:  oop.DefMap{Vars: c.fn.Scope.Defs.Vars, Funcs: c.fn.Scope.Funcs()},
+			Outer: &oop.DefMap{},
+		}
+		c.fn = &fn
+	}
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				rt.Output(rt.Stderr, c.rt.error(r).Error()+"\n")
+			}
+		}()
+		c.Call()
+	}()
+}
+
+// Error returns error of recovered panic.
+// Panics that are not thrown by interpreter are panicked again.
+func Error(r interface{}) error {
+	switch err := r.(type) {
+	case obj.Panic:
+		return err
+	case obj.ExitError:
+		return err
+	}
+	panic(r)
+}
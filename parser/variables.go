@@ -3,6 +3,7 @@ package parser
 import (
 	"fmt"
 
+	"github.com/fract-lang/fract/ast"
 	"github.com/fract-lang/fract/oop"
 	"github.com/fract-lang/fract/pkg/fract"
 	"github.com/fract-lang/fract/pkg/obj"
@@ -10,338 +11,199 @@ import (
 
 // Metadata of variable declaration.
 type varInfo struct {
-	shortDeclaration bool
-	constant         bool
-	mut              bool
+	constant bool
+	mut      bool
 }
 
-// Append variable to source.
-func (p *Parser) varadd(defs *oop.DefMap, inf varInfo, tokens []obj.Token) {
-	nameTk := tokens[0]
-	if !isValidName(nameTk.Val) {
-		fract.IPanic(nameTk, obj.SyntaxPanic, "Invalid name!")
-	}
-	// Name is already defined?
+// checkDefined panics if name is already defined.
+func (p *Parser) checkDefined(defs *oop.DefMap, nameTk obj.Token) {
 	var ln int
-	if &p.defs == defs { // Variable added to defmap of parser.
+	if &p.defs == defs { // Defmap of parser.
 		ln = p.defLineByName(nameTk.Val)
-	} else { // Variable added to another defmap.
+	} else { // Another defmap.
 		ln = defs.DefIndexByName(nameTk.Val)
 	}
 	if ln != -1 {
 		fract.IPanic(nameTk, obj.NamePanic, "\""+nameTk.Val+"\" is already defined at line: "+fmt.Sprint(ln))
 	}
+}
 
-	tokensLen := len(tokens)
-	// Setter is not defined?
-	if tokensLen < 2 {
-		fract.IPanicC(nameTk.File, nameTk.Line, nameTk.Column+len(nameTk.Val), obj.SyntaxPanic, "Setter is not found!")
-	}
-	setter := tokens[1]
-	// Setter is not a setter operator?
-	if setter.Type != fract.Operator || (setter.Val != "=" && !inf.shortDeclaration || setter.Val != ":=" && inf.shortDeclaration) {
-		fract.IPanic(setter, obj.SyntaxPanic, "Invalid setter operator: "+setter.Val)
-	}
-	// Value is not defined?
-	if tokensLen < 3 {
-		fract.IPanicC(setter.File, setter.Line, setter.Column+len(setter.Val), obj.SyntaxPanic, "Value is not given!")
-	}
-	val := *p.processValTokens(tokens[2:])
+// Append variable to source.
+func (p *Parser) varadd(defs *oop.DefMap, inf varInfo, spec ast.VarSpec) {
+	p.checkDefined(defs, spec.Name)
+	val := *p.processVal(spec.Val)
 	if val.Data == nil {
-		fract.IPanic(tokens[2], obj.ValuePanic, "Invalid value!")
-	}
-	if p.funcTempVars != -1 {
-		p.funcTempVars++
+		fract.IPanic(spec.Val.Token(), obj.ValuePanic, "Invalid value!")
 	}
 	val.Mut = inf.mut
 	val.Const = inf.constant
 	defs.Vars = append(defs.Vars, &oop.Var{
-		Name: nameTk.Val,
+		Name: spec.Name.Val,
 		Val:  val,
-		Line: nameTk.Line,
+		Line: spec.Name.Line,
 	})
 }
 
 // Process variable declaration to defmap.
-func (p *Parser) fvardec(defs *oop.DefMap, tokens []obj.Token) {
-	// Name is not defined?
-	if len(tokens) < 2 {
-		first := tokens[0]
-		fract.IPanicC(first.File, first.Line, first.Column+len(first.Val), obj.SyntaxPanic, "Name is not given!")
-	}
+func (p *Parser) fvardec(defs *oop.DefMap, s *ast.VarDecl) {
 	inf := varInfo{
-		constant: tokens[0].Val == "const",
-		mut:      tokens[0].Val == "mut",
+		constant: s.Tk.Val == "const",
+		mut:      s.Tk.Val == "mut",
 	}
-	pre := tokens[1]
-	if pre.Type == fract.Name {
-		p.varadd(defs, inf, tokens[1:])
-	} else if pre.Type == fract.Brace && pre.Val == "(" {
-		tokens = tokens[2 : len(tokens)-1]
-		last := 0
-		line := tokens[0].Line
-		braceCount := 0
-		for j, tk := range tokens {
-			if tk.Type == fract.Brace {
-				switch tk.Val {
-				case "{", "[", "(":
-					braceCount++
-				default:
-					braceCount--
-					line = tk.Line
-				}
-			}
-			if braceCount > 0 {
-				continue
-			}
-			if line < tk.Line {
-				p.varadd(defs, inf, tokens[last:j])
-				last = j
-				line = tk.Line
-			}
-		}
-		if len(tokens) != last {
-			p.varadd(defs, inf, tokens[last:])
-		}
-	} else {
-		fract.IPanic(pre, obj.SyntaxPanic, "Invalid syntax!")
+	for _, spec := range s.Specs {
+		p.varadd(defs, inf, spec)
 	}
 }
 
 // Process variable declaration to parser.
-func (p *Parser) vardec(tokens []obj.Token) { p.fvardec(&p.defs, tokens) }
-
-type shortVarDecNameInfo struct {
-	name    string
-	valType string
-}
+func (p *Parser) vardec(s *ast.VarDecl) { p.fvardec(&p.defs, s) }
 
-func (p *Parser) getShortVarDecNames(tokens []obj.Token) []shortVarDecNameInfo {
-	var names []shortVarDecNameInfo
-	var info shortVarDecNameInfo
-	lastIndex := 0
-	for index, tk := range tokens {
-		switch tk.Type {
-		case fract.Var:
-			if info.name == "" {
-				fract.IPanic(tk, obj.SyntaxPanic, "Invalid syntax!")
-			} else if info.valType != "" {
-				fract.IPanic(tk, obj.SyntaxPanic, "Type repetition!")
-			}
-			info.valType = tk.Val
-		case fract.Name:
-			if info.name != "" {
-				fract.IPanic(tk, obj.SyntaxPanic, "Invalid syntax!")
-			}
-			if tk.Val != "_" {
-				if !isValidName(tk.Val) {
-					fract.IPanic(tk, obj.SyntaxPanic, "Invalid name!")
-				}
-				// Name duplicate?
-				for _, info := range names {
-					if info.name == tk.Val {
-						fract.IPanic(tk, obj.NamePanic, "Name duplicate!")
-					}
-				}
-				// Name is already defined?
-				if ln := p.defLineByName(tk.Val); ln != -1 {
-					fract.IPanic(tk, obj.NamePanic, "\""+tk.Val+"\" is already defined at line: "+fmt.Sprint(ln))
-				}
-			}
-			info.name = tk.Val
-		case fract.Comma:
-			if info.name == "" {
-				fract.IPanic(tk, obj.SyntaxPanic, "Invalid syntax!")
-			}
-			names = append(names, info)
-			info.name = ""
-			info.valType = ""
-			lastIndex = index + 1
+// unpackValues returns values for names of short variable declaration.
+func unpackValues(values []oop.Val, count int, setter obj.Token) []oop.Val {
+	if len(values) == 1 && (values[0].Tag != "function_multiple_returns" || count == 1) {
+		values = append(values, make([]oop.Val, count-1)...)
+		for i := range values[1:] {
+			values[i+1] = values[0]
 		}
-	}
-	if lastIndex < len(tokens) {
-		if info.name == "" {
-			fract.IPanic(tokens[lastIndex], obj.SyntaxPanic, "Invalid syntax!")
+	} else if len(values) != count {
+		if len(values) == 1 && values[0].Tag == "function_multiple_returns" {
+			values = values[0].Data.(*oop.ListModel).Elems
 		}
-		names = append(names, info)
-	}
-	return names
-}
-
-func (p *Parser) getShortVarDecValues(tokens []obj.Token) []oop.Val {
-	var values []oop.Val
-	braceCount := 0
-	lastIndex := 0
-	for index, tk := range tokens {
-		switch tk.Type {
-		case fract.Brace:
-			switch tk.Val {
-			case "{", "[", "(":
-				braceCount++
-			default:
-				braceCount--
-			}
-		case fract.Comma:
-			if braceCount != 0 {
-				break
-			}
-			values = append(values, *p.processValTokens(tokens[lastIndex:index]))
-			lastIndex = index + 1
+		if len(values) != count {
+			fract.IPanic(setter, obj.SyntaxPanic, "Value assignment is wrong!")
 		}
 	}
-	if lastIndex < len(tokens) {
-		values = append(values, *p.processValTokens(tokens[lastIndex:]))
-	}
 	return values
 }
 
 // Process short variable declaration.
-func (p *Parser) varsdec(tokens []obj.Token, setterIndex int) {
-	// Name is not defined?
-	if len(tokens) < 2 {
-		first := tokens[0]
-		fract.IPanicC(first.File, first.Line, first.Column+len(first.Val), obj.SyntaxPanic, "Name is not given!")
-	}
-	var inf varInfo
-	inf.shortDeclaration = true
-	names := p.getShortVarDecNames(tokens[:setterIndex])
-	values := p.getShortVarDecValues(tokens[setterIndex+1:])
-	setter := tokens[setterIndex]
-	if len(values) == 0 {
-		fract.IPanic(tokens[setterIndex], obj.SyntaxPanic, "Value is not given!")
-	} else if len(values) == 1 {
-		val := values[0]
-		if val.Tag == "function_multiple_returns" {
-			if len(names) != 1 {
-				goto multiple
-			}
+func (p *Parser) varsdec(s *ast.ShortVarDecl) {
+	for _, name := range s.Names {
+		if name.Name.Val != "_" {
+			p.checkDefined(&p.defs, name.Name)
 		}
-		if len(names) == 1 && names[0].name == "_" {
-			fract.IPanic(tokens[0], obj.SyntaxPanic, "Invalid name!")
-		}
-		for _, info := range names {
-			switch info.valType {
-			case "mut":
-				val.Mut = true
-			case "const":
-				val.Const = true
-			}
-			if p.funcTempVars != -1 {
-				p.funcTempVars++
-			}
-			p.defs.Vars = append(p.defs.Vars, &oop.Var{
-				Name: info.name,
-				Val:  val,
-				Line: setter.Line,
-			})
-			val.Mut = false
-			val.Const = false
-		}
-		values = nil
-		return
 	}
-multiple:
-	if len(values) != len(names) {
-		if len(values) == 1 && values[0].Tag == "function_multiple_returns" {
-			values = values[0].Data.(*oop.ListModel).Elems
-			if len(values) == len(names) {
-				goto create
-			}
-		}
-		fract.IPanic(tokens[setterIndex], obj.SyntaxPanic, "Value assignment is wrong!")
+	var values []oop.Val
+	for _, v := range s.Vals {
+		values = append(values, *p.processVal(v))
 	}
-create:
-	for index, info := range names {
-		if info.name == "_" {
+	values = unpackValues(values, len(s.Names), s.Setter)
+	for i, name := range s.Names {
+		if name.Name.Val == "_" {
 			continue
 		}
-		val := values[index]
-		switch info.valType {
-		case "mut":
-			val.Mut = true
-		case "const":
-			val.Const = true
-		}
-		if p.funcTempVars != -1 {
-			p.funcTempVars++
-		}
+		val := values[i]
+		val.Mut = name.Type == "mut"
+		val.Const = name.Type == "const"
 		p.defs.Vars = append(p.defs.Vars, &oop.Var{
-			Name: info.name,
+			Name: name.Name.Val,
 			Val:  val,
-			Line: setter.Line,
+			Line: s.Setter.Line,
 		})
 	}
-	names = nil
-	values = nil
 }
 
-// Process variable set statement.
-func (p *Parser) varset(tokens []obj.Token) {
-	var (
-		enumVal    *oop.Val
-		selections interface{}
-		valTokens  []obj.Token
-		setter     obj.Token
-	)
-	braceCount := 0
-	lastOpenBrace := -1
-	for i, tk := range tokens {
-		if tk.Type == fract.Brace {
-			switch tk.Val {
-			case "[":
-				braceCount++
-				if braceCount == 1 {
-					lastOpenBrace = i
-				}
-			case "]":
-				braceCount--
-			}
+// nameRef returns reference to value of variable, returns nil if name is not variable.
+func (p *Parser) nameRef(nameTk obj.Token) *oop.Val {
+	if i, typ := p.defByName(nameTk.Val); typ == 'v' {
+		return &p.defs.Vars[i].Val
+	}
+	return nil
+}
+
+// fieldRef returns reference to value of field, returns nil if field is not variable.
+func fieldRef(val oop.Val, nameTk obj.Token) *oop.Val {
+	switch val.Type {
+	case oop.Package:
+		src := val.Data.(*importInfo).src
+		checkPublic(nil, nameTk)
+		return src.nameRef(nameTk)
+	case oop.StructIns:
+		ins := val.Data.(oop.StructInstance)
+		checkPublic(ins.File, nameTk)
+		if i := ins.Fields.VarIndexByName(nameTk.Val); i != -1 {
+			return &ins.Fields.Vars[i].Val
 		}
-		if braceCount > 0 {
-			continue
+	case oop.ClassIns:
+		ins := val.Data.(oop.ClassInstance)
+		checkPublic(ins.File, nameTk)
+		if i, typ := ins.Defs.DefByName(nameTk.Val); typ == 'v' {
+			return &ins.Defs.Vars[i].Val
 		}
-		if tk.Type == fract.Operator && tk.Val[len(tk.Val)-1] == '=' {
-			setter = tk
-			if lastOpenBrace == -1 {
-				enumVal = p.processValuePart(valuePartInfo{valType: "mut", tokens: tokens[:i]})
-				valTokens = tokens[i+1:]
-				break
-			}
-			enumVal = p.processValuePart(valuePartInfo{valType: "mut", tokens: tokens[:lastOpenBrace]})
-			valTokens = tokens[lastOpenBrace+1 : i-1]
-			// Index value is empty?
-			if len(valTokens) == 0 {
-				fract.IPanic(setter, obj.SyntaxPanic, "Index is not given!")
-			}
-			selections = enumerableSelections(*enumVal, *p.processValTokens(valTokens), setter)
-			valTokens = tokens[i+1:]
-			break
+	}
+	return nil
+}
+
+// ref returns reference to value of expression.
+// Returns mutable copy of value if expression is not a define.
+func (p *Parser) ref(e ast.Expr) *oop.Val {
+	switch t := e.(type) {
+	case *ast.Name:
+		if ref := p.nameRef(t.Tk); ref != nil {
+			return ref
+		}
+	case *ast.Selector:
+		val := p.processValue(t.X, "mut")
+		if ref := fieldRef(*val, t.Name); ref != nil {
+			return ref
 		}
+		ref := *p.selectorValue(*val, t.Name, "mut")
+		ref.Mut = true
+		return &ref
 	}
-	if len(valTokens) == 0 {
-		fract.IPanicC(setter.File, setter.Line, setter.Column+len(setter.Val), obj.SyntaxPanic, "Value is not given!")
+	return p.processValue(e, "mut")
+}
+
+// assignSelections returns selections of assignment target and checks target is changeable.
+// index is nil if target is not indexed.
+func assignSelections(enumVal, index *oop.Val, setter obj.Token) interface{} {
+	var selections interface{}
+	if index != nil {
+		selections = enumerableSelections(*enumVal, *index, setter)
 	}
 	// Check const state.
 	if enumVal.Const {
 		fract.IPanic(setter, obj.SyntaxPanic, "Values is cannot changed of constant defines!")
 	}
-	val := *p.processValTokens(valTokens)
+	return selections
+}
+
+// Process variable set statement.
+func (p *Parser) varset(s *ast.Assign) {
+	var (
+		enumVal *oop.Val
+		index   *oop.Val
+	)
+	if t, ok := s.Target.(*ast.Index); ok {
+		enumVal = p.ref(t.X)
+		index = p.processVal(t.Index)
+	} else {
+		enumVal = p.ref(s.Target)
+	}
+	selections := assignSelections(enumVal, index, s.Setter)
+	p.assign(enumVal, selections, s.Setter, *p.processVal(s.Val))
+}
+
+// assign value to target by setter.
+func (p *Parser) assign(enumVal *oop.Val, selections interface{}, setter obj.Token, val oop.Val) {
 	if val.Data == nil {
 		fract.IPanic(setter, obj.ValuePanic, "Invalid value!")
 	}
-	operator := obj.Token{Val: string(setter.Val[:len(setter.Val)-1])}
+	operator := setter
+	operator.Val = setter.Val[:len(setter.Val)-1]
 	if selections == nil {
 		switch setter.Val {
 		case "=": // =
+			val.Mut = enumVal.Mut
 			*enumVal = val
 		default: // Other assignments.
+			mut := enumVal.Mut
 			*enumVal = arithmeticProcess{
 				operator: operator,
-				left:     tokens,
 				leftVal:  *enumVal,
-				right:    []obj.Token{setter},
 				rightVal: val,
 			}.solve()
+			enumVal.Mut = mut
 		}
 		return
 	}
@@ -351,7 +213,7 @@ func (p *Parser) varset(tokens []obj.Token) {
 		switch setter.Val {
 		case "=":
 			switch t := selections.(type) {
-			case oop.ListModel:
+			case *oop.ListModel:
 				for _, key := range t.Elems {
 					m.Map[key] = val
 				}
@@ -360,7 +222,7 @@ func (p *Parser) varset(tokens []obj.Token) {
 			}
 		default: // Other assignments.
 			switch t := selections.(type) {
-			case oop.ListModel:
+			case *oop.ListModel:
 				for _, key := range t.Elems {
 					v, ok := m.Map[key]
 					if !ok {
@@ -369,9 +231,7 @@ func (p *Parser) varset(tokens []obj.Token) {
 					}
 					m.Map[key] = arithmeticProcess{
 						operator: operator,
-						left:     tokens,
 						leftVal:  v,
-						right:    []obj.Token{setter},
 						rightVal: val,
 					}.solve()
 				}
@@ -383,9 +243,7 @@ func (p *Parser) varset(tokens []obj.Token) {
 				}
 				m.Map[t] = arithmeticProcess{
 					operator: operator,
-					left:     tokens,
 					leftVal:  d,
-					right:    []obj.Token{setter},
 					rightVal: val,
 				}.solve()
 			}
@@ -398,9 +256,7 @@ func (p *Parser) varset(tokens []obj.Token) {
 			default: // Other assignments.
 				enumVal.Data.(*oop.ListModel).Elems[i] = arithmeticProcess{
 					operator: operator,
-					left:     tokens,
 					leftVal:  enumVal.Data.(*oop.ListModel).Elems[i],
-					right:    []obj.Token{setter},
 					rightVal: val,
 				}.solve()
 			}
@@ -424,9 +280,7 @@ func (p *Parser) varset(tokens []obj.Token) {
 			default: // Other assignments.
 				val = arithmeticProcess{
 					operator: operator,
-					left:     tokens,
-					leftVal:  oop.Val{Data: enumVal.Data.(string)[i], Type: oop.Int},
-					right:    []obj.Token{setter},
+					leftVal:  oop.Val{Data: int64(enumVal.Data.(string)[i]), Type: oop.Int},
 					rightVal: val,
 				}.solve()
 				if val.Type != oop.String {
@@ -0,0 +1,720 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/fract-lang/fract/bytecode"
+	"github.com/fract-lang/fract/oop"
+	"github.com/fract-lang/fract/pkg/fract"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// Lengths of defines of scope.
+type scope struct {
+	varLen int
+	fnLen  int
+	impLen int
+}
+
+// Call in progress.
+type callState struct {
+	val  oop.Val // Callee.
+	args *funcArgs
+	typ  string // Type of current parameter.
+}
+
+// Iteration in progress.
+type iterState struct {
+	it     *iterator
+	varLen int
+	index  *oop.Var // Nil if comprehension.
+	elem   *oop.Var
+	list   *oop.ListModel // Result of comprehension.
+}
+
+// Match in progress.
+type matchState struct {
+	val   oop.Val
+	base  int // Stack length at match.
+	binds []oop.VarDef
+}
+
+// Try block in progress.
+type tryState struct {
+	scope
+	deferLen   int
+	traceLen   int
+	catch      int       // Position of catch block.
+	finally    int       // Position of finally block of panics, -1 if not exist.
+	catching   bool      // Catch block is processing.
+	finalizing bool      // Finally block of panic is processing.
+	panic      obj.Panic // Catched panic.
+	// Lengths of states at try.
+	stackLen int
+	scopeLen int
+	iterLen  int
+	callLen  int
+	matchLen int
+	classLen int
+}
+
+// Virtual machine of compiled code.
+type vm struct {
+	p          *Parser
+	prog       *bytecode.Program
+	code       *bytecode.Code
+	pc         int
+	stack      []*oop.Val
+	scopes     []scope
+	iters      []*iterState
+	calls      []*callState
+	matches    []*matchState
+	classes    []*oop.Class
+	tries      []*tryState
+	selections interface{} // Selections of assignment target.
+}
+
+// exec executes compiled code and returns keyword state.
+func (p *Parser) exec(code *bytecode.Code) uint8 {
+	m := &vm{p: p, prog: p.prog, code: code}
+	// Iterators of loops that left by return or panic are closed.
+	defer m.closeIters(0)
+	for {
+		if finished, kws := m.run(); finished {
+			return kws
+		}
+	}
+}
+
+// run instructions until end of code or catched panic.
+// Returns false if panic is catched.
+func (m *vm) run() (finished bool, kws uint8) {
+	defer func() {
+		if r := recover(); r != nil {
+			cp, ok := r.(obj.Panic)
+			if !ok || cp.Fatal || !m.catch(cp) {
+				panic(r)
+			}
+		}
+	}()
+	for m.pc < len(m.code.Instrs) {
+		instr := m.code.Instrs[m.pc]
+		m.pc++
+		if kws, ok := m.step(instr); ok {
+			return true, kws
+		}
+	}
+	return true, fract.NA
+}
+
+// catch panic by try block, returns false if not exist any try block.
+func (m *vm) catch(cp obj.Panic) bool {
+	// Panics of catch blocks are catched by upper try blocks.
+	for len(m.tries) > 0 && m.tries[len(m.tries)-1].catching {
+		t := m.tries[len(m.tries)-1]
+		// Finally block runs before panic is raised again.
+		if t.finally != -1 && !t.finalizing {
+			m.restore(t, &cp)
+			t.finalizing = true
+			m.pc = t.finally
+			return true
+		}
+		m.tries = m.tries[:len(m.tries)-1]
+	}
+	if len(m.tries) == 0 {
+		return false
+	}
+	t := m.tries[len(m.tries)-1]
+	m.restore(t, &cp)
+	t.catching = true
+	m.pc = t.catch
+	return true
+}
+
+// restore states to try and set catched panic.
+func (m *vm) restore(t *tryState, cp *obj.Panic) {
+	m.p.rt.stackTrace(cp)
+	m.p.rt.calls = m.p.rt.calls[:t.traceLen]
+	m.p.rt.runDefers(t.deferLen)
+	m.truncate(t.scope)
+	m.stack = m.stack[:t.stackLen]
+	m.scopes = m.scopes[:t.scopeLen]
+	m.closeIters(t.iterLen)
+	m.calls = m.calls[:t.callLen]
+	m.matches = m.matches[:t.matchLen]
+	m.classes = m.classes[:t.classLen]
+	t.panic = *cp
+}
+
+// closeIters closes iterators after length and removes them.
+func (m *vm) closeIters(n int) {
+	for i := len(m.iters) - 1; i >= n; i-- {
+		m.iters[i].it.close()
+	}
+	m.iters = m.iters[:n]
+}
+
+// setReturn pops count of values and sets them as returned values.
+func (m *vm) setReturn(n int) {
+	vals := make([]oop.Val, n)
+	for i, val := range m.stack[len(m.stack)-n:] {
+		vals[i] = *val
+	}
+	m.stack = m.stack[:len(m.stack)-n]
+	m.p.setReturn(vals)
+}
+
+// token returns token of current instruction.
+func (m *vm) token(val string) obj.Token {
+	pos := m.code.Pos[m.pc-1]
+	return obj.Token{File: m.p.Lex.File, Val: val, Line: pos.Line, Column: pos.Column}
+}
+
+func (m *vm) push(val *oop.Val) { m.stack = append(m.stack, val) }
+
+func (m *vm) pop() *oop.Val {
+	val := m.stack[len(m.stack)-1]
+	m.stack = m.stack[:len(m.stack)-1]
+	return val
+}
+
+func (m *vm) top() *oop.Val { return m.stack[len(m.stack)-1] }
+
+// pushMode pushes copy of value by mode.
+func (m *vm) pushMode(val *oop.Val, mode int) {
+	cpy := *val
+	cpy.Mut = m.mode(mode) == "mut"
+	m.push(&cpy)
+}
+
+// mode returns value type of mode.
+func (m *vm) mode(mode int) string {
+	switch mode {
+	case bytecode.ModeVar:
+		return "var"
+	case bytecode.ModeMut:
+		return "mut"
+	case bytecode.ModeArg:
+		return argMode(m.calls[len(m.calls)-1].typ)
+	}
+	return ""
+}
+
+// defs returns defmap of definitions.
+func (m *vm) defs() *oop.DefMap {
+	if len(m.classes) > 0 {
+		return &m.classes[len(m.classes)-1].Defs
+	}
+	return &m.p.defs
+}
+
+func (m *vm) scope() scope {
+	return scope{
+		varLen: len(m.p.defs.Vars),
+		fnLen:  len(m.p.defs.Funcs),
+		impLen: len(m.p.packages),
+	}
+}
+
+// truncate defines to scope.
+func (m *vm) truncate(s scope) {
+	m.p.defs.Vars = m.p.defs.Vars[:s.varLen]
+	m.p.defs.Funcs = m.p.defs.Funcs[:s.fnLen]
+	m.p.packages = m.p.packages[:s.impLen]
+}
+
+// endTry ends try block or catch block.
+func (m *vm) endTry() {
+	t := m.tries[len(m.tries)-1]
+	m.tries = m.tries[:len(m.tries)-1]
+	if t.catching {
+		m.p.defs.Vars = m.p.defs.Vars[:t.varLen]
+		m.p.defs.Funcs = m.p.defs.Funcs[:t.fnLen]
+	} else {
+		m.truncate(t.scope)
+	}
+	m.p.rt.runDefers(t.deferLen)
+}
+
+// function returns function of compiled function.
+// Default values of parameters are popped from stack.
+func (m *vm) function(i int, line int) *oop.Fn {
+	code := m.prog.Funcs[i]
+	fn := &oop.Fn{Name: code.Name, Line: line, Src: m.p, Code: code, Generator: code.Generator}
+	n := 0
+	for _, param := range code.Params {
+		if param.Default {
+			n++
+		}
+	}
+	defaults := m.stack[len(m.stack)-n:]
+	m.stack = m.stack[:len(m.stack)-n]
+	for _, param := range code.Params {
+		fnParam := oop.Param{Name: param.Name, Params: param.Params, Type: param.Type}
+		if param.Default {
+			fnParam.DefaultVal = *defaults[0]
+			defaults = defaults[1:]
+			fn.DefaultParamCount++
+		}
+		fn.Params = append(fn.Params, fnParam)
+	}
+	return fn
+}
+
+// closure captures defines of local scope to function if function is declared in local scope.
+func (m *vm) closure(fn *oop.Fn) {
+	if m.p.fn != nil {
+		m.p.capture(fn)
+		return
+	}
+	if len(m.scopes) == 0 && len(m.iters) == 0 && len(m.tries) == 0 {
+		return
+	}
+	// Defines of top-level of source are before outermost block.
+	s := m.scope()
+	if len(m.scopes) > 0 {
+		s = minScope(s, m.scopes[0])
+	}
+	if len(m.tries) > 0 {
+		s = minScope(s, m.tries[0].scope)
+	}
+	if len(m.iters) > 0 && m.iters[0].varLen < s.varLen {
+		s.varLen = m.iters[0].varLen
+	}
+	m.p.srcVars[1], m.p.srcFuncs[1] = s.varLen, s.fnLen
+	m.p.capture(fn)
+}
+
+// minScope returns lengths of outer scope of a and b.
+func minScope(a, b scope) scope {
+	if b.varLen < a.varLen {
+		a.varLen = b.varLen
+	}
+	if b.fnLen < a.fnLen {
+		a.fnLen = b.fnLen
+	}
+	return a
+}
+
+// chanSelect pops operands of n cases, selects case and jumps to body of it.
+// Cases are the next n instructions.
+func (m *vm) chanSelect(n int) {
+	tk := m.token("")
+	instrs := m.code.Instrs[m.pc : m.pc+n]
+	cases := make([]commCase, n)
+	for i := n - 1; i >= 0; i-- {
+		switch instrs[i].B {
+		case bytecode.CaseSend:
+			cases[i].send = true
+			cases[i].val = *m.pop()
+			cases[i].ch = channel(*m.pop(), tk)
+		case bytecode.CaseRecv:
+			cases[i].ch = channel(*m.pop(), tk)
+		}
+	}
+	i, val := selectCase(cases, tk)
+	m.push(&val)
+	m.pc = instrs[i].A
+}
+
+// define variable to definitions.
+func (m *vm) define(name string, line int, val oop.Val) {
+	defs := m.defs()
+	defs.Vars = append(defs.Vars, &oop.Var{Name: name, Line: line, Val: val})
+}
+
+// step executes instruction.
+// Returns keyword state and true if execution is finished.
+func (m *vm) step(instr bytecode.Instr) (uint8, bool) {
+	switch instr.Op {
+	case bytecode.OpConst:
+		c := m.prog.Consts[instr.A]
+		m.pushMode(&oop.Val{Data: c.Data, Type: c.Type}, instr.B)
+	case bytecode.OpName:
+		m.pushMode(m.p.processNameValue(m.mode(instr.B), m.token(m.prog.Name(instr.A))), instr.B)
+	case bytecode.OpSelect:
+		val := m.pop()
+		m.pushMode(m.p.selectorValue(*val, m.token(m.prog.Name(instr.A)), m.mode(instr.B)), instr.B)
+	case bytecode.OpEnum:
+		if instr.A == 2 {
+			checkElemName(*m.top(), m.token(""))
+			break
+		} else if instr.A == 1 && isIterable(*m.top()) {
+			break
+		}
+		if !m.top().IsEnum() {
+			if instr.A == 1 {
+				fract.IPanic(m.token(""), obj.ValuePanic, "Foreach loop must defined enumerable value!")
+			}
+			fract.IPanic(m.token(""), obj.ValuePanic, "Index accessor is cannot used with not enumerable values!")
+		}
+	case bytecode.OpIndex:
+		index := m.pop()
+		val := m.pop()
+		tk := m.token("")
+		m.pushMode(m.p.selectEnumerable(m.mode(instr.B), *val, tk, enumerableSelections(*val, *index, tk)), instr.B)
+	case bytecode.OpBinary:
+		right := m.pop()
+		left := m.pop()
+		val := arithmeticProcess{
+			leftVal:  *left,
+			rightVal: *right,
+			operator: m.token(m.prog.Name(instr.A)),
+		}.solve()
+		m.pushMode(&val, instr.B)
+	case bytecode.OpCompare:
+		right := m.pop()
+		left := m.pop()
+		m.push(&oop.Val{Data: m.p.compare(*left, *right, m.token(m.prog.Name(instr.A))), Type: oop.Bool})
+	case bytecode.OpCond:
+		m.push(&oop.Val{Data: compareValues("==", *m.pop(), oop.Val{Data: true, Type: oop.Bool}), Type: oop.Bool})
+	case bytecode.OpJump:
+		m.pc = instr.A
+	case bytecode.OpJumpFalse:
+		if !m.pop().Data.(bool) {
+			m.pc = instr.A
+		}
+	case bytecode.OpJumpTrueKeep:
+		if m.top().Data.(bool) {
+			m.pc = instr.A
+		} else {
+			m.pop()
+		}
+	case bytecode.OpJumpFalseKeep:
+		if !m.top().Data.(bool) {
+			m.pc = instr.A
+		} else {
+			m.pop()
+		}
+	case bytecode.OpList:
+		list := oop.NewListModel()
+		for _, elem := range m.stack[len(m.stack)-instr.A:] {
+			list.PushBack(*elem)
+		}
+		m.stack = m.stack[:len(m.stack)-instr.A]
+		m.pushMode(&oop.Val{Data: list, Type: oop.List}, instr.B)
+	case bytecode.OpMap:
+		m.pushMode(&oop.Val{Data: oop.NewMapModel(), Type: oop.Map}, instr.B)
+	case bytecode.OpKey:
+		key := *m.top()
+		if _, ok := m.stack[len(m.stack)-2].Data.(oop.MapModel).Map[key]; ok {
+			fract.IPanic(m.token(""), obj.ValuePanic, "Key is already defined!")
+		}
+	case bytecode.OpSetKey:
+		val := m.pop()
+		key := m.pop()
+		m.top().Data.(oop.MapModel).Map[*key] = *val
+	case bytecode.OpLambda:
+		fn := m.function(instr.A, 0)
+		m.closure(fn)
+		m.pushMode(&oop.Val{Data: fn, Type: oop.Func}, instr.B)
+	case bytecode.OpStruct:
+		s := m.prog.Structs[instr.A]
+		fields := make([]obj.Token, len(s.Fields))
+		for i, field := range s.Fields {
+			fields[i] = obj.Token{Val: field}
+		}
+		m.pushMode(m.p.buildStruct(s.Name, fields), instr.B)
+	case bytecode.OpCallBegin:
+		val := *m.pop()
+		tk := m.token("")
+		if instr.A == 1 && val.Type != oop.Func {
+			fract.IPanic(tk, obj.ValuePanic, "Value is not function!")
+		}
+		m.calls = append(m.calls, &callState{val: val, args: newFuncArgs(callee(val, tk))})
+	case bytecode.OpArg:
+		c := m.calls[len(m.calls)-1]
+		tk := m.token("")
+		name := obj.Token{}
+		if instr.A != -1 {
+			name = m.token(m.prog.Name(instr.A))
+		}
+		c.typ = c.args.next(name, tk, instr.B == 1)
+	case bytecode.OpArgEnd:
+		m.calls[len(m.calls)-1].args.push(*m.pop(), instr.B == 1, m.token(""))
+	case bytecode.OpCall, bytecode.OpDefer, bytecode.OpGo:
+		c := m.calls[len(m.calls)-1]
+		m.calls = m.calls[:len(m.calls)-1]
+		tk := m.token("")
+		model := &funcCall{fn: c.args.fn, errTk: tk, args: c.args.done(tk), rt: m.p.rt}
+		switch instr.Op {
+		case bytecode.OpCall:
+			m.pushMode(callValue(c.val, model), instr.B)
+		case bytecode.OpDefer:
+			m.p.rt.defers = append(m.p.rt.defers, model)
+		default:
+			m.p.rt.goCall(model)
+		}
+	case bytecode.OpPop:
+		// Print value if live interpreting.
+		if val := m.pop(); instr.A == 1 && m.p.rt.Interactive {
+			if val.Data != nil {
+				m.p.rt.Output(m.p.rt.Stdout, val.String()+"\n")
+			}
+		}
+	case bytecode.OpDefined:
+		m.p.checkDefined(m.defs(), m.token(m.prog.Name(instr.A)))
+	case bytecode.OpValid:
+		if m.top().Data == nil {
+			fract.IPanic(m.token(""), obj.ValuePanic, "Invalid value!")
+		}
+	case bytecode.OpVar, bytecode.OpShortVar:
+		val := *m.pop()
+		if instr.A == -1 {
+			break
+		}
+		val.Mut = instr.B&bytecode.FlagMut != 0
+		val.Const = instr.B&bytecode.FlagConst != 0
+		tk := m.token("")
+		m.define(m.prog.Name(instr.A), tk.Line, val)
+	case bytecode.OpUnpack:
+		vals := make([]oop.Val, instr.B)
+		for i, val := range m.stack[len(m.stack)-instr.B:] {
+			vals[i] = *val
+		}
+		m.stack = m.stack[:len(m.stack)-instr.B]
+		for _, val := range unpackValues(vals, instr.A, m.token("")) {
+			val := val
+			m.push(&val)
+		}
+	case bytecode.OpConstDef:
+		val := *m.pop()
+		val.Const = true
+		m.define(m.prog.Name(instr.A), m.token("").Line, val)
+	case bytecode.OpFunc:
+		defs := m.defs()
+		fn := m.function(instr.A, m.token("").Line)
+		defs.Funcs = append(defs.Funcs, fn)
+		// Methods of classes are not closures.
+		if len(m.classes) == 0 {
+			// Function is captured by itself for recursive calls.
+			m.closure(fn)
+		}
+	case bytecode.OpParamsDefault:
+		if m.top().Type != oop.List {
+			fract.IPanic(m.token(""), obj.ValuePanic, "Params parameter is can only take list values!")
+		}
+	case bytecode.OpClass:
+		m.classes = append(m.classes, &oop.Class{Name: m.prog.Name(instr.A), File: m.p.Lex.File})
+	case bytecode.OpCtor:
+		class := m.classes[len(m.classes)-1]
+		if class.Constructor != nil {
+			fract.IPanic(m.token(""), obj.NamePanic, "Constructor is already defined!")
+		}
+		class.Constructor = class.Defs.Funcs[len(class.Defs.Funcs)-1]
+		class.Defs.Funcs = class.Defs.Funcs[:len(class.Defs.Funcs)-1]
+	case bytecode.OpEndClass:
+		class := m.classes[len(m.classes)-1]
+		m.classes = m.classes[:len(m.classes)-1]
+		if class.Constructor == nil { // Constructor is not given.
+			class.Constructor = &oop.Fn{Name: class.Name + ".constructor", Src: m.p}
+		}
+		m.push(&oop.Val{Data: *class, Type: oop.ClassDef})
+	case bytecode.OpRefName:
+		tk := m.token(m.prog.Name(instr.A))
+		if ref := m.p.nameRef(tk); ref != nil {
+			m.push(ref)
+			break
+		}
+		m.pushMode(m.p.processNameValue("mut", tk), bytecode.ModeMut)
+	case bytecode.OpRefField:
+		val := *m.pop()
+		tk := m.token(m.prog.Name(instr.A))
+		if ref := fieldRef(val, tk); ref != nil {
+			m.push(ref)
+			break
+		}
+		m.pushMode(m.p.selectorValue(val, tk, "mut"), bytecode.ModeMut)
+	case bytecode.OpTarget:
+		var index *oop.Val
+		if instr.B == 1 {
+			index = m.pop()
+		}
+		m.selections = assignSelections(m.top(), index, m.token(""))
+	case bytecode.OpAssign:
+		val := m.pop()
+		m.p.assign(m.pop(), m.selections, m.token(m.prog.Name(instr.A)), *val)
+		m.selections = nil
+	case bytecode.OpScope:
+		m.scopes = append(m.scopes, m.scope())
+	case bytecode.OpEndScope:
+		m.truncate(m.scopes[len(m.scopes)-1])
+		m.scopes = m.scopes[:len(m.scopes)-1]
+	case bytecode.OpIter:
+		it := &iterState{
+			it:     m.p.rt.iterate(*m.pop(), m.token("")),
+			varLen: len(m.p.defs.Vars),
+			index:  &oop.Var{Name: m.prog.Name(instr.A), Val: oop.Val{Data: int64(0), Type: oop.Int}},
+			elem:   &oop.Var{Name: m.prog.Name(instr.B)},
+		}
+		m.p.defs.Vars = append(m.p.defs.Vars, it.index, it.elem)
+		m.iters = append(m.iters, it)
+	case bytecode.OpCompIter:
+		it := &iterState{
+			it:     m.p.rt.iterate(*m.pop(), m.token("")),
+			varLen: len(m.p.defs.Vars),
+			elem:   &oop.Var{Name: m.prog.Name(instr.A)},
+			list:   oop.NewListModel(),
+		}
+		m.p.defs.Vars = append(m.p.defs.Vars, it.elem)
+		m.iters = append(m.iters, it)
+	case bytecode.OpNext:
+		it := m.iters[len(m.iters)-1]
+		if !it.it.next() {
+			m.pc = instr.A
+			break
+		}
+		// Variables are new for each iteration, closures are capture own iteration.
+		i := it.varLen
+		if it.index != nil {
+			it.index = &oop.Var{Name: it.index.Name, Val: it.it.a}
+			m.p.defs.Vars[i] = it.index
+			i++
+		}
+		it.elem = &oop.Var{Name: it.elem.Name, Val: it.it.b}
+		m.p.defs.Vars[i] = it.elem
+	case bytecode.OpAppend:
+		m.iters[len(m.iters)-1].list.PushBack(*m.pop())
+	case bytecode.OpEndIter:
+		it := m.iters[len(m.iters)-1]
+		m.closeIters(len(m.iters) - 1)
+		m.p.defs.Vars = m.p.defs.Vars[:it.varLen]
+		if instr.A == 1 {
+			m.pushMode(&oop.Val{Data: it.list, Type: oop.List}, instr.B)
+		}
+	case bytecode.OpTry:
+		m.tries = append(m.tries, &tryState{
+			scope:    m.scope(),
+			deferLen: len(m.p.rt.defers),
+			traceLen: len(m.p.rt.calls),
+			catch:    instr.A,
+			finally:  instr.B,
+			stackLen: len(m.stack),
+			scopeLen: len(m.scopes),
+			iterLen:  len(m.iters),
+			callLen:  len(m.calls),
+			matchLen: len(m.matches),
+			classLen: len(m.classes),
+		})
+	case bytecode.OpEndTry, bytecode.OpEndCatch:
+		m.endTry()
+	case bytecode.OpCatch:
+		m.p.catchVar(m.token(m.prog.Name(instr.A)), m.tries[len(m.tries)-1].panic)
+	case bytecode.OpCatchType:
+		if m.tries[len(m.tries)-1].panic.Type != m.prog.Name(instr.A) {
+			m.pc = instr.B
+		}
+	case bytecode.OpRethrow:
+		cp := m.tries[len(m.tries)-1].panic
+		m.endTry()
+		panic(cp)
+	case bytecode.OpFinally:
+		m.tries[len(m.tries)-1].finalizing = true
+	case bytecode.OpSetReturn:
+		m.setReturn(instr.A)
+	case bytecode.OpReturn:
+		if instr.A != -1 {
+			m.setReturn(instr.A)
+		}
+		for len(m.tries) > 0 {
+			m.endTry()
+		}
+		return fract.FUNCReturn, true
+	case bytecode.OpYield:
+		if m.p.gen.yield(*m.pop()) {
+			m.pc = instr.A
+		}
+	case bytecode.OpImport:
+		imp := m.p.importPath(m.token(""), m.prog.Name(instr.A), instr.B == 1)
+		m.push(&oop.Val{Data: imp, Type: oop.Package})
+	case bytecode.OpPackage:
+		m.p.addPackage(m.pop().Data.(*importInfo), m.prog.Name(instr.A), m.token(""))
+	case bytecode.OpMatch:
+		m.matches = append(m.matches, &matchState{val: *m.pop(), base: len(m.stack)})
+	case bytecode.OpPatBegin:
+		s := m.matches[len(m.matches)-1]
+		m.stack = m.stack[:s.base]
+		s.binds = nil
+		m.push(&s.val)
+	case bytecode.OpPatName:
+		s := m.matches[len(m.matches)-1]
+		m.p.bindPattern(m.token(m.prog.Name(instr.A)), *m.pop(), &s.binds)
+	case bytecode.OpPatEq:
+		pattern := m.pop()
+		if !compareValues("==", *m.pop(), *pattern) {
+			m.pc = instr.A
+		}
+	case bytecode.OpPatList, bytecode.OpPatListRest:
+		val := m.pop()
+		if val.Type != oop.List {
+			m.pc = instr.A
+			break
+		}
+		elems := val.Data.(*oop.ListModel).Elems
+		n := instr.B
+		if instr.Op == bytecode.OpPatListRest {
+			n--
+			if len(elems) < n {
+				m.pc = instr.A
+				break
+			}
+			m.push(&oop.Val{Data: oop.NewListModel(elems[n:]...), Type: oop.List})
+		} else if len(elems) != n {
+			m.pc = instr.A
+			break
+		}
+		for i := n - 1; i >= 0; i-- {
+			m.push(&elems[i])
+		}
+	case bytecode.OpIsStruct:
+		if m.top().Type != oop.StructDef {
+			m.pc = instr.A
+		}
+	case bytecode.OpPatStruct:
+		s := m.pop().Data.(oop.Struct)
+		val := m.pop()
+		if val.Type != oop.StructIns {
+			m.pc = instr.A
+			break
+		}
+		ins := val.Data.(oop.StructInstance)
+		if !s.IsInstance(ins) {
+			m.pc = instr.A
+			break
+		}
+		if instr.B != len(s.Constructor.Params) {
+			fract.IPanic(m.token(""), obj.SyntaxPanic, "Pattern field count is not same with struct field count!")
+		}
+		for i := instr.B - 1; i >= 0; i-- {
+			m.push(&ins.Fields.Vars[ins.Fields.VarIndexByName(s.Constructor.Params[i].Name)].Val)
+		}
+	case bytecode.OpPatBind:
+		m.scopes = append(m.scopes, m.scope())
+		m.p.defs.Vars = append(m.p.defs.Vars, m.matches[len(m.matches)-1].binds...)
+	case bytecode.OpNoMatch:
+		fract.Panic(m.token(""), obj.MatchPanic, "No case matched for value: "+m.matches[len(m.matches)-1].val.String())
+	case bytecode.OpEndMatch:
+		s := m.matches[len(m.matches)-1]
+		m.matches = m.matches[:len(m.matches)-1]
+		m.stack = m.stack[:s.base]
+	case bytecode.OpSend:
+		val := *m.pop()
+		channel(*m.pop(), m.token("")).Send(m.token(""), val)
+	case bytecode.OpFormat:
+		str := format(*m.pop(), m.prog.Name(instr.A), m.token(""))
+		m.push(&oop.Val{Data: str, Type: oop.String})
+	case bytecode.OpConcat:
+		var sb strings.Builder
+		for _, val := range m.stack[len(m.stack)-instr.A:] {
+			sb.WriteString(val.Data.(string))
+		}
+		m.stack = m.stack[:len(m.stack)-instr.A]
+		m.pushMode(&oop.Val{Data: sb.String(), Type: oop.String}, instr.B)
+	case bytecode.OpRecv:
+		val := receive(*m.pop(), m.token(""))
+		m.pushMode(&val, instr.B)
+	case bytecode.OpChanSelect:
+		m.chanSelect(instr.A)
+	}
+	return fract.NA, false
+}
@@ -0,0 +1,187 @@
+package decimal
+
+import (
+	"math/big"
+	"strings"
+)
+
+// DivPrecision is maximum count of fractional digits of division results.
+const DivPrecision = 28
+
+// Decimal is arbitrary-precision decimal number.
+// Value is Unscaled * 10^-Scale.
+//
+// ! Decimals are immutable, operations always returns new decimal.
+type Decimal struct {
+	Unscaled *big.Int
+	Scale    int
+}
+
+// pow10 returns 10^n.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// New returns decimal of integer.
+func New(i *big.Int) Decimal { return Decimal{Unscaled: new(big.Int).Set(i), Scale: 0} }
+
+// Parse decimal from string, returns false if string is not valid decimal.
+// Scale of decimal is count of written fractional digits.
+func Parse(s string) (Decimal, bool) {
+	exp := 0
+	if i := strings.IndexAny(s, "eE"); i != -1 {
+		e, ok := new(big.Int).SetString(s[i+1:], 10)
+		if !ok || !e.IsInt64() || e.Int64() > 1<<16 || e.Int64() < -(1<<16) {
+			return Decimal{}, false
+		}
+		exp = int(e.Int64())
+		s = s[:i]
+	}
+	scale := 0
+	if i := strings.IndexByte(s, '.'); i != -1 {
+		scale = len(s) - i - 1
+		s = s[:i] + s[i+1:]
+	}
+	if s == "" || s[len(s)-1] < '0' || s[len(s)-1] > '9' {
+		return Decimal{}, false
+	}
+	unscaled, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return Decimal{}, false
+	}
+	d := Decimal{Unscaled: unscaled, Scale: scale - exp}
+	if d.Scale < 0 {
+		d = Decimal{Unscaled: unscaled.Mul(unscaled, pow10(-d.Scale)), Scale: 0}
+	}
+	return d, true
+}
+
+// rescale returns unscaled value by scale.
+// ! Scale is must be greater or equals to scale of decimal.
+func (d Decimal) rescale(scale int) *big.Int {
+	if scale == d.Scale {
+		return d.Unscaled
+	}
+	return new(big.Int).Mul(d.Unscaled, pow10(scale-d.Scale))
+}
+
+// align returns unscaled values of decimals by same scale.
+func align(x, y Decimal) (*big.Int, *big.Int, int) {
+	scale := x.Scale
+	if y.Scale > scale {
+		scale = y.Scale
+	}
+	return x.rescale(scale), y.rescale(scale), scale
+}
+
+// trim removes trailing fractional zeros until minimum scale.
+func (d Decimal) trim(min int) Decimal {
+	ten := big.NewInt(10)
+	r := new(big.Int)
+	for d.Scale > min {
+		q, m := new(big.Int).QuoRem(d.Unscaled, ten, r)
+		if m.Sign() != 0 {
+			break
+		}
+		d = Decimal{Unscaled: q, Scale: d.Scale - 1}
+	}
+	return d
+}
+
+// Sign returns -1 if d < 0, 0 if d == 0 and 1 if d > 0.
+func (d Decimal) Sign() int { return d.Unscaled.Sign() }
+
+// Cmp compares decimals.
+// Returns -1 if d < x, 0 if d == x and 1 if d > x.
+func (d Decimal) Cmp(x Decimal) int {
+	left, right, _ := align(d, x)
+	return left.Cmp(right)
+}
+
+// Add returns d + x.
+func (d Decimal) Add(x Decimal) Decimal {
+	left, right, scale := align(d, x)
+	return Decimal{Unscaled: new(big.Int).Add(left, right), Scale: scale}
+}
+
+// Sub returns d - x.
+func (d Decimal) Sub(x Decimal) Decimal {
+	left, right, scale := align(d, x)
+	return Decimal{Unscaled: new(big.Int).Sub(left, right), Scale: scale}
+}
+
+// Mul returns d * x.
+func (d Decimal) Mul(x Decimal) Decimal {
+	return Decimal{Unscaled: new(big.Int).Mul(d.Unscaled, x.Unscaled), Scale: d.Scale + x.Scale}
+}
+
+// Quo returns d / x rounded half to even by DivPrecision.
+// Trailing zeros are removed until maximum scale of operands.
+// ! x is must be not zero.
+func (d Decimal) Quo(x Decimal) Decimal {
+	scale := DivPrecision
+	if d.Scale > scale {
+		scale = d.Scale
+	}
+	if x.Scale > scale {
+		scale = x.Scale
+	}
+	// d / x = du * 10^(xs+scale-ds) / xu at scale.
+	num := new(big.Int).Mul(d.Unscaled, pow10(x.Scale+scale-d.Scale))
+	q, r := new(big.Int).QuoRem(num, x.Unscaled, new(big.Int))
+	if r.Sign() != 0 {
+		// Round half to even.
+		r.Abs(r)
+		c := r.Lsh(r, 1).Cmp(new(big.Int).Abs(x.Unscaled))
+		if c > 0 || (c == 0 && q.Bit(0) == 1) {
+			if num.Sign() == x.Unscaled.Sign() {
+				q.Add(q, big.NewInt(1))
+			} else {
+				q.Sub(q, big.NewInt(1))
+			}
+		}
+	}
+	min := d.Scale
+	if x.Scale > min {
+		min = x.Scale
+	}
+	return Decimal{Unscaled: q, Scale: scale}.trim(min)
+}
+
+// Rem returns remainder of d / x truncated toward zero.
+// ! x is must be not zero.
+func (d Decimal) Rem(x Decimal) Decimal {
+	left, right, scale := align(d, x)
+	return Decimal{Unscaled: new(big.Int).Rem(left, right), Scale: scale}
+}
+
+// Int returns integer part of decimal.
+func (d Decimal) Int() *big.Int {
+	if d.Scale == 0 {
+		return new(big.Int).Set(d.Unscaled)
+	}
+	return new(big.Int).Quo(d.Unscaled, pow10(d.Scale))
+}
+
+// Float64 returns nearest float64 value of decimal.
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+// Rat returns rational value of decimal.
+func (d Decimal) Rat() *big.Rat { return new(big.Rat).SetFrac(d.Unscaled, pow10(d.Scale)) }
+
+func (d Decimal) String() string {
+	s := new(big.Int).Abs(d.Unscaled).String()
+	if d.Scale > 0 {
+		if len(s) <= d.Scale {
+			s = strings.Repeat("0", d.Scale-len(s)+1) + s
+		}
+		s = s[:len(s)-d.Scale] + "." + s[len(s)-d.Scale:]
+	}
+	if d.Sign() < 0 {
+		return "-" + s
+	}
+	return s
+}
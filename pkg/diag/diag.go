@@ -0,0 +1,136 @@
+// Package diag implements diagnostics of Fract code.
+package diag
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// Severity of diagnostic.
+type Severity uint8
+
+const (
+	Error Severity = iota
+	Warning
+)
+
+func (s Severity) String() string {
+	if s == Warning {
+		return "warning"
+	}
+	return "error"
+}
+
+func (s Severity) MarshalJSON() ([]byte, error) { return json.Marshal(s.String()) }
+
+func (s *Severity) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	switch text {
+	case "error":
+		*s = Error
+	case "warning":
+		*s = Warning
+	default:
+		return fmt.Errorf("invalid severity: %q", text)
+	}
+	return nil
+}
+
+// Span is source range of diagnostic.
+// End is same with start if range is a position.
+type Span struct {
+	File      string `json:"file"`
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	EndLine   int    `json:"endLine"`
+	EndColumn int    `json:"endColumn"`
+}
+
+// Diagnostic is error or warning of code.
+type Diagnostic struct {
+	Severity Severity `json:"severity"`
+	Code     string   `json:"code,omitempty"` // Type of panic or identifier of check.
+	Span     Span     `json:"span"`
+	Message  string   `json:"message"`
+	Fix      string   `json:"fix,omitempty"` // Suggested fix.
+}
+
+func (d Diagnostic) String() string {
+	s := fmt.Sprintf("%s:%d:%d: %s: %s", d.Span.File, d.Span.Line, d.Span.Column, d.Severity, d.Message)
+	if d.Code != "" {
+		s += " (" + d.Code + ")"
+	}
+	return s
+}
+
+// FromPanic returns error diagnostic of panic.
+func FromPanic(cp obj.Panic) Diagnostic {
+	msg := cp.Text
+	if msg == "" {
+		msg = cp.Msg
+	}
+	return Diagnostic{
+		Severity: Error,
+		Code:     cp.Type,
+		Span: Span{
+			File:      cp.File,
+			Line:      cp.Line,
+			Column:    cp.Column,
+			EndLine:   cp.Line,
+			EndColumn: cp.Column,
+		},
+		Message: msg,
+	}
+}
+
+// List of diagnostics.
+type List []Diagnostic
+
+// Add appends diagnostic to list.
+func (l *List) Add(d Diagnostic) { *l = append(*l, d) }
+
+// Run calls function and adds panic of it to list.
+// Returns false if function is panicked.
+// Panics that are not thrown by interpreter are panicked again.
+func (l *List) Run(f func()) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			cp, isPanic := r.(obj.Panic)
+			if !isPanic {
+				panic(r)
+			}
+			l.Add(FromPanic(cp))
+		}
+	}()
+	f()
+	return true
+}
+
+// HasErrors reports list has any error.
+func (l List) HasErrors() bool {
+	for _, d := range l {
+		if d.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
+
+// Sort diagnostics by file and position.
+func (l List) Sort() {
+	sort.SliceStable(l, func(i, j int) bool {
+		a, b := l[i].Span, l[j].Span
+		if a.File != b.File {
+			return a.File < b.File
+		} else if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+}
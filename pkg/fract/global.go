@@ -1,10 +1,11 @@
 package fract
 
 const (
-	Version     = "0.0.1"
-	Extension   = ".fract"
-	StdLib      = "stdlib" // Standard library path.
-	FloatFormat = "%g"
+	Version           = "0.0.1"
+	Extension         = ".fract"
+	BytecodeExtension = ".fbc"   // Extension of compiled files.
+	StdLib            = "stdlib" // Standard library path.
+	FloatFormat       = "%g"
 
 	NA                  uint8 = 0
 	Ignore              uint8 = 1
@@ -37,14 +38,14 @@ const (
 	Struct              uint8 = 36
 	Class               uint8 = 37
 	None                uint8 = 38
+	Match               uint8 = 39
+	Case                uint8 = 40
+	Select              uint8 = 41
+	Finally             uint8 = 42
+	Yield               uint8 = 43
+	Interpolation       uint8 = 44
 
 	LOOPBreak    uint8 = 1
 	LOOPContinue uint8 = 2
 	FUNCReturn   uint8 = 3
 )
-
-var (
-	TryCount         int // Try-Catch count.
-	ExecutablePath   string
-	InteractiveShell bool // Interactive shell mode.
-)
@@ -1,7 +1,6 @@
 package fract
 
 import (
-	"os"
 	"fmt"
 	"strings"
 
@@ -14,12 +13,13 @@ func PanicC(f *obj.File, col, ln int, t, m string) {
 		Msg: fmt.Sprintf("File: %s\nPosition: %d:%d\n    %s\n%s^\n%s: %s",
 			f.Path, ln, col, strings.ReplaceAll(f.Lines[ln-1], "\t", " "),
 			str.Full(4+col-2, ' '), t, m),
-		Type: t,
+		Text:   m,
+		Type:   t,
+		File:   f.Path,
+		Line:   ln,
+		Column: col,
 	}
-	if TryCount > 0 {
-		panic(e)
-	}
-	e.Panic(!InteractiveShell)
+	panic(e)
 }
 
 func Panic(tk obj.Token, t, m string) { PanicC(tk.File, tk.Column, tk.Line, t, m) }
@@ -30,9 +30,14 @@ func IPanicC(f *obj.File, ln, col int, t, m string) {
 		Msg: fmt.Sprintf("File: %s\nPosition: %d:%d\n    %s\n%s^\n%s: %s",
 			f.Path, ln, col, strings.ReplaceAll(f.Lines[ln-1], "\t", " "),
 			str.Full(4+col-2, ' '), t, m),
-		Type: t,
+		Text:   m,
+		Type:   t,
+		File:   f.Path,
+		Line:   ln,
+		Column: col,
+		Fatal:  true,
 	}
-	e.Panic(!InteractiveShell)
+	panic(e)
 }
 
 // Interpreter panic.
@@ -40,6 +45,12 @@ func IPanic(tk obj.Token, t, m string) { IPanicC(tk.File, tk.Line, tk.Column, t,
 
 // Error is text interpreter panic.
 func Error(f *obj.File, ln, col int, m string) {
-	fmt.Printf("File: %s\nPosition: %d:%d\n%s\n", f.Path, ln, col, m)
-	os.Exit(1)
+	panic(obj.Panic{
+		Msg:    fmt.Sprintf("File: %s\nPosition: %d:%d\n%s", f.Path, ln, col, m),
+		Text:   m,
+		File:   f.Path,
+		Line:   ln,
+		Column: col,
+		Fatal:  true,
+	})
 }
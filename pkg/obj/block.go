@@ -9,7 +9,11 @@ type Block struct {
 
 func (b *Block) catch() {
 	if r := recover(); r != nil {
-		b.Panic = r.(Panic)
+		p, ok := r.(Panic)
+		if !ok { // Exit or runtime error.
+			panic(r)
+		}
+		b.Panic = p
 		if b.Catch != nil {
 			b.Catch(b.Panic)
 		}
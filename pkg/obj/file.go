@@ -7,4 +7,6 @@ type File struct {
 	Path  string
 	File  *os.File
 	Lines []string
+	// File of builtin defines of standard library, lowercase names are public.
+	Builtin bool
 }
@@ -2,7 +2,7 @@ package obj
 
 import (
 	"fmt"
-	"os"
+	"strings"
 )
 
 const (
@@ -14,19 +14,61 @@ const (
 	OutOfRangePanic   = "OutOfRangePanic"
 	ArithmeticPanic   = "ArithmeticPanic"
 	DivideByZeroPanic = "DivideByZeroPanic"
+	MatchPanic        = "MatchPanic"
 )
 
+// Frame is function call of stack trace.
+type Frame struct {
+	Func   string // Name of function.
+	File   string // Path of file.
+	Line   int
+	Column int
+}
+
+func (f Frame) String() string { return fmt.Sprintf("%s (%s:%d:%d)", f.Func, f.File, f.Line, f.Column) }
+
 type Panic struct {
-	Msg  string
-	Type string
+	Msg    string
+	Text   string // Message without position.
+	Type   string
+	File   string // Path of file.
+	Line   int
+	Column int
+	Fatal  bool    // Interpreter panic, is cannot catch by try blocks.
+	Trace  []Frame // Stack trace, innermost call is first.
 }
 
 func (p Panic) String() string { return p.Msg }
 
-func (p Panic) Panic(exit bool) {
-	if exit {
-		fmt.Println(p)
-		os.Exit(1)
+// StackTrace returns text of stack trace.
+// Returns empty string if panic is not raised in any function.
+func (p Panic) StackTrace() string {
+	if len(p.Trace) < 2 {
+		return ""
 	}
-	panic(p)
+	var sb strings.Builder
+	sb.WriteString("Stack trace:")
+	for _, f := range p.Trace {
+		sb.WriteString("\n    at " + f.String())
+	}
+	return sb.String()
 }
+
+// Error returns message of panic with stack trace.
+func (p Panic) Error() string {
+	msg := p.Msg
+	if p.Type == PlainPanic {
+		msg = "panic: " + msg
+	}
+	if trace := p.StackTrace(); trace != "" {
+		msg += "\n" + trace
+	}
+	return msg
+}
+
+// ExitError is thrown by exit function with exit code.
+type ExitError struct {
+	Code int
+}
+
+func (e ExitError) Error() string { return fmt.Sprintf("exit status %d", e.Code) }
@@ -0,0 +1,409 @@
+// Package readline reads lines of terminal with editing, history and completion.
+//
+// Lines are read without editing if input is not a terminal.
+package readline
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ErrInterrupt is returned by ReadLine if line is interrupted by Ctrl-C.
+var ErrInterrupt = errors.New("interrupted")
+
+// MaxHistory is maximum count of lines in history.
+const MaxHistory = 1000
+
+// Width of terminal for listing of candidates.
+const width = 80
+
+// Completer returns candidates for word before cursor.
+// head is text before cursor and start is offset of word in head.
+// Candidates are complete words that replace the word.
+type Completer func(head string) (start int, candidates []string)
+
+// Reader of lines.
+type Reader struct {
+	Complete Completer // Tab completion, tabs are inserted if nil.
+
+	in       *bufio.Reader
+	out      io.Writer
+	fd       int
+	terminal bool
+	history  []string
+	histFile string // Lines of history are appended to file if not empty.
+}
+
+// New returns reader that reads from in and echoes to out.
+// fd is file descriptor of in and lines are edited only if it is a terminal.
+func New(in *bufio.Reader, fd int, out io.Writer) *Reader {
+	_, err := getState(fd)
+	return &Reader{in: in, out: out, fd: fd, terminal: err == nil}
+}
+
+// Terminal returns true if lines are edited, returns false if not.
+func (r *Reader) Terminal() bool { return r.terminal }
+
+// ReadLine prints prompt and reads line.
+// Returns io.EOF if input is ended and ErrInterrupt if line is interrupted.
+func (r *Reader) ReadLine(prompt string) (string, error) {
+	if r.terminal {
+		if old, err := makeRaw(r.fd); err == nil {
+			defer setState(r.fd, old)
+			return r.edit(prompt)
+		}
+	}
+	io.WriteString(r.out, prompt)
+	ln, err := r.in.ReadString('\n')
+	if err != nil && ln == "" {
+		return "", err
+	}
+	return strings.TrimRight(ln, "\r\n"), nil
+}
+
+// History returns lines of history, oldest is first.
+func (r *Reader) History() []string { return append([]string(nil), r.history...) }
+
+// AddHistory adds line to history.
+// Blank lines and repeats of last line are not added.
+func (r *Reader) AddHistory(line string) {
+	if strings.TrimSpace(line) == "" || len(r.history) > 0 && r.history[len(r.history)-1] == line {
+		return
+	}
+	r.history = append(r.history, line)
+	if len(r.history) > MaxHistory {
+		r.history = append([]string(nil), r.history[len(r.history)-MaxHistory:]...)
+	}
+	if r.histFile == "" {
+		return
+	}
+	if f, err := os.OpenFile(r.histFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600); err == nil {
+		f.WriteString(line + "\n")
+		f.Close()
+	}
+}
+
+// LoadHistory loads history from file and next lines of history are appended to file.
+// File is not required to exist.
+func (r *Reader) LoadHistory(path string) error {
+	r.histFile = path
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	r.history = nil
+	for _, line := range strings.Split(string(bytes), "\n") {
+		if line = strings.TrimRight(line, "\r"); line != "" {
+			r.history = append(r.history, line)
+		}
+	}
+	// File is truncated to keep it small.
+	if len(r.history) > MaxHistory {
+		r.history = r.history[len(r.history)-MaxHistory:]
+		return os.WriteFile(path, []byte(strings.Join(r.history, "\n")+"\n"), 0600)
+	}
+	return nil
+}
+
+func ctrl(c rune) rune { return c & 0x1f }
+
+// editor of line.
+type editor struct {
+	r      *Reader
+	prompt string
+	line   []rune
+	pos    int    // Cursor.
+	hist   int    // Index of history, length of history if line is not from history.
+	saved  []rune // Edited line while browsing history.
+}
+
+// edit reads line with editing, terminal is must be in raw mode.
+func (r *Reader) edit(prompt string) (string, error) {
+	e := &editor{r: r, prompt: prompt, hist: len(r.history)}
+	e.refresh()
+	for {
+		c, _, err := r.in.ReadRune()
+		if err != nil {
+			if len(e.line) == 0 {
+				return "", err
+			}
+			e.end()
+			return string(e.line), nil
+		}
+		switch c {
+		case '\r', '\n':
+			// Pasted lines may end with CRLF.
+			if c == '\r' && r.in.Buffered() > 0 {
+				if b, _ := r.in.Peek(1); b[0] == '\n' {
+					r.in.ReadByte()
+				}
+			}
+			e.end()
+			return string(e.line), nil
+		case ctrl('C'):
+			e.move(len(e.line))
+			io.WriteString(r.out, "^C\n")
+			return "", ErrInterrupt
+		case ctrl('D'):
+			if len(e.line) == 0 {
+				io.WriteString(r.out, "\n")
+				return "", io.EOF
+			}
+			e.delete()
+		case ctrl('A'):
+			e.move(0)
+		case ctrl('E'):
+			e.move(len(e.line))
+		case ctrl('B'):
+			e.move(e.pos - 1)
+		case ctrl('F'):
+			e.move(e.pos + 1)
+		case ctrl('H'), 127:
+			if e.pos > 0 {
+				e.pos--
+				e.delete()
+			}
+		case ctrl('K'):
+			e.line = e.line[:e.pos]
+			e.refresh()
+		case ctrl('U'):
+			e.line = append([]rune(nil), e.line[e.pos:]...)
+			e.pos = 0
+			e.refresh()
+		case ctrl('W'):
+			start := e.wordLeft()
+			e.line = append(e.line[:start], e.line[e.pos:]...)
+			e.pos = start
+			e.refresh()
+		case ctrl('L'):
+			io.WriteString(r.out, "\x1b[H\x1b[2J")
+			e.refresh()
+		case ctrl('P'):
+			e.browse(e.hist - 1)
+		case ctrl('N'):
+			e.browse(e.hist + 1)
+		case '\t':
+			e.complete()
+		case 27:
+			e.escape()
+		default:
+			if unicode.IsPrint(c) {
+				e.insert([]rune{c})
+			}
+		}
+	}
+}
+
+// escape processes escape sequence of key.
+func (e *editor) escape() {
+	c, _, err := e.r.in.ReadRune()
+	if err != nil {
+		return
+	}
+	switch c {
+	case 'b': // Alt-B.
+		e.move(e.wordLeft())
+	case 'f': // Alt-F.
+		e.move(e.wordRight())
+	case '[', 'O':
+		var params strings.Builder
+		for {
+			if c, _, err = e.r.in.ReadRune(); err != nil {
+				return
+			} else if c >= '0' && c <= '9' || c == ';' {
+				params.WriteRune(c)
+				continue
+			}
+			break
+		}
+		// Arrows with Ctrl or Alt are move by words.
+		word := strings.HasSuffix(params.String(), ";5") || strings.HasSuffix(params.String(), ";3")
+		switch c {
+		case 'A':
+			e.browse(e.hist - 1)
+		case 'B':
+			e.browse(e.hist + 1)
+		case 'C':
+			if word {
+				e.move(e.wordRight())
+			} else {
+				e.move(e.pos + 1)
+			}
+		case 'D':
+			if word {
+				e.move(e.wordLeft())
+			} else {
+				e.move(e.pos - 1)
+			}
+		case 'H':
+			e.move(0)
+		case 'F':
+			e.move(len(e.line))
+		case '~':
+			switch params.String() {
+			case "1", "7": // Home.
+				e.move(0)
+			case "4", "8": // End.
+				e.move(len(e.line))
+			case "3": // Delete.
+				e.delete()
+			}
+		}
+	}
+}
+
+// refresh redraws line and cursor.
+func (e *editor) refresh() {
+	var sb strings.Builder
+	sb.WriteString("\r" + e.prompt + string(e.line) + "\x1b[K")
+	if n := len(e.line) - e.pos; n > 0 {
+		fmt.Fprintf(&sb, "\x1b[%dD", n)
+	}
+	io.WriteString(e.r.out, sb.String())
+}
+
+// end moves cursor to end of line and finishes line.
+func (e *editor) end() {
+	e.move(len(e.line))
+	io.WriteString(e.r.out, "\n")
+}
+
+func (e *editor) move(pos int) {
+	if pos < 0 || pos > len(e.line) {
+		return
+	}
+	e.pos = pos
+	e.refresh()
+}
+
+func (e *editor) insert(rs []rune) {
+	line := append([]rune(nil), e.line[:e.pos]...)
+	line = append(line, rs...)
+	e.line = append(line, e.line[e.pos:]...)
+	e.pos += len(rs)
+	e.refresh()
+}
+
+// delete removes rune at cursor.
+func (e *editor) delete() {
+	if e.pos < len(e.line) {
+		e.line = append(e.line[:e.pos], e.line[e.pos+1:]...)
+	}
+	e.refresh()
+}
+
+func isWord(c rune) bool { return c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c) }
+
+// wordLeft returns start of word before cursor.
+func (e *editor) wordLeft() int {
+	i := e.pos
+	for i > 0 && !isWord(e.line[i-1]) {
+		i--
+	}
+	for i > 0 && isWord(e.line[i-1]) {
+		i--
+	}
+	return i
+}
+
+// wordRight returns end of word after cursor.
+func (e *editor) wordRight() int {
+	i := e.pos
+	for i < len(e.line) && !isWord(e.line[i]) {
+		i++
+	}
+	for i < len(e.line) && isWord(e.line[i]) {
+		i++
+	}
+	return i
+}
+
+// browse replaces line with line of history at index.
+// Index of length of history is the edited line.
+func (e *editor) browse(i int) {
+	if i < 0 || i > len(e.r.history) || i == e.hist {
+		return
+	}
+	if e.hist == len(e.r.history) {
+		e.saved = e.line
+	}
+	e.hist = i
+	if i == len(e.r.history) {
+		e.line = e.saved
+	} else {
+		e.line = []rune(e.r.history[i])
+	}
+	e.pos = len(e.line)
+	e.refresh()
+}
+
+// complete word before cursor.
+// Word is extended by common prefix of candidates,
+// candidates are listed if word is not extended.
+func (e *editor) complete() {
+	if e.r.Complete == nil {
+		e.insert([]rune{'\t'})
+		return
+	}
+	head := string(e.line[:e.pos])
+	start, candidates := e.r.Complete(head)
+	if len(candidates) == 0 || start < 0 || start > len(head) {
+		io.WriteString(e.r.out, "\a")
+		return
+	}
+	word := head[start:]
+	prefix := candidates[0]
+	for _, c := range candidates[1:] {
+		for !strings.HasPrefix(c, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	if len(prefix) > len(word) && strings.HasPrefix(prefix, word) {
+		repl := []rune(head[:start] + prefix)
+		e.line = append(repl, e.line[e.pos:]...)
+		e.pos = len(repl)
+		e.refresh()
+		return
+	} else if len(candidates) == 1 {
+		return
+	}
+	e.list(candidates)
+	e.refresh()
+}
+
+// list prints candidates by columns.
+func (e *editor) list(candidates []string) {
+	candidates = append([]string(nil), candidates...)
+	sort.Strings(candidates)
+	colWidth := 0
+	for _, c := range candidates {
+		if len(c) > colWidth {
+			colWidth = len(c)
+		}
+	}
+	colWidth += 2
+	cols := width / colWidth
+	if cols == 0 {
+		cols = 1
+	}
+	var sb strings.Builder
+	sb.WriteString("\n")
+	for i, c := range candidates {
+		sb.WriteString(c)
+		if (i+1)%cols == 0 || i == len(candidates)-1 {
+			sb.WriteString("\n")
+		} else {
+			sb.WriteString(strings.Repeat(" ", colWidth-len(c)))
+		}
+	}
+	e.move(len(e.line))
+	io.WriteString(e.r.out, sb.String())
+}
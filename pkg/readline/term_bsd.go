@@ -0,0 +1,11 @@
+//go:build darwin || freebsd || netbsd || openbsd || dragonfly
+// +build darwin freebsd netbsd openbsd dragonfly
+
+package readline
+
+import "syscall"
+
+const (
+	ioctlGet = syscall.TIOCGETA
+	ioctlSet = syscall.TIOCSETA
+)
@@ -0,0 +1,16 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd && !dragonfly
+// +build !linux,!darwin,!freebsd,!netbsd,!openbsd,!dragonfly
+
+package readline
+
+import "errors"
+
+// Line editing is not supported, lines are read without editing.
+
+type state struct{}
+
+func getState(fd int) (*state, error) { return nil, errors.New("terminal is not supported") }
+
+func setState(fd int, s *state) error { return errors.New("terminal is not supported") }
+
+func makeRaw(fd int) (*state, error) { return nil, errors.New("terminal is not supported") }
@@ -0,0 +1,51 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+// +build linux darwin freebsd netbsd openbsd dragonfly
+
+package readline
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// state of terminal.
+type state struct {
+	termios syscall.Termios
+}
+
+func getState(fd int) (*state, error) {
+	var s state
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), ioctlGet, uintptr(unsafe.Pointer(&s.termios)))
+	if errno != 0 {
+		return nil, errno
+	}
+	return &s, nil
+}
+
+func setState(fd int, s *state) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), ioctlSet, uintptr(unsafe.Pointer(&s.termios)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// makeRaw puts terminal into raw mode and returns previous state.
+// Output processing is kept for new lines of output.
+func makeRaw(fd int) (*state, error) {
+	old, err := getState(fd)
+	if err != nil {
+		return nil, err
+	}
+	raw := *old
+	raw.termios.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.termios.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.termios.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.termios.Cflag |= syscall.CS8
+	raw.termios.Cc[syscall.VMIN] = 1
+	raw.termios.Cc[syscall.VTIME] = 0
+	if err := setState(fd, &raw); err != nil {
+		return nil, err
+	}
+	return old, nil
+}
@@ -0,0 +1,201 @@
+// Package tester runs tests that written in Fract.
+//
+// Tests are functions of files that named with "_test.fract" suffix,
+// names of test functions are starts with "Test" like "TestAdd".
+// Each test is run in new interpreter and fails if panics.
+package tester
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	interp "github.com/fract-lang/fract"
+	"github.com/fract-lang/fract/ast"
+	"github.com/fract-lang/fract/parser"
+	"github.com/fract-lang/fract/pkg/fract"
+	"github.com/fract-lang/fract/pkg/obj"
+)
+
+// Suffix of test files.
+const Suffix = "_test" + fract.Extension
+
+// Panic types of testing package of standard library.
+const (
+	AssertionPanic = "AssertionPanic"
+	SkipPanic      = "SkipPanic"
+)
+
+// Status of test.
+type Status uint8
+
+const (
+	Pass Status = iota
+	Fail
+	Skip
+)
+
+func (s Status) String() string {
+	switch s {
+	case Fail:
+		return "FAIL"
+	case Skip:
+		return "SKIP"
+	}
+	return "PASS"
+}
+
+// Result of test.
+type Result struct {
+	File   string
+	Name   string // Name of test function, empty if file is not run.
+	Status Status
+	Msg    string // Message of panic.
+	Pos    string // Position of panic in test file like "x_test.fract:3:5".
+}
+
+// Runner of tests.
+type Runner struct {
+	StdLib string         // Path of standard library.
+	Run    *regexp.Regexp // Filter of test names, all tests are run if nil.
+	Stdout io.Writer      // Output of tests, defaults to os.Stdout.
+}
+
+// Files returns test files of pattern.
+// Directories are walked recursively and files are used as is,
+// other patterns are glob patterns of test files.
+// Returns error if pattern is not matches any file or directory.
+func Files(pattern string) ([]string, error) {
+	if info, err := os.Stat(pattern); err == nil {
+		if !info.IsDir() {
+			if !strings.HasSuffix(pattern, fract.Extension) {
+				return nil, fmt.Errorf("%s is not a Fract file", pattern)
+			}
+			return []string{pattern}, nil
+		}
+		var files []string
+		err := filepath.Walk(pattern, func(fp string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() && strings.HasSuffix(fp, Suffix) {
+				files = append(files, fp)
+			}
+			return nil
+		})
+		return files, err
+	}
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	} else if len(matches) == 0 {
+		return nil, fmt.Errorf("%s is not matches any file", pattern)
+	}
+	var files []string
+	for _, fp := range matches {
+		if info, err := os.Stat(fp); err == nil && !info.IsDir() && strings.HasSuffix(fp, Suffix) {
+			files = append(files, fp)
+		}
+	}
+	return files, nil
+}
+
+// isTest returns true if name is name of test function, returns false if not.
+// Name is must be "Test" or starts with "Test" that followed by not lower case letter.
+func isTest(name string) bool {
+	if !strings.HasPrefix(name, "Test") {
+		return false
+	} else if len(name) == 4 {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(name[4:])
+	return !unicode.IsLower(r)
+}
+
+// Names returns names of test functions in order of declare.
+// Returns error if file is not exist or has syntax error.
+func (r *Runner) Names(path string) ([]string, error) {
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p := parser.NewSource(parser.NewRuntime(r.StdLib), path, string(code))
+	if diags := p.Check(); len(diags) > 0 {
+		return nil, errors.New(diags[0].String())
+	}
+	var names []string
+	for _, stmt := range p.Tree().Stmts {
+		if f, ok := stmt.(*ast.FuncDecl); ok && isTest(f.Name.Val) {
+			names = append(names, f.Name.Val)
+		}
+	}
+	return names, nil
+}
+
+// File runs tests of file and returns results in order of declare.
+// Returns single failed result without name if file is not parsed.
+func (r *Runner) File(path string) []Result {
+	names, err := r.Names(path)
+	if err != nil {
+		return []Result{{File: path, Status: Fail, Msg: err.Error()}}
+	}
+	var results []Result
+	for _, name := range names {
+		if r.Run == nil || r.Run.MatchString(name) {
+			results = append(results, r.test(path, name))
+		}
+	}
+	return results
+}
+
+// test runs test function in new interpreter.
+// Top-level statements of file are run before test.
+func (r *Runner) test(path, name string) Result {
+	result := Result{File: path, Name: name}
+	i := interp.New(interp.Options{StdLib: r.StdLib, Stdout: r.Stdout})
+	err := i.RunFile(path)
+	if err == nil {
+		_, err = i.Call(name)
+	}
+	var (
+		p    interp.Panic
+		exit interp.ExitError
+	)
+	switch {
+	case err == nil:
+		return result
+	case errors.As(err, &p):
+		result.Status, result.Msg, result.Pos = Fail, p.Type+": "+p.Text, position(path, p)
+		switch p.Type {
+		case SkipPanic:
+			result.Status, result.Msg, result.Pos = Skip, p.Text, ""
+		case AssertionPanic:
+			result.Msg = p.Text
+		case obj.PlainPanic:
+			result.Msg = "panic: " + p.Text
+		}
+	case errors.As(err, &exit):
+		result.Status, result.Msg = Fail, fmt.Sprintf("exited with code %d", exit.Code)
+	default:
+		result.Status, result.Msg = Fail, err.Error()
+	}
+	return result
+}
+
+// position returns position of panic in test file.
+// Innermost call in test file is used if panic is raised in another file.
+func position(path string, p interp.Panic) string {
+	path = filepath.Clean(path)
+	for _, f := range p.Trace {
+		if filepath.Clean(f.File) == path {
+			return fmt.Sprintf("%s:%d:%d", f.File, f.Line, f.Column)
+		}
+	}
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
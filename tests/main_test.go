@@ -1,15 +1,1043 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/fract-lang/fract"
+	"github.com/fract-lang/fract/bytecode"
+	"github.com/fract-lang/fract/dap"
+	"github.com/fract-lang/fract/oop"
 	"github.com/fract-lang/fract/parser"
+	"github.com/fract-lang/fract/tester"
 )
 
 func BenchmarkInterpret(b *testing.B) {
-	p := parser.New("../test.fract")
+	p := parser.New(parser.NewRuntime("../stdlib"), "../test.fract")
 	p.AddBuiltInFuncs()
 	for i := 0; i < b.N; i++ {
 		p.Interpret()
 	}
 }
+
+// runBoth runs code by interpreter and by compiled bytecode,
+// outputs of both are must be want. Uncaught panic is added to output
+// as "line:column: type: message".
+func runBoth(t *testing.T, code, want string) {
+	t.Helper()
+	runBothWith(t, nil, code, want)
+}
+
+// runBothWith is runBoth with setup of interpreters, like defines of host.
+func runBothWith(t *testing.T, setup func(*fract.Interpreter) error, code, want string) {
+	t.Helper()
+	newInterp := func(stdout io.Writer) *fract.Interpreter {
+		i := fract.New(fract.Options{StdLib: "../stdlib", Stdout: stdout})
+		if setup != nil {
+			if err := setup(i); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return i
+	}
+	for _, path := range writeBoth(t, newInterp(nil), code) {
+		var stdout bytes.Buffer
+		err := newInterp(&stdout).RunFile(path)
+		var cp fract.Panic
+		if errors.As(err, &cp) {
+			fmt.Fprintf(&stdout, "%d:%d: %s: %s\n", cp.Line, cp.Column, cp.Type, cp.Text)
+		} else if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		if stdout.String() != want {
+			t.Errorf("%s: got %q, want %q", path, stdout.String(), want)
+		}
+	}
+}
+
+// writeBoth writes code to source file and compiles it by interp
+// to bytecode file. Returns paths of source and bytecode files.
+func writeBoth(t *testing.T, interp *fract.Interpreter, code string) []string {
+	t.Helper()
+	src := filepath.Join(t.TempDir(), "main.fract")
+	if err := os.WriteFile(src, []byte(code), 0644); err != nil {
+		t.Fatal(err)
+	}
+	prog, err := interp.Compile(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := bytecode.Encode(&buf, prog); err != nil {
+		t.Fatal(err)
+	}
+	compiled := filepath.Join(t.TempDir(), "main.fbc")
+	if err := os.WriteFile(compiled, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return []string{src, compiled}
+}
+
+// TestConcurrentStress runs goroutines of stress script by interpreter and
+// by compiled bytecode, must be passed with race detector.
+func TestConcurrentStress(t *testing.T) {
+	const want = "9000\n500\n5000\n"
+	run := func(path string) {
+		var stdout, stderr bytes.Buffer
+		i := fract.New(fract.Options{StdLib: "../stdlib", Stdout: &stdout, Stderr: &stderr})
+		if err := i.RunFile(path); err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		if stdout.String() != want || stderr.Len() > 0 {
+			t.Errorf("%s: got %q, stderr %q, want %q", path, stdout.String(), stderr.String(), want)
+		}
+	}
+	run("stress.fract")
+	prog, err := fract.New(fract.Options{StdLib: "../stdlib"}).Compile("stress.fract")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "stress.fbc")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bytecode.Encode(f, prog); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	run(path)
+}
+
+// TestTesting runs tests of testing package of standard library.
+// Last test must fail at position of assertion.
+func TestTesting(t *testing.T) {
+	r := &tester.Runner{StdLib: "../stdlib"}
+	results := r.File("testing_test.fract")
+	want := []tester.Status{tester.Pass, tester.Pass, tester.Pass, tester.Pass, tester.Skip, tester.Fail}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d: %v", len(results), len(want), results)
+	}
+	for i, result := range results {
+		if result.Status != want[i] {
+			t.Errorf("%s: got %s, want %s: %s", result.Name, result.Status, want[i], result.Msg)
+		}
+	}
+	last := results[len(results)-1]
+	if last.Pos != "testing_test.fract:40:18" || last.Msg != "got 'a', want 'b'" {
+		t.Errorf("got failure %s: %s", last.Pos, last.Msg)
+	}
+}
+
+// TestDebug stops at breakpoint in function and inspects variables.
+func TestDebug(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "x.fract")
+	code := "package main\n\nfunc add(a, b) {\n    sum := a + b\n    return sum\n}\n\nprintln(add(1, 2))\n"
+	if err := os.WriteFile(path, []byte(code), 0644); err != nil {
+		t.Fatal(err)
+	}
+	inr, inw := io.Pipe()
+	outr, outw := io.Pipe()
+	s := dap.NewServer(inr, outw)
+	s.StdLib = "../stdlib"
+	go s.Serve()
+	out := bufio.NewReader(outr)
+	// Requests are written by another goroutine because pipes are not buffered.
+	requests := make(chan []byte, 16)
+	defer close(requests)
+	go func() {
+		for data := range requests {
+			fmt.Fprintf(inw, "Content-Length: %d\r\n\r\n%s", len(data), data)
+		}
+	}()
+	seq := 0
+	send := func(command string, args interface{}) {
+		seq++
+		data, _ := json.Marshal(map[string]interface{}{"seq": seq, "type": "request", "command": command, "arguments": args})
+		requests <- data
+	}
+	type message struct {
+		Type    string
+		Event   string
+		Command string
+		Success bool
+		Body    json.RawMessage
+	}
+	// wait returns body of message that matches event or command.
+	wait := func(name string) json.RawMessage {
+		for {
+			var length int
+			if _, err := fmt.Fscanf(out, "Content-Length: %d\r\n\r\n", &length); err != nil {
+				t.Fatal(err)
+			}
+			data := make([]byte, length)
+			io.ReadFull(out, data)
+			var msg message
+			json.Unmarshal(data, &msg)
+			if msg.Type == "response" && !msg.Success {
+				t.Fatalf("%s: %s", msg.Command, data)
+			} else if msg.Event == name || msg.Command == name {
+				return msg.Body
+			}
+		}
+	}
+	send("initialize", nil)
+	wait("initialized")
+	send("launch", map[string]interface{}{"program": path})
+	send("setBreakpoints", map[string]interface{}{"source": map[string]string{"path": path}, "breakpoints": []map[string]int{{"line": 5}}})
+	send("configurationDone", nil)
+	wait("stopped")
+	send("stackTrace", map[string]int{"threadId": 1})
+	var trace struct {
+		StackFrames []struct {
+			Name string
+			Line int
+		}
+	}
+	json.Unmarshal(wait("stackTrace"), &trace)
+	if len(trace.StackFrames) != 2 || trace.StackFrames[0].Name != "add" || trace.StackFrames[0].Line != 5 {
+		t.Fatalf("invalid stack trace: %+v", trace.StackFrames)
+	}
+	send("scopes", map[string]int{"frameId": 0})
+	var scopes struct {
+		Scopes []struct{ VariablesReference int }
+	}
+	json.Unmarshal(wait("scopes"), &scopes)
+	send("variables", map[string]int{"variablesReference": scopes.Scopes[0].VariablesReference})
+	var vars struct {
+		Variables []struct{ Name, Value string }
+	}
+	json.Unmarshal(wait("variables"), &vars)
+	if got := fmt.Sprint(vars.Variables); got != "[{a 1} {b 2} {sum 3}]" {
+		t.Errorf("invalid locals: %s", got)
+	}
+	send("continue", nil)
+	if body := wait("output"); !bytes.Contains(body, []byte(`"3\n"`)) {
+		t.Errorf("invalid output: %s", body)
+	}
+	wait("terminated")
+	send("disconnect", nil)
+	wait("disconnect")
+}
+
+// TestSession evaluates code in session of interactive shell, loads file
+// into session and lists defines of session.
+func TestSession(t *testing.T) {
+	p := parser.NewStdin(parser.NewRuntime("../stdlib"))
+	p.AddBuiltInFuncs()
+	if err := p.Eval("var a = [1, 2]\nfunc inc(x) { return x + 1 }"); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), "load.fract")
+	if err := os.WriteFile(file, []byte("package load\n\nvar b = inc(len(a))\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Load(file); err != nil {
+		t.Fatal(err)
+	}
+	defs := p.SessionDefs()
+	if len(defs.Funcs) != 1 || defs.Funcs[0].Signature() != "func inc(x)" {
+		t.Errorf("got functions %v", defs.Funcs)
+	}
+	if len(defs.Vars) != 2 || defs.Vars[0].Name != "a" || defs.Vars[1].Name != "b" {
+		t.Errorf("got variables %v", defs.Vars)
+	}
+	val, err := p.Value("b * 2")
+	if err != nil || val.String() != "6" {
+		t.Errorf("got %v, %v, want 6", val, err)
+	}
+	if _, err := p.Value("var c = 1"); err == nil {
+		t.Error("got no error for statement")
+	}
+}
+
+// TestClosures runs closures by interpreter and by compiled bytecode.
+func TestClosures(t *testing.T) {
+	const code = `package main
+
+func counter() {
+    count := 0
+    return func() {
+        count += 1
+        return count
+    }
+}
+
+func fact() {
+    func f(n) {
+        if n <= 1 { return 1 }
+        return n * f(n - 1)
+    }
+    return f
+}
+
+func parity() {
+    func even(n) {
+        if n == 0 { return true }
+        return odd(n - 1)
+    }
+    func odd(n) {
+        if n == 0 { return false }
+        return even(n - 1)
+    }
+    return even(4)
+}
+
+var fs = []
+for _, i in [1, 2, 3] {
+    k := i * 10
+    fs.pushBack(func(x) { return x + k + i })
+}
+next := counter()
+next()
+println(next(), counter()(), fact()(5))
+println(fs[0](1), fs[2](1))
+println(parity())
+`
+	const want = "21120\n1234\ntrue\n"
+	runBoth(t, code, want)
+}
+
+// TestGenerators runs generators by interpreter and by compiled bytecode.
+func TestGenerators(t *testing.T) {
+	const code = `package main
+
+func count(n) {
+    defer print("d")
+    i := 0
+    for i < n {
+        yield i
+        i += 1
+    }
+}
+
+func bad() {
+    yield 1
+    panic("boom")
+}
+
+for x in count(10) {
+    if x == 2 { break }
+    print(x)
+}
+println([x * 2 for x in count(3)])
+try {
+    for x in bad() { print(x) }
+} catch e {
+    println(e.message)
+}
+for i, x in count(3) {}
+`
+	const want = "01dd[0 2 4]\n1boom\n27:8: ValuePanic: Iterator loops are takes only value name!\n"
+	runBoth(t, code, want)
+}
+
+// TestIteratorProtocol iterates instances of classes by iterator protocol.
+func TestIteratorProtocol(t *testing.T) {
+	const code = `package main
+
+class Countdown {
+    var n = 0
+    func Countdown(n) { this.n = n }
+    func Next() {
+        this.n -= 1
+        return this.n >= 0
+    }
+    func Value() { return this.n }
+    func Close() { print("c") }
+}
+
+class Bag {
+    var items = [1, 2, 3]
+    func Iter() {
+        for _, item in this.items { yield item }
+    }
+    func Contains(x) { return x == 42 }
+}
+
+for x in Countdown(5) {
+    if x == 2 { break }
+    print(x)
+}
+b := Bag()
+println([x * 2 for x in b], len(b), len(Countdown(4)), 42 in b, 3 in Countdown(5), 3 in Countdown(2))
+for i, x in b {}
+`
+	const want = "43cc[2 4 6]34truetruefalse\n28:8: ValuePanic: Iterator loops are takes only value name!\n"
+	runBoth(t, code, want)
+}
+
+// TestStringInterpolation formats interpolated strings by interpreter and
+// by compiled bytecode.
+func TestStringInterpolation(t *testing.T) {
+	const code = `package main
+
+name := 'Ada'
+items := [1, 2, 3]
+func greet(who) {
+    n := 2
+    return "{who}:{n * 2:03d}"
+}
+println("Hello {name}, you have {len(items)} items")
+println("[{3.14159:8.2f}] [{255:x}] [{42:-4}] [{name:.2}] {{x}} {greet('b')} {items[0] + 1}")
+`
+	const want = "Hello Ada, you have 3 items\n[    3.14] [ff] [42  ] [Ad] {x} b:004 2\n"
+	runBoth(t, code, want)
+}
+
+// TestStringLiterals processes raw strings and escape sequences.
+func TestStringLiterals(t *testing.T) {
+	const code = "s := `first \\n\n  second`; println(s, len(s))\n" +
+		`println("caf\u00e9|\x41|\U0001F600", '\x7a', ` + "`{x}`)\n"
+	const want = "first \\n\n  second17\ncafé|A|😀z{x}\n"
+	var stdout bytes.Buffer
+	if err := fract.New(fract.Options{StdLib: "../stdlib", Stdout: &stdout}).RunString(code); err != nil {
+		t.Fatal(err)
+	}
+	if stdout.String() != want {
+		t.Errorf("got %q, want %q", stdout.String(), want)
+	}
+}
+
+// TestChannelLoops receives values of channel by loops, loops of channels
+// are take only value name by interpreter and by compiled bytecode.
+func TestChannelLoops(t *testing.T) {
+	const code = `package main
+
+ch := chan(2)
+ch <- 5
+ch <- 6
+close(ch)
+for x in ch { print(x, ':') }
+println()
+for i, x in ch {}
+`
+	const want = "5:6:\n9:8: ValuePanic: Channel loops are takes only value name!\n"
+	runBoth(t, code, want)
+}
+
+// TestChannels sends and receives values of channels by goroutines,
+// loops and select statements.
+func TestChannels(t *testing.T) {
+	const code = `package main
+
+open sync
+
+ch := chan(2)
+wg := sync.WaitGroup()
+func send(x) {
+    ch <- x * 10
+    wg.Done()
+}
+for _, x in [1, 2, 3] {
+    wg.Add()
+    go send(x)
+}
+sum := 0
+for _ in range(1, 3) {
+    sum += <-ch
+}
+wg.Wait()
+println(sum)
+ch <- 'a'
+close(ch)
+for x in ch {
+    println(x)
+}
+select {
+case x := <-ch { println('closed: ', x) }
+case _ { println('default') }
+}
+`
+	const want = "60\na\nclosed: none\n"
+	runBoth(t, code, want)
+}
+
+// TestErrorStruct catches panics as instances of error struct of standard
+// library and matches them by struct patterns.
+func TestErrorStruct(t *testing.T) {
+	const code = `package main
+
+try {
+    x := 1 / 0
+} catch e {
+    match e {
+        case error(m) { println(m, '|', e.type, '|', e.line, ':', e.column) }
+        case _ { println('no match') }
+    }
+}
+e := error('boom')
+println(e.message)
+match e { case error(m) { println(m) } }
+`
+	const want = "Divide by zero!|DivideByZeroPanic|4:12\nboom\nboom\n"
+	runBoth(t, code, want)
+}
+
+// TestHost registers functions, constants and dotted package of host.
+func TestHost(t *testing.T) {
+	setup := func(i *fract.Interpreter) error {
+		if err := i.Register(fract.Func{Name: "Twice", Fn: func(x int) int { return x * 2 }}); err != nil {
+			return err
+		}
+		if err := i.Const("Answer", 42); err != nil {
+			return err
+		}
+		return i.RegisterPackage(fract.Package{
+			Name: "host.m",
+			Funcs: []fract.Func{{
+				Name: "Sum",
+				Fn: func(xs ...int) (sum int) {
+					for _, x := range xs {
+						sum += x
+					}
+					return sum
+				},
+			}},
+			Consts: map[string]interface{}{"Pi": 3.5},
+		})
+	}
+	const code = `package main
+
+open host.m
+open alias host.m
+
+println(Twice(2), ' ', Answer, ' ', m.Sum(1, 2, 3), ' ', alias.Pi)
+`
+	runBothWith(t, setup, code, "4 42 6 3.5\n")
+
+	i := fract.New(fract.Options{StdLib: "../stdlib"})
+	if err := setup(i); err != nil {
+		t.Fatal(err)
+	}
+	for name, err := range map[string]error{
+		"not function":      i.Register(fract.Func{Name: "F", Fn: 1}),
+		"unsupported const": i.Const("C", make(chan int)),
+		"invalid package":   i.RegisterPackage(fract.Package{Name: "host..m"}),
+		"defined package":   i.RegisterPackage(fract.Package{Name: "host.m"}),
+	} {
+		if err == nil {
+			t.Errorf("%s: error is not returned", name)
+		}
+	}
+}
+
+// TestConversions converts Go values to Fract values and back.
+func TestConversions(t *testing.T) {
+	type point struct {
+		X, Y  int
+		Tags  []string
+		label string
+	}
+	val, err := oop.ValOf(point{X: 1, Y: 2, Tags: []string{"a"}, label: "p"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{"X": int64(1), "Y": int64(2), "Tags": []interface{}{"a"}}
+	if got := val.Interface(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Interface: got %#v, want %#v", got, want)
+	}
+	rv, err := val.GoVal(reflect.TypeOf(point{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := rv.Interface().(point); got.X != 1 || got.Y != 2 || !reflect.DeepEqual(got.Tags, []string{"a"}) {
+		t.Errorf("GoVal: got %#v", got)
+	}
+	if val, _ := oop.ValOf(uint64(math.MaxUint64)); val.Type != oop.BigInt {
+		t.Errorf("ValOf: max uint64 is not bigint: %s", oop.TypeName(val.Type))
+	}
+	if _, err := oop.ValOf(make(chan int)); err == nil {
+		t.Error("ValOf: chan is converted")
+	}
+	big, _ := oop.ValOf(300)
+	if _, err := big.GoVal(reflect.TypeOf(int8(0))); err == nil {
+		t.Error("GoVal: 300 is converted to int8")
+	}
+	if _, err := big.GoVal(reflect.TypeOf("")); err == nil {
+		t.Error("GoVal: int is converted to string")
+	}
+
+	if _, err := oop.NativeFn("f", 1, nil); err == nil {
+		t.Error("NativeFn: int is converted to function")
+	}
+	if _, err := oop.NativeFn("f", func(int) {}, []oop.Param{}); err == nil {
+		t.Error("NativeFn: count of parameters is not checked")
+	}
+	fn, err := oop.NativeFn("divmod", func(a, b int) (int, int, error) {
+		if b == 0 {
+			return 0, 0, errors.New("zero divisor")
+		}
+		return a / b, a % b, nil
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := fract.New(fract.Options{StdLib: "../stdlib"})
+	if got, err := i.CallFn(fn, 7, 2); err != nil || !reflect.DeepEqual(got, []interface{}{int64(3), int64(1)}) {
+		t.Errorf("divmod(7, 2): got %v, %v", got, err)
+	}
+	var cp fract.Panic
+	if _, err := i.CallFn(fn, 7, 0); !errors.As(err, &cp) || cp.Text != "zero divisor" {
+		t.Errorf("divmod(7, 0): got %v", err)
+	}
+	if _, err := i.CallFn(fn, "7", 2); !errors.As(err, &cp) || cp.Type != "ValuePanic" {
+		t.Errorf("divmod('7', 2): got %v", err)
+	}
+}
+
+// TestCall calls functions and reads variables of program after run.
+func TestCall(t *testing.T) {
+	const code = `package main
+
+var count = 3
+var names = ['a', 'b']
+var big = 300
+
+func swap(a, b) { return b, a }
+func check(path) {
+    if path == '' { panic('path is empty', 'PathPanic') }
+    return 'ok'
+}
+func nothing() {}
+`
+	for _, path := range writeBoth(t, fract.New(fract.Options{StdLib: "../stdlib"}), code) {
+		i := fract.New(fract.Options{StdLib: "../stdlib"})
+		if err := i.RunFile(path); err != nil {
+			t.Fatal(err)
+		}
+		for _, c := range []struct {
+			name string
+			args []interface{}
+			want []interface{}
+		}{
+			{"swap", []interface{}{1, "a"}, []interface{}{"a", int64(1)}},
+			{"check", []interface{}{"x"}, []interface{}{"ok"}},
+			{"nothing", nil, []interface{}{}},
+		} {
+			if got, err := i.Call(c.name, c.args...); err != nil || !reflect.DeepEqual(got, c.want) {
+				t.Errorf("%s: %s: got %#v, %v, want %#v", path, c.name, got, err, c.want)
+			}
+		}
+		_, err := i.Call("check", "")
+		var cp fract.Panic
+		if !errors.As(err, &cp) || cp.Type != "PathPanic" || cp.Text != "path is empty" || cp.Line != 9 {
+			t.Errorf("%s: check(''): got %#v", path, err)
+		}
+		if _, err := i.Call("missing"); err == nil || errors.As(err, &cp) {
+			t.Errorf("%s: missing function: got %v", path, err)
+		}
+		if _, err := i.Call("swap", make(chan int), 1); err == nil {
+			t.Errorf("%s: unsupported argument is converted", path)
+		}
+
+		if _, err := i.Lookup("missing"); err == nil {
+			t.Errorf("%s: missing name is found", path)
+		}
+		if v, err := i.Lookup("count"); err != nil || v != int64(3) {
+			t.Errorf("%s: count: got %#v, %v", path, v, err)
+		}
+		v, err := i.Lookup("swap")
+		fn, ok := v.(*oop.Fn)
+		if err != nil || !ok {
+			t.Fatalf("%s: swap: got %#v, %v", path, v, err)
+		}
+		if got, err := i.CallFn(fn, true, 2.5); err != nil || !reflect.DeepEqual(got, []interface{}{2.5, true}) {
+			t.Errorf("%s: CallFn: got %#v, %v", path, got, err)
+		}
+
+		var names []string
+		if err := i.Var("names", &names); err != nil || !reflect.DeepEqual(names, []string{"a", "b"}) {
+			t.Errorf("%s: names: got %#v, %v", path, names, err)
+		}
+		var small int8
+		var str string
+		for name, err := range map[string]error{
+			"missing":    i.Var("missing", &str),
+			"no pointer": i.Var("count", str),
+			"string":     i.Var("count", &str),
+			"list":       i.Var("names", &small),
+			"range":      i.Var("big", &small),
+		} {
+			if err == nil {
+				t.Errorf("%s: %s: value is stored", path, name)
+			}
+		}
+	}
+}
+
+// TestGoroutinePanic runs defers of panicked goroutines,
+// so waits of wait groups are not blocked.
+func TestGoroutinePanic(t *testing.T) {
+	const code = `package main
+
+open sync
+
+wg := sync.WaitGroup()
+
+func worker(id) {
+    defer wg.Done()
+    if id == 2 { panic('boom') }
+}
+
+for _, id in range(1, 3) {
+    wg.Add(1)
+    go worker(id)
+}
+wg.Wait()
+println('done')
+`
+	for _, path := range writeBoth(t, fract.New(fract.Options{StdLib: "../stdlib"}), code) {
+		// Panic is written to stderr after defers, so it is not waited by program.
+		var stdout bytes.Buffer
+		done := make(chan error, 1)
+		go func() {
+			done <- fract.New(fract.Options{StdLib: "../stdlib", Stdout: &stdout, Stderr: io.Discard}).RunFile(path)
+		}()
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("%s: %v", path, err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s: wait of group is blocked", path)
+		}
+		if stdout.String() != "done\n" {
+			t.Errorf("%s: got %q, want %q", path, stdout.String(), "done\n")
+		}
+	}
+}
+
+// TestCheck reports all errors of file. Statements that have error of
+// tokens are dropped, so errors are not reported again by parser.
+func TestCheck(t *testing.T) {
+	const code = `package main
+
+func f() {
+    a := 'ok
+    return 1
+}
+
+y := 'ok
+z := 1 +
+w := [1,
+  'x]
+println(f(), z, w)
+q := 1 $ 2
+`
+	src := filepath.Join(t.TempDir(), "main.fract")
+	if err := os.WriteFile(src, []byte(code), 0644); err != nil {
+		t.Fatal(err)
+	}
+	diags, err := fract.New(fract.Options{StdLib: "../stdlib"}).Check(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"4:13: error: Close quote is not found! (SyntaxPanic)",
+		"8:9: error: Close quote is not found! (SyntaxPanic)",
+		"9:8: error: Operator overflow! (SyntaxPanic)",
+		"11:6: error: Close quote is not found! (SyntaxPanic)",
+		"13:8: error: Invalid token! (SyntaxPanic)",
+	}
+	if len(diags) != len(want) {
+		t.Fatalf("got %d diagnostics, want %d: %v", len(diags), len(want), diags)
+	}
+	for i, d := range diags {
+		if d.String() != src+":"+want[i] {
+			t.Errorf("got %q, want %q", d.String(), src+":"+want[i])
+		}
+	}
+}
+
+// TestFiles returns test files of directories, files and glob patterns.
+func TestFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a_test.fract", "main.fract", filepath.Join("sub", "b_test.fract")} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("package main\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for pattern, want := range map[string][]string{
+		dir:                                  {"a_test.fract", filepath.Join("sub", "b_test.fract")},
+		filepath.Join(dir, "*.fract"):        {"a_test.fract"},
+		filepath.Join(dir, "main.fract"):     {"main.fract"},
+		filepath.Join(dir, "sub", "*_test*"): {filepath.Join("sub", "b_test.fract")},
+	} {
+		files, err := tester.Files(pattern)
+		if err != nil {
+			t.Errorf("%s: %v", pattern, err)
+			continue
+		}
+		for i := range want {
+			want[i] = filepath.Join(dir, want[i])
+		}
+		if !reflect.DeepEqual(files, want) {
+			t.Errorf("%s: got %v, want %v", pattern, files, want)
+		}
+	}
+	for _, pattern := range []string{filepath.Join(dir, "missing"), filepath.Join(dir, "x*")} {
+		if _, err := tester.Files(pattern); err == nil {
+			t.Errorf("%s: error is not returned", pattern)
+		}
+	}
+}
+
+// TestPinPattern compares values with pinned names and binds bare names.
+// Bare names of defines are not bound.
+func TestPinPattern(t *testing.T) {
+	const code = `package main
+
+limit := 10
+func classify(v) {
+    match v {
+        case ^limit      { return 'limit' }
+        case [^limit, x] { return 'pair ' + string(x) }
+        case other       { return 'other ' + string(other) }
+    }
+}
+println(classify(10), '|', classify([10, 2]), '|', classify(3))
+match 1 { case limit { } }
+`
+	const want = "limit|pair 2|other 3\n12:16: NamePanic: \"limit\" is already defined at line: 3\n"
+	runBoth(t, code, want)
+}
+
+// TestRange returns lists of range by steps, steps are must be positive.
+func TestRange(t *testing.T) {
+	const code = `package main
+
+println(range(1, 7, 2), range(3, 1), range(0.5, 1.5, 0.5))
+for _, step in [0, -1, 0.0, nan] {
+    try { range(1, 5, step) } catch ValuePanic e { print(e.message, ' ') }
+}
+`
+	const want = "[1 3 5 7][3 2 1][0.5 1 1.5]\n" + `"step" argument should be greater than zero! ` +
+		`"step" argument should be greater than zero! "step" argument should be greater than zero! ` +
+		`"step" argument should be greater than zero! `
+	runBoth(t, code, want)
+}
+
+// TestMatch matches values by literal, list, rest, struct and name patterns.
+func TestMatch(t *testing.T) {
+	const code = `package main
+
+struct point {
+    x
+    y
+}
+
+func describe(v) {
+    match v {
+        case 0, 1         { return 'small' }
+        case [a, 2]       { return 'pair ending with two: ' + string(a) }
+        case [h, t...]    { return 'head: ' + string(h) + ' tail: ' + string(t) }
+        case point(x, 0)  { return 'on x axis: ' + string(x) }
+        case point(_, _)  { return 'point' }
+        case other        { return 'other: ' + string(other) }
+    }
+}
+
+println(describe(1))
+println(describe([5, 2]))
+println(describe([5, 6, 7]))
+println(describe(point(3, 0)))
+println(describe(point(3, 4)))
+println(describe('fract'))
+`
+	const want = "small\npair ending with two: 5\nhead: 5 tail: [6 7]\non x axis: 3\npoint\nother: fract\n"
+	runBoth(t, code, want)
+}
+
+// TestIntegers computes integers by int64 values,
+// integer overflow is a panic.
+func TestIntegers(t *testing.T) {
+	const code = `package main
+
+println(9223372036854775807)
+println(7 / 2, ' ', -7 / 2, ' ', -7 % 3, ' ', 7.0 / 2)
+println(1 << 62, ' ', 2 ** 62, ' ', 6 | 9, ' ', 6 & 3, ' ', 6 ^ 3)
+println(len([1, 2, 3]), ' ', int('42') + 1, ' ', range(1, 3))
+try {
+    a := 9223372036854775807 + 1
+} catch p {
+    println('panicked: ', p.message)
+}
+try {
+    a := -9223372036854775807 - 2
+} catch p {
+    println('panicked: ', p.message)
+}
+`
+	const want = "9223372036854775807\n3 -3 -1 3.5\n4611686018427387904 4611686018427387904 15 2 5\n" +
+		"3 43 [1 2 3]\npanicked: Integer overflow!\npanicked: Integer overflow!\n"
+	runBoth(t, code, want)
+}
+
+// TestBigNumbers computes bigints and decimals,
+// mixing of floats with bigints and decimals and negative shifters are catchable panics.
+func TestBigNumbers(t *testing.T) {
+	const code = `package main
+
+a := 123456789012345678901234567890n
+println(a * a)
+println(2n ** 100, ' ', -5n / 2, ' ', 1n << 70, ' ', a > 1)
+println(1.10d + 2.205d, ' ', 10d / 3, ' ', 0.1d + 0.2d == 0.3d)
+println(bigint('99999999999999999999'), ' ', decimal(0.1), ' ', int(9.99d))
+try {
+    b := 1n + 1.5
+} catch p {
+    println('panicked: ', p.message)
+}
+for _, shift in [func() { return 1 << -1 }, func() { return 1 >> -1 }, func() { return 1n << -1n }] {
+    try {
+        shift()
+    } catch ArithmeticPanic p {
+        println('panicked: ', p.message)
+    }
+}
+`
+	const want = "15241578753238836750495351562536198787501905199875019052100\n" +
+		"1267650600228229401496703205376 -2 1180591620717411303424 true\n" +
+		"3.305 3.3333333333333333333333333333 true\n99999999999999999999 0.1 9\n" +
+		"panicked: Float values is not compatible with bigint and decimal values!\n" +
+		"panicked: Shifter is cannot should be negative!\npanicked: Shifter is cannot should be negative!\n" +
+		"panicked: Shifter is cannot should be negative!\n"
+	runBoth(t, code, want)
+}
+
+// TestStackTrace returns stack trace of panic by calls of functions,
+// caught errors have stack trace as text.
+func TestStackTrace(t *testing.T) {
+	const code = `package main
+
+func divide(x, y) {
+    return x / y
+}
+func average(list) {
+    sum := 0
+    for _, x in list {
+        sum += x
+    }
+    return divide(sum, len(list))
+}
+average([])
+`
+	const want = "[{divide 4 14} {average 11 18} {<main> 13 8}]"
+	// Frames of bytecode are in source file.
+	paths := writeBoth(t, fract.New(fract.Options{StdLib: "../stdlib"}), code)
+	for _, path := range paths {
+		err := fract.New(fract.Options{StdLib: "../stdlib"}).RunFile(path)
+		var cp fract.Panic
+		if !errors.As(err, &cp) {
+			t.Fatalf("%s: got %v", path, err)
+		}
+		var frames []string
+		for _, f := range cp.Trace {
+			if f.File != paths[0] {
+				t.Errorf("%s: file of frame %s is %s", path, f.Func, f.File)
+			}
+			frames = append(frames, fmt.Sprintf("{%s %d %d}", f.Func, f.Line, f.Column))
+		}
+		if got := "[" + strings.Join(frames, " ") + "]"; got != want {
+			t.Errorf("%s: got %s, want %s", path, got, want)
+		}
+	}
+	const caught = `package main
+
+func inner() { return 1 / 0 }
+func outer() { return inner() }
+try {
+    outer()
+} catch e {
+    println([ln.sub(0, ln.index(' ')) for ln in e.trace.split('\n')])
+}
+`
+	runBoth(t, caught, "[inner outer <main>]\n")
+}
+
+// TestTypedCatch catches panics by types,
+// panics that are not caught by types are raised again.
+func TestTypedCatch(t *testing.T) {
+	const code = `package main
+
+try {
+    try {
+        list := [1, 2, 3]
+        println(list[5])
+    } catch DivideByZeroPanic e {
+        println('divide by zero')
+    }
+} catch OutOfRangePanic e {
+    println(e.type, ' ', e.line, ':', e.column, ' ', e.message)
+}
+try {
+    x := 1 / 0
+} catch OutOfRangePanic _ {
+    println('out of range')
+} catch e {
+    println(e.type, ': ', e.message)
+}
+`
+	const want = "OutOfRangePanic 6:21 Index is out of range!\nDivideByZeroPanic: Divide by zero!\n"
+	runBoth(t, code, want)
+}
+
+// TestFinally runs finally blocks after try and catch blocks, also if they are
+// left by return, break or continue. Raised again panics keep their positions.
+func TestFinally(t *testing.T) {
+	const code = `package main
+
+func load(path) {
+    try {
+        if path == '' {
+            panic('path is empty', 'PathPanic')
+        }
+        return 'loaded ' + path
+    } catch PathPanic e {
+        println(e.type, ': ', e.message)
+        panic(e)
+    } finally {
+        println('finally')
+    }
+}
+println(load('file'))
+try {
+    load('')
+} catch e {
+    println('rethrown ', e.type, ' ', e.line, ':', e.column)
+}
+for _, i in range(1, 3) {
+    try {
+        if i == 1 { continue }
+        if i == 3 { break }
+        println('body ', i)
+    } finally {
+        println('finally ', i)
+    }
+}
+try {
+    panic('error')
+} catch e {
+    println(e.type, ': ', e.message)
+}
+`
+	const want = "finally\nloaded file\nPathPanic: path is empty\nfinally\nrethrown PathPanic 6:18\n" +
+		"finally 1\nbody 2\nfinally 2\nfinally 3\nPanic: error\n"
+	runBoth(t, code, want)
+}